@@ -0,0 +1,14 @@
+// Comando gopher-tasks: cliente de linha de comando da API de tarefas.
+package main
+
+import (
+	"os"
+
+	"github.com/rubenfabio/gopher-tasks/internal/delivery/cli"
+)
+
+func main() {
+    if err := cli.NewRootCmd().Execute(); err != nil {
+        os.Exit(1)
+    }
+}
@@ -0,0 +1,105 @@
+// Comando migrate: aplica as migrations embutidas no binário.
+//
+// Uso:
+//
+//	migrate up            aplica todas as pendentes
+//	migrate down [n]      desfaz as últimas n (padrão 1)
+//	migrate goto <versão> sobe/desce até a versão informada
+//	migrate status        lista as migrations e se já foram aplicadas
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/config"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/database"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/scripts/migrations"
+)
+
+const usage = "usage: migrate up | down [n] | goto <version> | status"
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, usage)
+        os.Exit(2)
+    }
+
+    cfg, err := config.Load("configs/config.yaml")
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+        os.Exit(1)
+    }
+    log := logger.New(cfg.Log.Level, cfg.Log.Format, os.Stdout)
+
+    db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN, 2, 1, time.Minute)
+    if err != nil {
+        log.WithField("error", err).Fatal("Failed to connect to database")
+    }
+    defer db.Close()
+
+    migrator, err := database.NewMigrator(db, migrations.FS, log)
+    if err != nil {
+        log.WithField("error", err).Fatal("Failed to load migrations")
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    if err := run(ctx, migrator, os.Args[1:]); err != nil {
+        log.WithField("error", err).Error("Migration failed")
+        stop()
+        db.Close()
+        os.Exit(1)
+    }
+}
+
+func run(ctx context.Context, m *database.Migrator, args []string) error {
+    switch args[0] {
+    case "up":
+        return m.Up(ctx)
+    case "down":
+        steps := 1
+        if len(args) > 1 {
+            n, err := strconv.Atoi(args[1])
+            if err != nil || n < 1 {
+                return fmt.Errorf("invalid number of steps %q", args[1])
+            }
+            steps = n
+        }
+        return m.Down(ctx, steps)
+    case "goto":
+        if len(args) < 2 {
+            return fmt.Errorf("goto requires a version")
+        }
+        version, err := strconv.Atoi(args[1])
+        if err != nil {
+            return fmt.Errorf("invalid version %q", args[1])
+        }
+        return m.Goto(ctx, version)
+    case "status":
+        statuses, err := m.Status(ctx)
+        if err != nil {
+            return err
+        }
+        tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+        fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED AT")
+        for _, s := range statuses {
+            appliedAt := "pending"
+            if s.AppliedAt != nil {
+                appliedAt = s.AppliedAt.Format(time.RFC3339)
+            }
+            fmt.Fprintf(tw, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+        }
+        return tw.Flush()
+    default:
+        return fmt.Errorf("unknown command %q (%s)", args[0], usage)
+    }
+}
@@ -3,22 +3,38 @@
 // @description API para gerenciamento de tarefas
 // @host        localhost:8080
 // @BasePath    /
+// @securityDefinitions.apikey BearerAuth
+// @in                         header
+// @name                       Authorization
+// @description                Token JWT no formato "Bearer <token>"
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
+	_ "time/tzdata" // fusos embutidos para recorrências, mesmo sem tzdata no sistema
 
 	"github.com/gorilla/mux"
 	_ "github.com/rubenfabio/gopher-tasks/docs" // swagger docs
 	httpdelivery "github.com/rubenfabio/gopher-tasks/internal/delivery/http"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/auth"
 	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/config"
 	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/database"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/eventbus"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/lifecycle"
 	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/notify"
 	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/persistence/postgres"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/webhook"
 	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+	"github.com/rubenfabio/gopher-tasks/scripts/migrations"
 	httpSwagger "github.com/swaggo/http-swagger" // swagger UI handler
 )
 
@@ -34,6 +50,11 @@ func main() {
     log := logger.New(cfg.Log.Level, cfg.Log.Format, os.Stdout)
     log.Info("Configuration loaded")
 
+    // Contexto cancelado em SIGINT/SIGTERM e grupo de workers de background
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+    workers := lifecycle.NewGroup(log)
+
     // 3. DB
     db, err := database.Open(
         cfg.Database.Driver,
@@ -47,14 +68,145 @@ func main() {
     }
     log.Info("Database connection established")
 
-    // 4. UseCases e Handler
-    taskRepo    := postgres.NewTaskRepo(db)
-    createUC    := usecase.NewCreateTaskUseCase(taskRepo)
-    listUC      := usecase.NewListTasksUseCase(taskRepo)
-    taskHandler := httpdelivery.NewTaskHandler(createUC, listUC, log)
+    if cfg.Database.MigrateOnStart {
+        migrator, err := database.NewMigrator(db, migrations.FS, log)
+        if err != nil {
+            log.WithField("error", err).Fatal("Failed to load migrations")
+        }
+        if err := migrator.Up(ctx); err != nil {
+            log.WithField("error", err).Fatal("Failed to apply migrations")
+        }
+        log.Info("Database schema is up to date")
+    }
+
+    // 4. Auth
+    tokens, err := auth.NewJWTService(cfg.Auth.JWTSecret, time.Duration(cfg.Auth.TokenExpiryMinutes)*time.Minute)
+    if err != nil {
+        log.WithField("error", err).Fatal("Invalid auth configuration")
+    }
+    hasher      := auth.NewBcryptHasher(0)
+    userRepo    := postgres.NewUserRepo(db, cfg.Database.QueryTimeout)
+    registerUC  := usecase.NewRegisterUserUseCase(userRepo, hasher)
+    loginUC     := usecase.NewLoginUserUseCase(userRepo, hasher, tokens)
+    authHandler := httpdelivery.NewAuthHandler(registerUC, loginUC, log)
+    userHandler := httpdelivery.NewUserHandler(
+        usecase.NewGetCurrentUserUseCase(userRepo),
+        usecase.NewUpdateCurrentUserUseCase(userRepo),
+        log,
+    )
+
+    // 5. UseCases e Handler
+    taskRepo    := postgres.NewTaskRepo(db, cfg.Database.QueryTimeout)
+    projectRepo := postgres.NewProjectRepo(db, cfg.Database.QueryTimeout)
+    depRepo     := postgres.NewTaskDependencyRepo(db, cfg.Database.QueryTimeout)
+    webhookRepo := postgres.NewWebhookRepo(db, cfg.Database.QueryTimeout)
+
+    // Os use cases que gravam em mais de uma tabela abrem uma transação
+    // pelo TxManager; os repositórios acima a enxergam pelo contexto
+    txManager, err := postgres.NewTxManager(db, cfg.Database.TxIsolation, cfg.Database.TxMaxAttempts)
+    if err != nil {
+        log.WithField("error", err).Fatal("Invalid transaction configuration")
+    }
+
+    // Os use cases gravam os eventos de Task na outbox, na transação da
+    // mudança, e o relay os leva aos sinks: webhooks, streams ao vivo e,
+    // se configurado, um arquivo NDJSON. Com várias réplicas, cada uma
+    // repassa às outras o que publica. Sem o relay nenhum evento sairia da
+    // outbox, então ele roda sempre
+    outboxRepo := postgres.NewOutboxRepo(db, cfg.Database.QueryTimeout)
+    bus        := eventbus.New(cfg.Stream.LogSize, cfg.Stream.Buffer)
+    sinks      := []usecase.OutboxSink{
+        {Name: "webhooks", Publisher: usecase.NewWebhookEventPublisher(webhookRepo)},
+        {Name: "stream", Publisher: bus},
+    }
+    if cfg.Stream.Postgres {
+        bridge := eventbus.NewPostgresBridge(db, cfg.Database.DSN, log)
+        sinks = append(sinks, usecase.OutboxSink{Name: "replicas", Publisher: bridge})
+        workers.Go("event-listener", func(ctx context.Context) {
+            bridge.Listen(ctx, bus)
+        })
+    }
+    if cfg.Outbox.File != "" {
+        fileSink, err := eventbus.NewFileSink(cfg.Outbox.File)
+        if err != nil {
+            log.WithField("error", err).Fatal("Failed to open outbox file")
+        }
+        defer fileSink.Close()
+        sinks = append(sinks, usecase.OutboxSink{Name: "file", Publisher: fileSink})
+    }
+
+    createUC    := usecase.NewCreateTaskUseCase(taskRepo, projectRepo, userRepo, outboxRepo, txManager)
+    listUC      := usecase.NewListTasksUseCase(taskRepo, cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit)
+    getUC       := usecase.NewGetTaskUseCase(taskRepo)
+    updateUC    := usecase.NewUpdateTaskUseCase(taskRepo, projectRepo, depRepo, userRepo, outboxRepo, txManager)
+    patchUC     := usecase.NewPatchTaskUseCase(taskRepo, projectRepo, depRepo, userRepo, outboxRepo, txManager)
+    deleteUC    := usecase.NewDeleteTaskUseCase(taskRepo, depRepo, outboxRepo, txManager)
+    childrenUC  := usecase.NewListTaskChildrenUseCase(taskRepo, listUC)
+    treeUC      := usecase.NewGetTaskTreeUseCase(taskRepo)
+    occurUC     := usecase.NewPreviewTaskOccurrencesUseCase(taskRepo)
+    taskHandler := httpdelivery.NewTaskHandler(createUC, listUC, getUC, updateUC, patchUC, deleteUC, childrenUC, treeUC, occurUC, cfg.Concurrency.RequireIfMatch, log)
+
+    // Operações em lote rodam os mesmos use cases, em uma transação no modo atômico
+    batchUC      := usecase.NewBatchTasksUseCase(taskRepo, projectRepo, depRepo, userRepo, outboxRepo, txManager, cfg.Batch.MaxOperations)
+    batchHandler := httpdelivery.NewTaskBatchHandler(batchUC, usecase.NewBulkUpdateTasksUseCase(batchUC), cfg.Concurrency.RequireIfMatch, log)
+
+    streamHandler := httpdelivery.NewTaskStreamHandler(usecase.NewStreamTaskEventsUseCase(bus), cfg.Stream.Heartbeat, log)
+
+    tagRepo    := postgres.NewTagRepo(db, cfg.Database.QueryTimeout)
+    tagHandler := httpdelivery.NewTagHandler(
+        usecase.NewCreateTagUseCase(tagRepo),
+        usecase.NewListTagsUseCase(tagRepo),
+        usecase.NewGetTagUseCase(tagRepo),
+        usecase.NewUpdateTagUseCase(tagRepo),
+        usecase.NewDeleteTagUseCase(tagRepo),
+        log,
+    )
+
+    dependencyHandler := httpdelivery.NewDependencyHandler(
+        usecase.NewListTaskDependenciesUseCase(taskRepo, depRepo),
+        usecase.NewAddTaskDependencyUseCase(taskRepo, depRepo, txManager),
+        usecase.NewRemoveTaskDependencyUseCase(taskRepo, depRepo, txManager),
+        usecase.NewGetCriticalPathUseCase(projectRepo, depRepo),
+        log,
+    )
+
+    reminderRepo    := postgres.NewReminderRepo(db, cfg.Database.QueryTimeout)
+    reminderHandler := httpdelivery.NewReminderHandler(
+        usecase.NewCreateReminderUseCase(taskRepo, reminderRepo),
+        usecase.NewListRemindersUseCase(taskRepo, reminderRepo),
+        usecase.NewDeleteReminderUseCase(reminderRepo),
+        log,
+    )
+
+    projectHandler := httpdelivery.NewProjectHandler(
+        usecase.NewCreateProjectUseCase(projectRepo),
+        usecase.NewListProjectsUseCase(projectRepo),
+        usecase.NewGetProjectUseCase(projectRepo),
+        usecase.NewUpdateProjectUseCase(projectRepo),
+        usecase.NewDeleteProjectUseCase(projectRepo),
+        usecase.NewListProjectTasksUseCase(projectRepo, listUC),
+        log,
+    )
+
+    webhookHandler := httpdelivery.NewWebhookHandler(
+        usecase.NewCreateWebhookUseCase(webhookRepo),
+        usecase.NewListWebhooksUseCase(webhookRepo),
+        usecase.NewGetWebhookUseCase(webhookRepo),
+        usecase.NewUpdateWebhookUseCase(webhookRepo),
+        usecase.NewDeleteWebhookUseCase(webhookRepo),
+        usecase.NewListWebhookDeliveriesUseCase(webhookRepo),
+        usecase.NewRedeliverWebhookUseCase(webhookRepo),
+        log,
+    )
 
-    // 5. Router
+    // Requisições de escrita com Idempotency-Key são executadas uma única vez
+    idempotencyRepo := postgres.NewIdempotencyRepo(db, cfg.Database.QueryTimeout)
+    idempotency     := httpdelivery.Idempotency(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.Lease, log)
+
+    // 6. Router
     r := mux.NewRouter()
+    r.NotFoundHandler = httpdelivery.NotFoundHandler()
+    r.MethodNotAllowedHandler = httpdelivery.MethodNotAllowedHandler()
 
     // Swagger UI endpoint em /swagger/index.html
     r.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
@@ -65,7 +217,7 @@ func main() {
     }).Methods(http.MethodGet)
     // Health-check
     r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-        if err := db.Ping(); err != nil {
+        if err := db.PingContext(r.Context()); err != nil {
             log.WithField("error", err).Error("Database ping failed")
             http.Error(w, "service unavailable", http.StatusServiceUnavailable)
             return
@@ -74,12 +226,179 @@ func main() {
         w.Write([]byte("gopher-tasks is running and DB is healthy!"))
     }).Methods(http.MethodGet)
 
+    // Cadastro e login (públicos)
+    r.HandleFunc("/auth/register", authHandler.Register).Methods(http.MethodPost)
+    r.HandleFunc("/auth/login", authHandler.Login).Methods(http.MethodPost)
+
+    // Perfil do usuário autenticado
+    users := r.PathPrefix("/users").Subrouter()
+    users.Use(httpdelivery.RequireAuth(tokens), idempotency)
+    users.HandleFunc("/me", userHandler.Me).Methods(http.MethodGet)
+    users.HandleFunc("/me", userHandler.UpdateMe).Methods(http.MethodPatch)
+
+    // Endpoints de task exigem token e enxergam apenas as tasks do usuário
+    tasks := r.PathPrefix("/tasks").Subrouter()
+    tasks.Use(httpdelivery.RequireAuth(tokens), idempotency)
     // Create task
-    r.HandleFunc("/tasks", taskHandler.Create).Methods(http.MethodPost)
+    tasks.HandleFunc("", taskHandler.Create).Methods(http.MethodPost)
     // List tasks
-    r.HandleFunc("/tasks", taskHandler.List).Methods(http.MethodGet)
+    tasks.HandleFunc("", taskHandler.List).Methods(http.MethodGet)
+    // Eventos ao vivo (SSE); registrado antes de /{id}
+    tasks.HandleFunc("/stream", streamHandler.Stream).Methods(http.MethodGet)
+    // Get, replace, patch e delete de uma task
+    tasks.HandleFunc("/{id}", taskHandler.Get).Methods(http.MethodGet)
+    tasks.HandleFunc("/{id}", taskHandler.Update).Methods(http.MethodPut)
+    tasks.HandleFunc("/{id}", taskHandler.Patch).Methods(http.MethodPatch)
+    tasks.HandleFunc("/{id}", taskHandler.Delete).Methods(http.MethodDelete)
+    // Subtarefas
+    tasks.HandleFunc("/{id}/children", taskHandler.Children).Methods(http.MethodGet)
+    tasks.HandleFunc("/{id}/tree", taskHandler.Tree).Methods(http.MethodGet)
+    // Prévia das próximas ocorrências de uma task recorrente
+    tasks.HandleFunc("/{id}/occurrences", taskHandler.Occurrences).Methods(http.MethodGet)
+    // Dependências ("blocked by")
+    tasks.HandleFunc("/{id}/dependencies", dependencyHandler.List).Methods(http.MethodGet)
+    tasks.HandleFunc("/{id}/dependencies", dependencyHandler.Add).Methods(http.MethodPost)
+    tasks.HandleFunc("/{id}/dependencies/{dependsOnId}", dependencyHandler.Remove).Methods(http.MethodDelete)
+    // Lembretes
+    tasks.HandleFunc("/{id}/reminders", reminderHandler.List).Methods(http.MethodGet)
+    tasks.HandleFunc("/{id}/reminders", reminderHandler.Create).Methods(http.MethodPost)
+    tasks.HandleFunc("/{id}/reminders/{reminderId}", reminderHandler.Delete).Methods(http.MethodDelete)
+
+    // Operações em lote e atualização por filtro; fora do subrouter /tasks
+    // porque o mux exige que os caminhos dele comecem com /
+    taskBatch := r.NewRoute().Subrouter()
+    taskBatch.Use(httpdelivery.RequireAuth(tokens), idempotency)
+    taskBatch.HandleFunc("/tasks:batch", batchHandler.Batch).Methods(http.MethodPost)
+    taskBatch.HandleFunc("/tasks:bulk-update", batchHandler.BulkUpdate).Methods(http.MethodPost)
 
-    // 6. Start server
+    // Tags do usuário
+    tags := r.PathPrefix("/tags").Subrouter()
+    tags.Use(httpdelivery.RequireAuth(tokens), idempotency)
+    tags.HandleFunc("", tagHandler.Create).Methods(http.MethodPost)
+    tags.HandleFunc("", tagHandler.List).Methods(http.MethodGet)
+    tags.HandleFunc("/{id}", tagHandler.Get).Methods(http.MethodGet)
+    tags.HandleFunc("/{id}", tagHandler.Update).Methods(http.MethodPatch)
+    tags.HandleFunc("/{id}", tagHandler.Delete).Methods(http.MethodDelete)
+
+    // Projetos e suas tasks
+    projects := r.PathPrefix("/projects").Subrouter()
+    projects.Use(httpdelivery.RequireAuth(tokens), idempotency)
+    projects.HandleFunc("", projectHandler.Create).Methods(http.MethodPost)
+    projects.HandleFunc("", projectHandler.List).Methods(http.MethodGet)
+    projects.HandleFunc("/{id}", projectHandler.Get).Methods(http.MethodGet)
+    projects.HandleFunc("/{id}", projectHandler.Update).Methods(http.MethodPatch)
+    projects.HandleFunc("/{id}", projectHandler.Delete).Methods(http.MethodDelete)
+    projects.HandleFunc("/{id}/archive", projectHandler.Archive).Methods(http.MethodPost)
+    projects.HandleFunc("/{id}/unarchive", projectHandler.Unarchive).Methods(http.MethodPost)
+    projects.HandleFunc("/{id}/tasks", projectHandler.Tasks).Methods(http.MethodGet)
+    projects.HandleFunc("/{id}/critical-path", dependencyHandler.CriticalPath).Methods(http.MethodGet)
+
+    // Webhooks do usuário e o histórico de entregas
+    webhooks := r.PathPrefix("/webhooks").Subrouter()
+    webhooks.Use(httpdelivery.RequireAuth(tokens), idempotency)
+    webhooks.HandleFunc("", webhookHandler.Create).Methods(http.MethodPost)
+    webhooks.HandleFunc("", webhookHandler.List).Methods(http.MethodGet)
+    webhooks.HandleFunc("/{id}", webhookHandler.Get).Methods(http.MethodGet)
+    webhooks.HandleFunc("/{id}", webhookHandler.Update).Methods(http.MethodPatch)
+    webhooks.HandleFunc("/{id}", webhookHandler.Delete).Methods(http.MethodDelete)
+    webhooks.HandleFunc("/{id}/deliveries", webhookHandler.Deliveries).Methods(http.MethodGet)
+    webhooks.HandleFunc("/{id}/deliveries/{deliveryId}/redeliver", webhookHandler.Redeliver).Methods(http.MethodPost)
+
+    // 7. Scheduler de lembretes: cada réplica varre os vencidos; SKIP LOCKED
+    // garante que duas réplicas não entreguem o mesmo lembrete
+    if cfg.Reminders.Enabled {
+        notifiers := notify.Multi{notify.NewLogNotifier(log)}
+        if cfg.Notify.Webhook.URL != "" {
+            notifiers = append(notifiers, notify.NewWebhookNotifier(cfg.Notify.Webhook.URL, cfg.Notify.Webhook.Timeout))
+        }
+        if cfg.Notify.SMTP.Host != "" {
+            smtp := cfg.Notify.SMTP
+            notifiers = append(notifiers, notify.NewSMTPNotifier(smtp.Host, smtp.Port, smtp.Username, smtp.Password, smtp.From))
+        }
+        deliverUC := usecase.NewDeliverRemindersUseCase(
+            reminderRepo,
+            notifiers,
+            cfg.Reminders.BatchSize,
+            cfg.Reminders.MaxAttempts,
+            cfg.Reminders.Lease,
+        )
+        workers.Go("reminders", lifecycle.Every(cfg.Reminders.PollInterval, func(ctx context.Context) {
+            res, err := deliverUC.Execute(ctx)
+            if err != nil && ctx.Err() == nil {
+                log.WithField("error", err).Error("Failed to deliver reminders")
+            }
+            if res != (usecase.ReminderDelivery{}) {
+                log.WithField("sent", res.Sent).
+                    WithField("retried", res.Retried).
+                    WithField("failed", res.Failed).
+                    Info("Reminders delivered")
+            }
+        }))
+    }
+
+    // 8. Entrega de webhooks: mesma estratégia de SKIP LOCKED dos lembretes;
+    // falhas voltam para a fila com espera exponencial até virarem dead
+    if cfg.Webhooks.Enabled {
+        deliverUC := usecase.NewDeliverWebhooksUseCase(
+            webhookRepo,
+            webhook.NewSender(cfg.Webhooks.Timeout),
+            cfg.Webhooks.BatchSize,
+            cfg.Webhooks.MaxAttempts,
+            cfg.Webhooks.Lease,
+        )
+        workers.Go("webhooks", lifecycle.Every(cfg.Webhooks.PollInterval, func(ctx context.Context) {
+            res, err := deliverUC.Execute(ctx)
+            if err != nil && ctx.Err() == nil {
+                log.WithField("error", err).Error("Failed to deliver webhooks")
+            }
+            if res != (usecase.WebhookDeliveryResult{}) {
+                log.WithField("delivered", res.Delivered).
+                    WithField("retried", res.Retried).
+                    WithField("dead", res.Dead).
+                    Info("Webhooks delivered")
+            }
+        }))
+    }
+
+    // 9. Relay da outbox: publica nos sinks os eventos gravados pelos use
+    // cases, em ordem por Task; falhas são repetidas com espera exponencial
+    relayUC := usecase.NewRelayOutboxUseCase(outboxRepo, sinks, cfg.Outbox.BatchSize, cfg.Outbox.Lease)
+    workers.Go("outbox-relay", lifecycle.Every(cfg.Outbox.PollInterval, func(ctx context.Context) {
+        res, err := relayUC.Execute(ctx)
+        if err != nil && ctx.Err() == nil {
+            log.WithField("error", err).Error("Failed to relay outbox events")
+        }
+        if res.Failed > 0 || res.Deferred > 0 {
+            log.WithField("published", res.Published).
+                WithField("failed", res.Failed).
+                WithField("deferred", res.Deferred).
+                Warn("Some outbox events were not published")
+        }
+    }))
+    purgeOutboxUC := usecase.NewPurgeOutboxUseCase(outboxRepo, cfg.Outbox.Retention, cfg.Outbox.BatchSize)
+    workers.Go("outbox-gc", lifecycle.Every(cfg.Outbox.GCInterval, func(ctx context.Context) {
+        n, err := purgeOutboxUC.Execute(ctx)
+        if err != nil && ctx.Err() == nil {
+            log.WithField("error", err).Error("Failed to purge outbox events")
+        }
+        if n > 0 {
+            log.WithField("deleted", n).Info("Published outbox events purged")
+        }
+    }))
+
+    // 10. Coleta das chaves de idempotência expiradas
+    purgeUC := usecase.NewPurgeIdempotencyKeysUseCase(idempotencyRepo, cfg.Idempotency.BatchSize)
+    workers.Go("idempotency-gc", lifecycle.Every(cfg.Idempotency.GCInterval, func(ctx context.Context) {
+        n, err := purgeUC.Execute(ctx)
+        if err != nil && ctx.Err() == nil {
+            log.WithField("error", err).Error("Failed to purge idempotency keys")
+        }
+        if n > 0 {
+            log.WithField("deleted", n).Info("Expired idempotency keys purged")
+        }
+    }))
+
+    // 11. Start server
     addr := fmt.Sprintf(":%d", cfg.Server.Port)
     srv := &http.Server{
         Addr:         addr,
@@ -88,8 +407,51 @@ func main() {
         WriteTimeout: cfg.Server.WriteTimeout,
     }
 
-    log.Infof("Starting server on %s", addr)
-    if err := srv.ListenAndServe(); err != nil {
-        log.WithField("error", err).Fatal("Server failed")
+    // Streams SSE não terminam sozinhos: encerra-os para o Shutdown não esperar por eles
+    srv.RegisterOnShutdown(bus.Close)
+
+    serverErr := make(chan error, 1)
+    go func() {
+        log.Infof("Starting server on %s", addr)
+        if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            serverErr <- err
+        }
+    }()
+
+    // 12. Aguarda SIGINT/SIGTERM (ou falha do listener) e faz o shutdown gracioso
+    select {
+    case <-ctx.Done():
+        log.Info("Shutdown signal received")
+    case err := <-serverErr:
+        log.WithField("error", err).Error("Server failed")
+    }
+    stop()
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+    defer cancel()
+
+    // O servidor e os workers param em paralelo, cada um com o prazo
+    // inteiro: um dreno lento das requisições não tira o tempo dos workers
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        // Para de aceitar conexões e espera as requisições em andamento
+        if err := srv.Shutdown(shutdownCtx); err != nil {
+            log.WithField("error", err).Error("Server shutdown did not complete in time")
+        }
+    }()
+    go func() {
+        defer wg.Done()
+        // Para os workers de background
+        if err := workers.Stop(shutdownCtx); err != nil {
+            log.WithField("error", err).Error("Background workers did not stop in time")
+        }
+    }()
+    wg.Wait()
+    // Fecha o pool do banco por último
+    if err := db.Close(); err != nil {
+        log.WithField("error", err).Error("Failed to close database")
     }
+    log.Info("Server stopped")
 }
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newLoginCmd(a *app) *cobra.Command {
+    var email, password string
+    cmd := &cobra.Command{
+        Use:   "login",
+        Short: "Autentica e salva o token de acesso",
+        RunE: func(cmd *cobra.Command, args []string) error {
+            if password == "" {
+                p, err := readLine(cmd, "Password: ")
+                if err != nil {
+                    return err
+                }
+                password = p
+            }
+
+            res, err := NewClient(a.cfg.Server, "").Login(cmd.Context(), email, password)
+            if err != nil {
+                return err
+            }
+
+            a.cfg.Email = res.User.Email
+            a.cfg.Token = res.AccessToken
+            a.cfg.ExpiresAt = res.ExpiresAt
+            if err := a.cfg.Save(a.configPath); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Logged in as %s (token expires %s)\n",
+                res.User.Email, res.ExpiresAt.Local().Format("2006-01-02 15:04"))
+            return nil
+        },
+    }
+    cmd.Flags().StringVar(&email, "email", "", "e-mail da conta")
+    cmd.Flags().StringVar(&password, "password", "", "senha (lida da entrada padrão se omitida)")
+    cmd.MarkFlagRequired("email")
+    return cmd
+}
+
+func newRegisterCmd(a *app) *cobra.Command {
+    var email, name, password, timezone string
+    cmd := &cobra.Command{
+        Use:   "register",
+        Short: "Cria uma conta na API",
+        RunE: func(cmd *cobra.Command, args []string) error {
+            if password == "" {
+                p, err := readLine(cmd, "Password: ")
+                if err != nil {
+                    return err
+                }
+                password = p
+            }
+
+            user, err := NewClient(a.cfg.Server, "").Register(cmd.Context(), email, name, password, timezone)
+            if err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Account %s created; run `gopher-tasks login --email %s`\n", user.ID, user.Email)
+            return nil
+        },
+    }
+    cmd.Flags().StringVar(&email, "email", "", "e-mail da conta")
+    cmd.Flags().StringVar(&name, "name", "", "nome de exibição")
+    cmd.Flags().StringVar(&password, "password", "", "senha (lida da entrada padrão se omitida)")
+    cmd.Flags().StringVar(&timezone, "timezone", "", "fuso IANA usado nas tasks recorrentes (padrão UTC)")
+    cmd.MarkFlagRequired("email")
+    return cmd
+}
+
+func newLogoutCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "logout",
+        Short: "Remove o token salvo",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            a.cfg.Token = ""
+            a.cfg.Email = ""
+            a.cfg.ExpiresAt = time.Time{}
+            return a.cfg.Save(a.configPath)
+        },
+    }
+}
+
+func newConfigCmd(a *app) *cobra.Command {
+    cmd := &cobra.Command{
+        Use:   "config",
+        Short: "Mostra ou altera a configuração da CLI",
+    }
+    cmd.AddCommand(
+        &cobra.Command{
+            Use:   "set-server <url>",
+            Short: "Define a URL da API",
+            Args:  cobra.ExactArgs(1),
+            RunE: func(cmd *cobra.Command, args []string) error {
+                a.cfg.Server = strings.TrimRight(args[0], "/")
+                return a.cfg.Save(a.configPath)
+            },
+        },
+        &cobra.Command{
+            Use:   "show",
+            Short: "Mostra a configuração atual",
+            Args:  cobra.NoArgs,
+            RunE: func(cmd *cobra.Command, args []string) error {
+                out := cmd.OutOrStdout()
+                fmt.Fprintf(out, "file:   %s\n", a.configPath)
+                fmt.Fprintf(out, "server: %s\n", a.cfg.Server)
+                if a.cfg.Token != "" {
+                    fmt.Fprintf(out, "user:   %s (token expires %s)\n",
+                        a.cfg.Email, a.cfg.ExpiresAt.Local().Format("2006-01-02 15:04"))
+                } else {
+                    fmt.Fprintln(out, "user:   not logged in")
+                }
+                return nil
+            },
+        },
+    )
+    return cmd
+}
+
+// readLine lê uma linha da entrada do comando exibindo o prompt em stderr.
+func readLine(cmd *cobra.Command, prompt string) (string, error) {
+    fmt.Fprint(cmd.ErrOrStderr(), prompt)
+    line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+    line = strings.TrimRight(line, "\r\n")
+    if line == "" && err != nil {
+        return "", errors.New("no input provided")
+    }
+    return line, nil
+}
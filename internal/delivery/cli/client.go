@@ -0,0 +1,466 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Client conversa com a API HTTP do gopher-tasks.
+type Client struct {
+    BaseURL string
+    Token   string
+    HTTP    *http.Client
+}
+
+// NewClient cria um cliente com timeout padrão.
+func NewClient(baseURL, token string) *Client {
+    return &Client{
+        BaseURL: strings.TrimRight(baseURL, "/"),
+        Token:   token,
+        HTTP:    &http.Client{Timeout: 30 * time.Second},
+    }
+}
+
+// APIError é um erro problem+json retornado pela API.
+type APIError struct {
+    Status int                 `json:"status"`
+    Title  string              `json:"title"`
+    Detail string              `json:"detail"`
+    Errors []domain.FieldError `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+    msg := e.Detail
+    if msg == "" {
+        msg = e.Title
+    }
+    for _, f := range e.Errors {
+        msg += fmt.Sprintf("\n  - %s: %s", f.Field, f.Message)
+    }
+    return fmt.Sprintf("%s (HTTP %d)", msg, e.Status)
+}
+
+// LoginResult é a resposta de POST /auth/login.
+type LoginResult struct {
+    AccessToken string       `json:"access_token"`
+    ExpiresAt   time.Time    `json:"expires_at"`
+    User        *domain.User `json:"user"`
+}
+
+// TaskInput é o payload de criação de task.
+type TaskInput struct {
+    ProjectID   string   `json:"project_id,omitempty"`
+    ParentID    string   `json:"parent_id,omitempty"`
+    Title       string   `json:"title"`
+    Description string   `json:"description,omitempty"`
+    Priority    string   `json:"priority,omitempty"`
+    DueDate     string   `json:"due_date,omitempty"`
+    Tags        []string `json:"tags,omitempty"`
+    RRule       string   `json:"rrule,omitempty"`
+}
+
+// Register cadastra um usuário.
+func (c *Client) Register(ctx context.Context, email, name, password, timezone string) (*domain.User, error) {
+    var user domain.User
+    body := map[string]string{"email": email, "name": name, "password": password, "timezone": timezone}
+    if err := c.do(ctx, http.MethodPost, "/auth/register", nil, body, &user); err != nil {
+        return nil, err
+    }
+    return &user, nil
+}
+
+// Login troca credenciais por um token.
+func (c *Client) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+    var out LoginResult
+    body := map[string]string{"email": email, "password": password}
+    if err := c.do(ctx, http.MethodPost, "/auth/login", nil, body, &out); err != nil {
+        return nil, err
+    }
+    return &out, nil
+}
+
+// CreateTask cria uma task.
+func (c *Client) CreateTask(ctx context.Context, in TaskInput) (*domain.Task, error) {
+    var task domain.Task
+    if err := c.do(ctx, http.MethodPost, "/tasks", nil, in, &task); err != nil {
+        return nil, err
+    }
+    return &task, nil
+}
+
+// TaskList é uma página de GET /tasks.
+type TaskList struct {
+    Data []*domain.Task `json:"data"`
+    Page struct {
+        Limit      int    `json:"limit"`
+        NextCursor string `json:"next_cursor"`
+        PrevCursor string `json:"prev_cursor"`
+        Total      *int   `json:"total"`
+    } `json:"page"`
+}
+
+// ListTasks lista uma página de tasks repassando os filtros (e o cursor)
+// como query string.
+func (c *Client) ListTasks(ctx context.Context, query url.Values) (*TaskList, error) {
+    var list TaskList
+    if err := c.do(ctx, http.MethodGet, "/tasks", query, nil, &list); err != nil {
+        return nil, err
+    }
+    return &list, nil
+}
+
+// WatchTasks acompanha GET /tasks/stream e chama fn a cada evento até ctx
+// terminar ou o servidor fechar o stream; um evento reset chega como nil.
+// lastEventID retoma de onde a conexão anterior parou, e o ID do último
+// evento recebido é devolvido para a próxima.
+func (c *Client) WatchTasks(ctx context.Context, query url.Values, lastEventID string, fn func(*domain.Event) error) (string, error) {
+    u := c.BaseURL + "/tasks/stream"
+    if len(query) > 0 {
+        u += "?" + query.Encode()
+    }
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+    if err != nil {
+        return lastEventID, err
+    }
+    req.Header.Set("Accept", "text/event-stream")
+    req.Header.Set("Authorization", "Bearer "+c.Token)
+    if lastEventID != "" {
+        req.Header.Set("Last-Event-ID", lastEventID)
+    }
+
+    // Sem o timeout do cliente: o stream fica aberto indefinidamente
+    stream := &http.Client{Transport: c.HTTP.Transport}
+    resp, err := stream.Do(req)
+    if err != nil {
+        return lastEventID, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode >= 400 {
+        apiErr := &APIError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+        json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(apiErr)
+        return lastEventID, apiErr
+    }
+
+    var id, typ, data string
+    scanner := bufio.NewScanner(resp.Body)
+    scanner.Buffer(make([]byte, 64*1024), 1<<20)
+    for scanner.Scan() {
+        field, value, _ := strings.Cut(scanner.Text(), ":")
+        value = strings.TrimPrefix(value, " ")
+        switch field {
+        case "id":
+            id = value
+        case "event":
+            typ = value
+        case "data":
+            data = value
+        case "":
+            // Linha em branco encerra a mensagem; comentários (heartbeats) também caem aqui
+            if data == "" {
+                continue
+            }
+            var e *domain.Event
+            if typ != "reset" {
+                e = &domain.Event{}
+                if err := json.Unmarshal([]byte(data), e); err != nil {
+                    return lastEventID, err
+                }
+            }
+            if err := fn(e); err != nil {
+                return lastEventID, err
+            }
+            if id != "" {
+                lastEventID = id
+            }
+            id, typ, data = "", "", ""
+        }
+    }
+    return lastEventID, scanner.Err()
+}
+
+// GetTask busca uma task pelo ID.
+func (c *Client) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+    var task domain.Task
+    if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id), nil, nil, &task); err != nil {
+        return nil, err
+    }
+    return &task, nil
+}
+
+// GetTaskTree busca uma task com todas as suas subtarefas.
+func (c *Client) GetTaskTree(ctx context.Context, id string) (*domain.TaskNode, error) {
+    var tree domain.TaskNode
+    if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id)+"/tree", nil, nil, &tree); err != nil {
+        return nil, err
+    }
+    return &tree, nil
+}
+
+// AddTaskDependency faz a task depender de outra.
+func (c *Client) AddTaskDependency(ctx context.Context, id, dependsOnID string) (*domain.TaskDependencies, error) {
+    var deps domain.TaskDependencies
+    body := map[string]string{"depends_on_id": dependsOnID}
+    if err := c.do(ctx, http.MethodPost, "/tasks/"+url.PathEscape(id)+"/dependencies", nil, body, &deps); err != nil {
+        return nil, err
+    }
+    return &deps, nil
+}
+
+// RemoveTaskDependency remove um pré-requisito da task.
+func (c *Client) RemoveTaskDependency(ctx context.Context, id, dependsOnID string) error {
+    path := "/tasks/" + url.PathEscape(id) + "/dependencies/" + url.PathEscape(dependsOnID)
+    return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// ListReminders lista os lembretes da task.
+func (c *Client) ListReminders(ctx context.Context, id string) ([]*domain.Reminder, error) {
+    var reminders []*domain.Reminder
+    if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id)+"/reminders", nil, nil, &reminders); err != nil {
+        return nil, err
+    }
+    return reminders, nil
+}
+
+// ReminderInput é o corpo de POST /tasks/{id}/reminders: at ou before_minutes.
+type ReminderInput struct {
+    At            string `json:"at,omitempty"`
+    BeforeMinutes *int   `json:"before_minutes,omitempty"`
+}
+
+// CreateReminder agenda um lembrete para a task.
+func (c *Client) CreateReminder(ctx context.Context, id string, in ReminderInput) (*domain.Reminder, error) {
+    var reminder domain.Reminder
+    if err := c.do(ctx, http.MethodPost, "/tasks/"+url.PathEscape(id)+"/reminders", nil, in, &reminder); err != nil {
+        return nil, err
+    }
+    return &reminder, nil
+}
+
+// DeleteReminder cancela um lembrete da task.
+func (c *Client) DeleteReminder(ctx context.Context, id, reminderID string) error {
+    path := "/tasks/" + url.PathEscape(id) + "/reminders/" + url.PathEscape(reminderID)
+    return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// TaskOccurrences é a prévia de GET /tasks/{id}/occurrences.
+type TaskOccurrences struct {
+    Recurrence  *domain.TaskRecurrence `json:"recurrence"`
+    Occurrences []time.Time            `json:"occurrences"`
+}
+
+// PreviewTaskOccurrences lista as próximas ocorrências de uma task recorrente.
+func (c *Client) PreviewTaskOccurrences(ctx context.Context, id string, count int) (*TaskOccurrences, error) {
+    var out TaskOccurrences
+    query := url.Values{}
+    if count > 0 {
+        query.Set("count", strconv.Itoa(count))
+    }
+    if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id)+"/occurrences", query, nil, &out); err != nil {
+        return nil, err
+    }
+    return &out, nil
+}
+
+// PatchTask altera apenas os campos informados.
+func (c *Client) PatchTask(ctx context.Context, id string, fields map[string]interface{}) (*domain.Task, error) {
+    var task domain.Task
+    if err := c.do(ctx, http.MethodPatch, "/tasks/"+url.PathEscape(id), nil, fields, &task); err != nil {
+        return nil, err
+    }
+    return &task, nil
+}
+
+// ListTags lista as tags do usuário.
+func (c *Client) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+    var tags []*domain.Tag
+    if err := c.do(ctx, http.MethodGet, "/tags", nil, nil, &tags); err != nil {
+        return nil, err
+    }
+    return tags, nil
+}
+
+// CreateTag cria uma tag.
+func (c *Client) CreateTag(ctx context.Context, name, color string) (*domain.Tag, error) {
+    var tag domain.Tag
+    body := map[string]string{"name": name, "color": color}
+    if err := c.do(ctx, http.MethodPost, "/tags", nil, body, &tag); err != nil {
+        return nil, err
+    }
+    return &tag, nil
+}
+
+// DeleteTag remove uma tag.
+func (c *Client) DeleteTag(ctx context.Context, id string) error {
+    return c.do(ctx, http.MethodDelete, "/tags/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ListProjects lista os projetos do usuário.
+func (c *Client) ListProjects(ctx context.Context, includeArchived bool) ([]*domain.Project, error) {
+    var projects []*domain.Project
+    query := url.Values{}
+    if includeArchived {
+        query.Set("include_archived", "true")
+    }
+    if err := c.do(ctx, http.MethodGet, "/projects", query, nil, &projects); err != nil {
+        return nil, err
+    }
+    return projects, nil
+}
+
+// CreateProject cria um projeto.
+func (c *Client) CreateProject(ctx context.Context, name, description, color string) (*domain.Project, error) {
+    var project domain.Project
+    body := map[string]string{"name": name, "description": description, "color": color}
+    if err := c.do(ctx, http.MethodPost, "/projects", nil, body, &project); err != nil {
+        return nil, err
+    }
+    return &project, nil
+}
+
+// SetProjectArchived arquiva ou reativa um projeto.
+func (c *Client) SetProjectArchived(ctx context.Context, id string, archived bool) (*domain.Project, error) {
+    var project domain.Project
+    action := "/archive"
+    if !archived {
+        action = "/unarchive"
+    }
+    if err := c.do(ctx, http.MethodPost, "/projects/"+url.PathEscape(id)+action, nil, nil, &project); err != nil {
+        return nil, err
+    }
+    return &project, nil
+}
+
+// DeleteProject remove um projeto; com cascade remove também suas tasks.
+func (c *Client) DeleteProject(ctx context.Context, id string, cascade bool) error {
+    query := url.Values{}
+    if cascade {
+        query.Set("cascade", "true")
+    }
+    return c.do(ctx, http.MethodDelete, "/projects/"+url.PathEscape(id), query, nil, nil)
+}
+
+// DeleteTask remove uma task.
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+    return c.do(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// do executa a requisição, decodifica out em caso de sucesso e converte
+// respostas de erro em *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+    u := c.BaseURL + path
+    if len(query) > 0 {
+        u += "?" + query.Encode()
+    }
+
+    var body io.Reader
+    if in != nil {
+        data, err := json.Marshal(in)
+        if err != nil {
+            return err
+        }
+        body = bytes.NewReader(data)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, method, u, body)
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Accept", "application/json")
+    if in != nil {
+        req.Header.Set("Content-Type", "application/json")
+    }
+    if c.Token != "" {
+        req.Header.Set("Authorization", "Bearer "+c.Token)
+    }
+    // Os comandos de escrita da CLI sobrescrevem de propósito o estado atual;
+    // "*" satisfaz servidores que exigem If-Match sem conferir a versão
+    switch method {
+    case http.MethodPut, http.MethodPatch, http.MethodDelete:
+        req.Header.Set("If-Match", "*")
+    }
+
+    resp, err := c.HTTP.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 400 {
+        apiErr := &APIError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+        data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+        json.Unmarshal(data, apiErr)
+        if apiErr.Detail == "" && len(data) > 0 && !json.Valid(data) {
+            apiErr.Detail = strings.TrimSpace(string(data))
+        }
+        return apiErr
+    }
+
+    if out == nil || resp.StatusCode == http.StatusNoContent {
+        return nil
+    }
+    return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// CreatedWebhook é a resposta de POST /webhooks, a única que traz o segredo.
+type CreatedWebhook struct {
+    *domain.Webhook
+    Secret string `json:"secret"`
+}
+
+// ListWebhooks lista as assinaturas de webhook do usuário.
+func (c *Client) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+    var webhooks []*domain.Webhook
+    if err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &webhooks); err != nil {
+        return nil, err
+    }
+    return webhooks, nil
+}
+
+// CreateWebhook assina os eventos informados; secret vazio gera um aleatório.
+func (c *Client) CreateWebhook(ctx context.Context, target string, events []string, secret string) (*CreatedWebhook, error) {
+    var webhook CreatedWebhook
+    body := map[string]interface{}{"url": target, "events": events, "secret": secret}
+    if err := c.do(ctx, http.MethodPost, "/webhooks", nil, body, &webhook); err != nil {
+        return nil, err
+    }
+    return &webhook, nil
+}
+
+// DeleteWebhook remove uma assinatura de webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+    return c.do(ctx, http.MethodDelete, "/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ListWebhookDeliveries lista as entregas de uma assinatura; status vazio traz todas.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id, status string) ([]*domain.WebhookDelivery, error) {
+    var deliveries []*domain.WebhookDelivery
+    query := url.Values{}
+    if status != "" {
+        query.Set("status", status)
+    }
+    if err := c.do(ctx, http.MethodGet, "/webhooks/"+url.PathEscape(id)+"/deliveries", query, nil, &deliveries); err != nil {
+        return nil, err
+    }
+    return deliveries, nil
+}
+
+// RedeliverWebhook devolve uma entrega para a fila.
+func (c *Client) RedeliverWebhook(ctx context.Context, id, deliveryID string) (*domain.WebhookDelivery, error) {
+    var delivery domain.WebhookDelivery
+    path := "/webhooks/" + url.PathEscape(id) + "/deliveries/" + url.PathEscape(deliveryID) + "/redeliver"
+    if err := c.do(ctx, http.MethodPost, path, nil, nil, &delivery); err != nil {
+        return nil, err
+    }
+    return &delivery, nil
+}
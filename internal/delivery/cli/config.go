@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultServer é usado enquanto nenhum servidor foi configurado.
+const defaultServer = "http://localhost:8080"
+
+// Config é o estado persistido da CLI (servidor e credenciais).
+type Config struct {
+    Server    string    `yaml:"server"`
+    Email     string    `yaml:"email,omitempty"`
+    Token     string    `yaml:"token,omitempty"`
+    ExpiresAt time.Time `yaml:"expires_at,omitempty"`
+}
+
+// DefaultConfigPath retorna <UserConfigDir>/gopher-tasks/config.yaml.
+func DefaultConfigPath() (string, error) {
+    dir, err := os.UserConfigDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(dir, "gopher-tasks", "config.yaml"), nil
+}
+
+// LoadConfig lê o arquivo de configuração; se não existir, retorna os padrões.
+func LoadConfig(path string) (*Config, error) {
+    cfg := &Config{Server: defaultServer}
+    data, err := os.ReadFile(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return cfg, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    if err := yaml.Unmarshal(data, cfg); err != nil {
+        return nil, err
+    }
+    if cfg.Server == "" {
+        cfg.Server = defaultServer
+    }
+    return cfg, nil
+}
+
+// Save grava o arquivo com permissão 0600, pois ele guarda o token de acesso.
+func (c *Config) Save(path string) error {
+    if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+        return err
+    }
+    data, err := yaml.Marshal(c)
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(path, data, 0o600)
+}
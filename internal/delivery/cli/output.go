@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Formatos de saída aceitos em --output.
+const (
+    outputTable = "table"
+    outputJSON  = "json"
+    outputYAML  = "yaml"
+)
+
+// printTasks escreve as tasks no formato pedido.
+func printTasks(w io.Writer, format string, tasks []*domain.Task) error {
+    if tasks == nil {
+        tasks = []*domain.Task{}
+    }
+    switch format {
+    case outputJSON, outputYAML:
+        return printStructured(w, format, tasks)
+    case outputTable:
+        tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+        fmt.Fprintln(tw, "ID\tTITLE\tSTATUS\tPRIORITY\tDUE\tTAGS")
+        for _, t := range tasks {
+            fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", t.ID, t.Title, t.Status, t.Priority, formatDue(t.DueDate), strings.Join(t.Tags, ","))
+        }
+        return tw.Flush()
+    default:
+        return fmt.Errorf("unknown output format %q (use table, json or yaml)", format)
+    }
+}
+
+// printTask escreve uma única task; em tabela usa o layout chave/valor.
+func printTask(w io.Writer, format string, t *domain.Task) error {
+    if format != outputTable {
+        return printStructured(w, format, t)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintf(tw, "ID:\t%s\n", t.ID)
+    fmt.Fprintf(tw, "Title:\t%s\n", t.Title)
+    fmt.Fprintf(tw, "Description:\t%s\n", t.Description)
+    fmt.Fprintf(tw, "Due:\t%s\n", formatDue(t.DueDate))
+    fmt.Fprintf(tw, "Status:\t%s\n", t.Status)
+    fmt.Fprintf(tw, "Priority:\t%s\n", t.Priority)
+    fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(t.Tags, ", "))
+    fmt.Fprintf(tw, "Created:\t%s\n", t.CreatedAt.Local().Format(time.RFC3339))
+    fmt.Fprintf(tw, "Updated:\t%s\n", t.UpdatedAt.Local().Format(time.RFC3339))
+    fmt.Fprintf(tw, "Version:\t%d\n", t.Version)
+    return tw.Flush()
+}
+
+// printTaskTree escreve a árvore de subtarefas; em tabela cada nível é
+// indentado sob o pai, com o progresso de quem tem filhas.
+func printTaskTree(w io.Writer, format string, root *domain.TaskNode) error {
+    if format != outputTable {
+        return printStructured(w, format, root)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "TITLE	ID	STATUS	PROGRESS")
+    var walk func(n *domain.TaskNode, depth int)
+    walk = func(n *domain.TaskNode, depth int) {
+        progress := "-"
+        if n.Progress != nil {
+            progress = fmt.Sprintf("%d/%d (%d%%)", n.Progress.Completed, n.Progress.Total, n.Progress.Percent)
+        }
+        fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\n", strings.Repeat("  ", depth), n.Title, n.ID, n.Status, progress)
+        for _, child := range n.Children {
+            walk(child, depth+1)
+        }
+    }
+    walk(root, 0)
+    return tw.Flush()
+}
+
+// printTags escreve as tags no formato pedido.
+func printTags(w io.Writer, format string, tags []*domain.Tag) error {
+    if tags == nil {
+        tags = []*domain.Tag{}
+    }
+    if format != outputTable {
+        return printStructured(w, format, tags)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tNAME\tCOLOR\tTASKS")
+    for _, t := range tags {
+        fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", t.ID, t.Name, t.Color, t.TaskCount)
+    }
+    return tw.Flush()
+}
+
+// printProjects escreve os projetos no formato pedido.
+func printProjects(w io.Writer, format string, projects []*domain.Project) error {
+    if projects == nil {
+        projects = []*domain.Project{}
+    }
+    if format != outputTable {
+        return printStructured(w, format, projects)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tNAME\tTASKS\tARCHIVED")
+    for _, p := range projects {
+        fmt.Fprintf(tw, "%s\t%s\t%d\t%t\n", p.ID, p.Name, p.TaskCount, p.Archived)
+    }
+    return tw.Flush()
+}
+
+// printReminders escreve os lembretes no formato pedido.
+func printReminders(w io.Writer, format string, reminders []*domain.Reminder) error {
+    if reminders == nil {
+        reminders = []*domain.Reminder{}
+    }
+    if format != outputTable {
+        return printStructured(w, format, reminders)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tFIRES\tTRIGGER\tSTATUS")
+    for _, r := range reminders {
+        trigger := "at " + formatDue(r.At)
+        if r.BeforeMinutes != nil {
+            trigger = fmt.Sprintf("%s before due", time.Duration(*r.BeforeMinutes)*time.Minute)
+        }
+        status := "pending"
+        switch {
+        case r.SentAt != nil:
+            status = "sent"
+        case r.FailedAt != nil:
+            status = "failed: " + r.LastError
+        }
+        fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.ID, formatDue(r.FireAt), trigger, status)
+    }
+    return tw.Flush()
+}
+
+// printWebhooks escreve as assinaturas de webhook no formato pedido.
+func printWebhooks(w io.Writer, format string, webhooks []*domain.Webhook) error {
+    if webhooks == nil {
+        webhooks = []*domain.Webhook{}
+    }
+    if format != outputTable {
+        return printStructured(w, format, webhooks)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tURL\tEVENTS\tACTIVE")
+    for _, h := range webhooks {
+        events := make([]string, len(h.Events))
+        for i, e := range h.Events {
+            events[i] = string(e)
+        }
+        fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", h.ID, h.URL, strings.Join(events, ","), h.Active)
+    }
+    return tw.Flush()
+}
+
+// printWebhookDeliveries escreve o histórico de entregas no formato pedido.
+func printWebhookDeliveries(w io.Writer, format string, deliveries []*domain.WebhookDelivery) error {
+    if deliveries == nil {
+        deliveries = []*domain.WebhookDelivery{}
+    }
+    if format != outputTable {
+        return printStructured(w, format, deliveries)
+    }
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tEVENT\tSTATUS\tATTEMPTS\tLAST RESPONSE\tCREATED")
+    for _, d := range deliveries {
+        last := "-"
+        switch {
+        case d.LastError != "":
+            last = d.LastError
+        case d.LastStatusCode != nil:
+            last = fmt.Sprintf("HTTP %d", *d.LastStatusCode)
+        }
+        fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", d.ID, d.EventType, d.Status, d.Attempts, last, formatDue(&d.CreatedAt))
+    }
+    return tw.Flush()
+}
+
+// printStructured serializa v em JSON ou YAML. O YAML passa pelo JSON para
+// manter os mesmos nomes de campo da API.
+func printStructured(w io.Writer, format string, v interface{}) error {
+    data, err := json.Marshal(v)
+    if err != nil {
+        return err
+    }
+    switch format {
+    case outputJSON:
+        var out interface{}
+        json.Unmarshal(data, &out)
+        enc := json.NewEncoder(w)
+        enc.SetIndent("", "  ")
+        return enc.Encode(out)
+    case outputYAML:
+        var out interface{}
+        if err := json.Unmarshal(data, &out); err != nil {
+            return err
+        }
+        enc := yaml.NewEncoder(w)
+        enc.SetIndent(2)
+        defer enc.Close()
+        return enc.Encode(out)
+    default:
+        return fmt.Errorf("unknown output format %q (use table, json or yaml)", format)
+    }
+}
+
+func formatDue(d *time.Time) string {
+    if d == nil {
+        return "-"
+    }
+    return d.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newProjectCmd(a *app) *cobra.Command {
+    cmd := &cobra.Command{
+        Use:   "project",
+        Short: "Gerencia projetos",
+    }
+    cmd.AddCommand(
+        newProjectListCmd(a),
+        newProjectCreateCmd(a),
+        newProjectArchiveCmd(a, true),
+        newProjectArchiveCmd(a, false),
+        newProjectDeleteCmd(a),
+    )
+    return cmd
+}
+
+func newProjectListCmd(a *app) *cobra.Command {
+    var all bool
+    cmd := &cobra.Command{
+        Use:   "list",
+        Short: "Lista projetos",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            projects, err := c.ListProjects(cmd.Context(), all)
+            if err != nil {
+                return err
+            }
+            return printProjects(cmd.OutOrStdout(), a.output, projects)
+        },
+    }
+    cmd.Flags().BoolVar(&all, "all", false, "inclui projetos arquivados")
+    return cmd
+}
+
+func newProjectCreateCmd(a *app) *cobra.Command {
+    var description, color string
+    cmd := &cobra.Command{
+        Use:   "create <name>",
+        Short: "Cria um projeto",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            project, err := c.CreateProject(cmd.Context(), args[0], description, color)
+            if err != nil {
+                return err
+            }
+            return printProjects(cmd.OutOrStdout(), a.output, []*domain.Project{project})
+        },
+    }
+    cmd.Flags().StringVarP(&description, "description", "d", "", "descrição")
+    cmd.Flags().StringVar(&color, "color", "", "cor no formato #RRGGBB")
+    return cmd
+}
+
+// newProjectArchiveCmd monta "archive" ou "unarchive".
+func newProjectArchiveCmd(a *app, archived bool) *cobra.Command {
+    use, short := "archive <id>", "Arquiva um projeto"
+    if !archived {
+        use, short = "unarchive <id>", "Reativa um projeto arquivado"
+    }
+    return &cobra.Command{
+        Use:   use,
+        Short: short,
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            project, err := c.SetProjectArchived(cmd.Context(), args[0], archived)
+            if err != nil {
+                return err
+            }
+            return printProjects(cmd.OutOrStdout(), a.output, []*domain.Project{project})
+        },
+    }
+}
+
+func newProjectDeleteCmd(a *app) *cobra.Command {
+    var cascade bool
+    cmd := &cobra.Command{
+        Use:   "delete <id>",
+        Short: "Remove um projeto",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.DeleteProject(cmd.Context(), args[0], cascade); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Project %s deleted\n", args[0])
+            return nil
+        },
+    }
+    cmd.Flags().BoolVar(&cascade, "cascade", false, "remove também todas as tasks do projeto")
+    return cmd
+}
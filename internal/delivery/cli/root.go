@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// app guarda o estado compartilhado entre os comandos.
+type app struct {
+    configPath string
+    server     string
+    output     string
+    cfg        *Config
+}
+
+// NewRootCmd monta a árvore de comandos do gopher-tasks.
+func NewRootCmd() *cobra.Command {
+    a := &app{}
+
+    root := &cobra.Command{
+        Use:           "gopher-tasks",
+        Short:         "Gerencie suas tarefas do gopher-tasks pelo terminal",
+        SilenceUsage:  true,
+        PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+            return a.loadConfig()
+        },
+    }
+
+    flags := root.PersistentFlags()
+    flags.StringVar(&a.configPath, "config", "", "arquivo de configuração (padrão: <config dir>/gopher-tasks/config.yaml)")
+    flags.StringVar(&a.server, "server", "", "URL da API (sobrescreve a configuração salva)")
+    flags.StringVarP(&a.output, "output", "o", outputTable, "formato de saída: table, json ou yaml")
+
+    root.AddCommand(
+        newLoginCmd(a),
+        newRegisterCmd(a),
+        newLogoutCmd(a),
+        newConfigCmd(a),
+        newTaskCmd(a),
+        newTagCmd(a),
+        newProjectCmd(a),
+        newWebhookCmd(a),
+    )
+    return root
+}
+
+func (a *app) loadConfig() error {
+    if a.configPath == "" {
+        path, err := DefaultConfigPath()
+        if err != nil {
+            return err
+        }
+        a.configPath = path
+    }
+    cfg, err := LoadConfig(a.configPath)
+    if err != nil {
+        return err
+    }
+    if a.server != "" {
+        cfg.Server = a.server
+    }
+    a.cfg = cfg
+    return nil
+}
+
+// client retorna um cliente autenticado com as credenciais salvas.
+func (a *app) client() (*Client, error) {
+    if a.cfg.Token == "" {
+        return nil, errors.New("not logged in: run `gopher-tasks login` first")
+    }
+    return NewClient(a.cfg.Server, a.cfg.Token), nil
+}
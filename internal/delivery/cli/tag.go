@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newTagCmd(a *app) *cobra.Command {
+    cmd := &cobra.Command{
+        Use:   "tag",
+        Short: "Gerencia tags",
+    }
+    cmd.AddCommand(
+        newTagListCmd(a),
+        newTagCreateCmd(a),
+        newTagDeleteCmd(a),
+    )
+    return cmd
+}
+
+func newTagListCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "list",
+        Short: "Lista tags",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            tags, err := c.ListTags(cmd.Context())
+            if err != nil {
+                return err
+            }
+            return printTags(cmd.OutOrStdout(), a.output, tags)
+        },
+    }
+}
+
+func newTagCreateCmd(a *app) *cobra.Command {
+    var color string
+    cmd := &cobra.Command{
+        Use:   "create <name>",
+        Short: "Cria uma tag",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            tag, err := c.CreateTag(cmd.Context(), args[0], color)
+            if err != nil {
+                return err
+            }
+            return printTags(cmd.OutOrStdout(), a.output, []*domain.Tag{tag})
+        },
+    }
+    cmd.Flags().StringVar(&color, "color", "", "cor no formato #RRGGBB")
+    return cmd
+}
+
+func newTagDeleteCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "delete <id>",
+        Short: "Remove uma tag de todas as tasks",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.DeleteTag(cmd.Context(), args[0]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Tag %s deleted\n", args[0])
+            return nil
+        },
+    }
+}
@@ -0,0 +1,446 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newTaskCmd(a *app) *cobra.Command {
+    cmd := &cobra.Command{
+        Use:   "task",
+        Short: "Gerencia tasks",
+    }
+    cmd.AddCommand(
+        newTaskCreateCmd(a),
+        newTaskListCmd(a),
+        newTaskWatchCmd(a),
+        newTaskGetCmd(a),
+        newTaskTreeCmd(a),
+        newTaskOccurrencesCmd(a),
+        newTaskDependCmd(a),
+        newTaskUndependCmd(a),
+        newTaskRemindCmd(a),
+        newTaskRemindersCmd(a),
+        newTaskUnremindCmd(a),
+        newTaskCompleteCmd(a),
+        newTaskStatusCmd(a),
+        newTaskDeleteCmd(a),
+    )
+    return cmd
+}
+
+func newTaskCreateCmd(a *app) *cobra.Command {
+    var in TaskInput
+    cmd := &cobra.Command{
+        Use:   "create <title>",
+        Short: "Cria uma task",
+        Args:  cobra.MinimumNArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            in.Title = strings.Join(args, " ")
+            task, err := c.CreateTask(cmd.Context(), in)
+            if err != nil {
+                return err
+            }
+            return printTask(cmd.OutOrStdout(), a.output, task)
+        },
+    }
+    cmd.Flags().StringVarP(&in.Description, "description", "d", "", "descrição")
+    cmd.Flags().StringVar(&in.DueDate, "due", "", "vencimento (YYYY-MM-DD ou RFC3339)")
+    cmd.Flags().StringVarP(&in.Priority, "priority", "p", "", "prioridade: low, medium, high ou urgent")
+    cmd.Flags().StringVar(&in.ProjectID, "project", "", "ID do projeto")
+    cmd.Flags().StringVar(&in.ParentID, "parent", "", "ID da task pai (cria como subtarefa)")
+    cmd.Flags().StringSliceVarP(&in.Tags, "tag", "t", nil, "tag (pode repetir ou separar por vírgula)")
+    cmd.Flags().StringVar(&in.RRule, "rrule", "", "recorrência, ex.: FREQ=WEEKLY;BYDAY=MO (exige --due)")
+    return cmd
+}
+
+func newTaskListCmd(a *app) *cobra.Command {
+    var status, priority, search, tags, project string
+    var overdue bool
+    var limit int
+    var cursor string
+    var sort string
+    cmd := &cobra.Command{
+        Use:   "list",
+        Short: "Lista tasks",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+
+            query := url.Values{}
+            switch status {
+            case "", "all":
+            case "pending":
+                query.Set("status", "todo,in_progress,blocked")
+            case "completed":
+                query.Set("status", "done")
+            default:
+                // Lista explícita, ex.: --status=todo,in_progress
+                query.Set("status", status)
+            }
+            if priority != "" {
+                query.Set("priority", priority)
+            }
+            if search != "" {
+                query.Set("q", search)
+            }
+            if tags != "" {
+                query.Set("tags", tags)
+            }
+            if project != "" {
+                query.Set("project_id", project)
+            }
+            if overdue {
+                query.Set("overdue", "true")
+            }
+            if limit > 0 {
+                query.Set("limit", strconv.Itoa(limit))
+            }
+            if sort != "" {
+                query.Set("sort", sort)
+            }
+            if cursor != "" {
+                query.Set("cursor", cursor)
+            }
+
+            list, err := c.ListTasks(cmd.Context(), query)
+            if err != nil {
+                return err
+            }
+            if err := printTasks(cmd.OutOrStdout(), a.output, list.Data); err != nil {
+                return err
+            }
+            // Em tabela o cursor não aparece na saída: indica como continuar
+            if a.output == outputTable && list.Page.NextCursor != "" {
+                fmt.Fprintf(cmd.ErrOrStderr(), "\nmore results: --cursor %s\n", list.Page.NextCursor)
+            }
+            return nil
+        },
+    }
+    cmd.Flags().StringVar(&status, "status", "all", "filtra por status: pending, completed, all ou lista (todo,in_progress,...)")
+    cmd.Flags().StringVar(&priority, "priority", "", "filtra por prioridade (low,medium,high,urgent)")
+    cmd.Flags().StringVarP(&search, "search", "q", "", "busca textual em título e descrição")
+    cmd.Flags().StringVar(&project, "project", "", "apenas tasks do projeto (ID)")
+    cmd.Flags().StringVar(&tags, "tags", "", "filtra por tags: a,b (alguma), all:a,b (todas) ou none:a,b")
+    cmd.Flags().BoolVar(&overdue, "overdue", false, "apenas tasks vencidas e abertas")
+    cmd.Flags().StringVar(&sort, "sort", "", "ordenação, ex.: due_date,-priority (- = decrescente)")
+    cmd.Flags().IntVar(&limit, "limit", 0, "tamanho da página (padrão do servidor se omitido)")
+    cmd.Flags().StringVar(&cursor, "cursor", "", "cursor da página seguinte, informado ao fim da listagem anterior")
+    return cmd
+}
+
+func newTaskWatchCmd(a *app) *cobra.Command {
+    var status, priority, search, tags, project string
+    cmd := &cobra.Command{
+        Use:   "watch",
+        Short: "Acompanha mudanças nas tasks ao vivo",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+
+            query := url.Values{}
+            for param, value := range map[string]string{
+                "status":     status,
+                "priority":   priority,
+                "q":          search,
+                "tags":       tags,
+                "project_id": project,
+            } {
+                if value != "" {
+                    query.Set(param, value)
+                }
+            }
+
+            out := cmd.OutOrStdout()
+            show := func(e *domain.Event) error {
+                if e == nil {
+                    fmt.Fprintln(cmd.ErrOrStderr(), "some events were missed; run `task list` to resync")
+                    return nil
+                }
+                if a.output != outputTable {
+                    return printStructured(out, a.output, e)
+                }
+                fmt.Fprintf(out, "%s  %-14s  %s  %s  %s\n", e.OccurredAt.Local().Format("15:04:05"), e.Type, e.Task.ID, e.Task.Status, e.Task.Title)
+                return nil
+            }
+
+            // Reconecta quando o stream cai, retomando do último evento recebido
+            var lastEventID string
+            for {
+                lastEventID, err = c.WatchTasks(cmd.Context(), query, lastEventID, show)
+                var apiErr *APIError
+                if errors.As(err, &apiErr) || cmd.Context().Err() != nil {
+                    return err
+                }
+                if err != nil {
+                    fmt.Fprintf(cmd.ErrOrStderr(), "stream interrupted: %v; reconnecting\n", err)
+                }
+                select {
+                case <-cmd.Context().Done():
+                    return nil
+                case <-time.After(3 * time.Second):
+                }
+            }
+        },
+    }
+    cmd.Flags().StringVar(&status, "status", "", "status separados por vírgula (todo,in_progress,...)")
+    cmd.Flags().StringVar(&priority, "priority", "", "filtra por prioridade (low,medium,high,urgent)")
+    cmd.Flags().StringVarP(&search, "search", "q", "", "busca textual em título e descrição")
+    cmd.Flags().StringVar(&project, "project", "", "apenas tasks do projeto (ID)")
+    cmd.Flags().StringVar(&tags, "tags", "", "filtra por tags: a,b (alguma), all:a,b (todas) ou none:a,b")
+    return cmd
+}
+
+func newTaskGetCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "get <id>",
+        Short: "Mostra uma task",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            task, err := c.GetTask(cmd.Context(), args[0])
+            if err != nil {
+                return err
+            }
+            return printTask(cmd.OutOrStdout(), a.output, task)
+        },
+    }
+}
+
+func newTaskTreeCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "tree <id>",
+        Short: "Mostra uma task com suas subtarefas",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            tree, err := c.GetTaskTree(cmd.Context(), args[0])
+            if err != nil {
+                return err
+            }
+            return printTaskTree(cmd.OutOrStdout(), a.output, tree)
+        },
+    }
+}
+
+func newTaskRemindCmd(a *app) *cobra.Command {
+    var at string
+    var before time.Duration
+    cmd := &cobra.Command{
+        Use:   "remind <id>",
+        Short: "Agenda um lembrete para uma task",
+        Long:  "Agenda um lembrete em um horário fixo (--at) ou antes do vencimento da task (--before).",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            if (at == "") == (before == 0) {
+                return fmt.Errorf("use exactly one of --at or --before")
+            }
+            in := ReminderInput{At: at}
+            if before != 0 {
+                minutes := int(before.Minutes())
+                in.BeforeMinutes = &minutes
+            }
+
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            reminder, err := c.CreateReminder(cmd.Context(), args[0], in)
+            if err != nil {
+                return err
+            }
+            return printReminders(cmd.OutOrStdout(), a.output, []*domain.Reminder{reminder})
+        },
+    }
+    cmd.Flags().StringVar(&at, "at", "", "horário do lembrete (RFC3339)")
+    cmd.Flags().DurationVar(&before, "before", 0, "antecedência em relação ao vencimento, ex.: 30m, 2h, 24h")
+    return cmd
+}
+
+func newTaskRemindersCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "reminders <id>",
+        Short: "Lista os lembretes de uma task",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            reminders, err := c.ListReminders(cmd.Context(), args[0])
+            if err != nil {
+                return err
+            }
+            return printReminders(cmd.OutOrStdout(), a.output, reminders)
+        },
+    }
+}
+
+func newTaskUnremindCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "unremind <id> <reminder-id>",
+        Short: "Cancela um lembrete de uma task",
+        Args:  cobra.ExactArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.DeleteReminder(cmd.Context(), args[0], args[1]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Reminder %s deleted\n", args[1])
+            return nil
+        },
+    }
+}
+
+func newTaskOccurrencesCmd(a *app) *cobra.Command {
+    var count int
+    cmd := &cobra.Command{
+        Use:   "occurrences <id>",
+        Short: "Mostra as próximas ocorrências de uma task recorrente",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            out, err := c.PreviewTaskOccurrences(cmd.Context(), args[0], count)
+            if err != nil {
+                return err
+            }
+            if a.output != outputTable {
+                return printStructured(cmd.OutOrStdout(), a.output, out)
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "%s (%s)\n", out.Recurrence.Rule, out.Recurrence.Timezone)
+            for _, t := range out.Occurrences {
+                fmt.Fprintln(cmd.OutOrStdout(), formatDue(&t))
+            }
+            return nil
+        },
+    }
+    cmd.Flags().IntVarP(&count, "count", "n", 0, "quantidade de ocorrências (padrão do servidor se omitido)")
+    return cmd
+}
+
+func newTaskDependCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "depend <id> <prerequisite-id>",
+        Short: "Faz uma task esperar pela conclusão de outra",
+        Args:  cobra.ExactArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            deps, err := c.AddTaskDependency(cmd.Context(), args[0], args[1])
+            if err != nil {
+                return err
+            }
+            if a.output != outputTable {
+                return printStructured(cmd.OutOrStdout(), a.output, deps)
+            }
+            return printTasks(cmd.OutOrStdout(), a.output, deps.BlockedBy)
+        },
+    }
+}
+
+func newTaskUndependCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "undepend <id> <prerequisite-id>",
+        Short: "Remove um pré-requisito de uma task",
+        Args:  cobra.ExactArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.RemoveTaskDependency(cmd.Context(), args[0], args[1]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Task %s no longer depends on %s\n", args[0], args[1])
+            return nil
+        },
+    }
+}
+
+func newTaskCompleteCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "complete <id>",
+        Short: "Marca uma task como concluída",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            task, err := c.PatchTask(cmd.Context(), args[0], map[string]interface{}{"status": "done"})
+            if err != nil {
+                return err
+            }
+            return printTask(cmd.OutOrStdout(), a.output, task)
+        },
+    }
+}
+
+func newTaskStatusCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "status <id> <todo|in_progress|blocked|done|cancelled>",
+        Short: "Muda o status de uma task",
+        Args:  cobra.ExactArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            task, err := c.PatchTask(cmd.Context(), args[0], map[string]interface{}{"status": args[1]})
+            if err != nil {
+                return err
+            }
+            return printTask(cmd.OutOrStdout(), a.output, task)
+        },
+    }
+}
+
+func newTaskDeleteCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "delete <id>",
+        Short: "Remove uma task",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.DeleteTask(cmd.Context(), args[0]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Task %s deleted\n", args[0])
+            return nil
+        },
+    }
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newWebhookCmd(a *app) *cobra.Command {
+    cmd := &cobra.Command{
+        Use:   "webhook",
+        Short: "Gerencia webhooks de eventos das tasks",
+    }
+    cmd.AddCommand(
+        newWebhookListCmd(a),
+        newWebhookCreateCmd(a),
+        newWebhookDeleteCmd(a),
+        newWebhookDeliveriesCmd(a),
+        newWebhookRedeliverCmd(a),
+    )
+    return cmd
+}
+
+func newWebhookListCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "list",
+        Short: "Lista webhooks",
+        Args:  cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            webhooks, err := c.ListWebhooks(cmd.Context())
+            if err != nil {
+                return err
+            }
+            return printWebhooks(cmd.OutOrStdout(), a.output, webhooks)
+        },
+    }
+}
+
+func newWebhookCreateCmd(a *app) *cobra.Command {
+    var events []string
+    var secret string
+    cmd := &cobra.Command{
+        Use:   "create <url>",
+        Short: "Assina eventos das tasks em uma URL",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            webhook, err := c.CreateWebhook(cmd.Context(), args[0], events, secret)
+            if err != nil {
+                return err
+            }
+            if a.output != outputTable {
+                return printStructured(cmd.OutOrStdout(), a.output, webhook)
+            }
+            if err := printWebhooks(cmd.OutOrStdout(), a.output, []*domain.Webhook{webhook.Webhook}); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "\nSecret: %s\n(guarde-o agora: ele não será exibido de novo)\n", webhook.Secret)
+            return nil
+        },
+    }
+    cmd.Flags().StringSliceVar(&events, "events", []string{"*"}, "eventos assinados: task.created, task.updated, task.completed, task.deleted ou *")
+    cmd.Flags().StringVar(&secret, "secret", "", "segredo da assinatura HMAC (padrão: gerado pelo servidor)")
+    return cmd
+}
+
+func newWebhookDeleteCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "delete <id>",
+        Short: "Remove um webhook e o histórico de entregas",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if err := c.DeleteWebhook(cmd.Context(), args[0]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Webhook %s deleted\n", args[0])
+            return nil
+        },
+    }
+}
+
+func newWebhookDeliveriesCmd(a *app) *cobra.Command {
+    var status string
+    cmd := &cobra.Command{
+        Use:   "deliveries <id>",
+        Short: "Lista as entregas de um webhook",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            deliveries, err := c.ListWebhookDeliveries(cmd.Context(), args[0], status)
+            if err != nil {
+                return err
+            }
+            return printWebhookDeliveries(cmd.OutOrStdout(), a.output, deliveries)
+        },
+    }
+    cmd.Flags().StringVar(&status, "status", "", "filtra por pending, delivered ou dead")
+    return cmd
+}
+
+func newWebhookRedeliverCmd(a *app) *cobra.Command {
+    return &cobra.Command{
+        Use:   "redeliver <id> <delivery-id>",
+        Short: "Reenvia uma entrega de webhook",
+        Args:  cobra.ExactArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            c, err := a.client()
+            if err != nil {
+                return err
+            }
+            if _, err := c.RedeliverWebhook(cmd.Context(), args[0], args[1]); err != nil {
+                return err
+            }
+            fmt.Fprintf(cmd.OutOrStdout(), "Delivery %s queued for redelivery\n", args[1])
+            return nil
+        },
+    }
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// registerRequest representa o payload de cadastro de usuário.
+type registerRequest struct {
+    Email    string `json:"email" example:"gopher@example.com"`
+    Name     string `json:"name" example:"Gopher"`
+    Password string `json:"password" example:"s3cr3t-pass"`
+    Timezone string `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+}
+
+// loginRequest representa o payload de login.
+type loginRequest struct {
+    Email    string `json:"email" example:"gopher@example.com"`
+    Password string `json:"password" example:"s3cr3t-pass"`
+}
+
+// loginResponse é o token emitido no login.
+type loginResponse struct {
+    AccessToken string       `json:"access_token"`
+    TokenType   string       `json:"token_type" example:"Bearer"`
+    ExpiresAt   time.Time    `json:"expires_at"`
+    User        *domain.User `json:"user"`
+}
+
+// AuthHandler agrupa os use cases de cadastro e login.
+type AuthHandler struct {
+    RegisterUC *usecase.RegisterUserUseCase
+    LoginUC    *usecase.LoginUserUseCase
+    Log        logger.Logger
+}
+
+// NewAuthHandler injeta os use cases de autenticação, além do logger.
+func NewAuthHandler(
+    registerUC *usecase.RegisterUserUseCase,
+    loginUC *usecase.LoginUserUseCase,
+    log logger.Logger,
+) *AuthHandler {
+    return &AuthHandler{RegisterUC: registerUC, LoginUC: loginUC, Log: log}
+}
+
+// Register godoc
+// @Summary      Cadastra um usuário
+// @Description  Cria uma conta com e-mail e senha e, opcionalmente, o fuso horário (padrão UTC)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        user  body      registerRequest  true  "Dados de cadastro"
+// @Success      201   {object}  domain.User
+// @Failure      400   {object}  problemDetails
+// @Failure      409   {object}  problemDetails
+// @Failure      422   {object}  problemDetails
+// @Failure      500   {object}  problemDetails
+// @Router       /auth/register [post]
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+    var req registerRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    user, err := h.RegisterUC.Execute(r.Context(), usecase.RegisterUserInput{
+        Email:    req.Email,
+        Name:     req.Name,
+        Password: req.Password,
+        Timezone: req.Timezone,
+    })
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to register user")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(user)
+}
+
+// Login godoc
+// @Summary      Autentica um usuário
+// @Description  Troca e-mail e senha por um token JWT de acesso
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      loginRequest  true  "Credenciais"
+// @Success      200          {object}  loginResponse
+// @Failure      400          {object}  problemDetails
+// @Failure      401          {object}  problemDetails
+// @Failure      500          {object}  problemDetails
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+    var req loginRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    out, err := h.LoginUC.Execute(r.Context(), req.Email, req.Password)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to log in")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(loginResponse{
+        AccessToken: out.Token,
+        TokenType:   "Bearer",
+        ExpiresAt:   out.ExpiresAt,
+        User:        out.User,
+    })
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// addDependencyRequest representa o payload para declarar um pré-requisito.
+type addDependencyRequest struct {
+    DependsOnID string `json:"depends_on_id" example:"0b7e4c1a-0000-4000-8000-000000000000"`
+}
+
+// DependencyHandler agrupa os use cases e o logger para o grafo de dependências entre Tasks.
+type DependencyHandler struct {
+    ListUC         *usecase.ListTaskDependenciesUseCase
+    AddUC          *usecase.AddTaskDependencyUseCase
+    RemoveUC       *usecase.RemoveTaskDependencyUseCase
+    CriticalPathUC *usecase.GetCriticalPathUseCase
+    Log            logger.Logger
+}
+
+// NewDependencyHandler injeta os use cases de dependências, além do logger.
+func NewDependencyHandler(
+    listUC *usecase.ListTaskDependenciesUseCase,
+    addUC *usecase.AddTaskDependencyUseCase,
+    removeUC *usecase.RemoveTaskDependencyUseCase,
+    criticalPathUC *usecase.GetCriticalPathUseCase,
+    log logger.Logger,
+) *DependencyHandler {
+    return &DependencyHandler{
+        ListUC:         listUC,
+        AddUC:          addUC,
+        RemoveUC:       removeUC,
+        CriticalPathUC: criticalPathUC,
+        Log:            log,
+    }
+}
+
+// ListTaskDependencies godoc
+// @Summary      Lista as dependências de uma task
+// @Description  Retorna os pré-requisitos da task (blocked_by) e as tasks que esperam por ela (blocks)
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID da task"
+// @Success      200  {object}  domain.TaskDependencies
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tasks/{id}/dependencies [get]
+func (h *DependencyHandler) List(w http.ResponseWriter, r *http.Request) {
+    deps, err := h.ListUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list task dependencies")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(deps)
+}
+
+// AddTaskDependency godoc
+// @Summary      Adiciona um pré-requisito a uma task
+// @Description  A task só pode ser iniciada ou concluída depois que depends_on_id terminar.
+// @Description  Uma task em todo com pré-requisito aberto passa automaticamente para blocked
+// @Description  e volta para todo quando todos terminam. Dependências que fechariam um ciclo são rejeitadas.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                true  "ID da task"
+// @Param        dependency  body      addDependencyRequest  true  "Pré-requisito"
+// @Success      201         {object}  domain.TaskDependencies
+// @Failure      400         {object}  problemDetails
+// @Failure      401         {object}  problemDetails
+// @Failure      404         {object}  problemDetails
+// @Failure      409         {object}  problemDetails
+// @Failure      422         {object}  problemDetails
+// @Failure      500         {object}  problemDetails
+// @Router       /tasks/{id}/dependencies [post]
+func (h *DependencyHandler) Add(w http.ResponseWriter, r *http.Request) {
+    var req addDependencyRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    deps, err := h.AddUC.Execute(r.Context(), mux.Vars(r)["id"], req.DependsOnID)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to add task dependency")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(deps)
+}
+
+// RemoveTaskDependency godoc
+// @Summary      Remove um pré-requisito de uma task
+// @Description  Remove a dependência; a task é desbloqueada se não restar pré-requisito aberto
+// @Tags         tasks
+// @Security     BearerAuth
+// @Param        id           path      string  true  "ID da task"
+// @Param        dependsOnId  path      string  true  "ID do pré-requisito"
+// @Success      204
+// @Failure      401          {object}  problemDetails
+// @Failure      404          {object}  problemDetails
+// @Failure      500          {object}  problemDetails
+// @Router       /tasks/{id}/dependencies/{dependsOnId} [delete]
+func (h *DependencyHandler) Remove(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    if err := h.RemoveUC.Execute(r.Context(), vars["id"], vars["dependsOnId"]); err != nil {
+        writeError(w, r, h.Log, err, "failed to remove task dependency")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCriticalPath godoc
+// @Summary      Caminho crítico de um projeto
+// @Description  Maior cadeia de tasks abertas do projeto ligadas por dependências (empates pelo
+// @Description  vencimento mais tardio). due_date é o maior vencimento do caminho e conflicts
+// @Description  lista as dependências cujo pré-requisito vence depois da task que depende dele.
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID do projeto"
+// @Success      200  {object}  domain.CriticalPath
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /projects/{id}/critical-path [get]
+func (h *DependencyHandler) CriticalPath(w http.ResponseWriter, r *http.Request) {
+    path, err := h.CriticalPathUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to compute critical path")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(path)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// problemContentType é o media type definido pela RFC 7807.
+const problemContentType = "application/problem+json"
+
+// problemDetails é o corpo de erro retornado por todos os endpoints (RFC 7807).
+type problemDetails struct {
+    Type     string              `json:"type" example:"about:blank"`
+    Title    string              `json:"title" example:"Bad Request"`
+    Status   int                 `json:"status" example:"400"`
+    Detail   string              `json:"detail,omitempty" example:"validation failed"`
+    Instance string              `json:"instance,omitempty" example:"/tasks"`
+    Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// newProblem monta o corpo problem+json de uma resposta com o status informado.
+// fields é copiado: pode ser o de um erro sentinela, que quem recebe o
+// problema não deve alterar.
+func newProblem(r *http.Request, status int, detail string, fields ...domain.FieldError) problemDetails {
+    return problemDetails{
+        Type:     "about:blank",
+        Title:    http.StatusText(status),
+        Status:   status,
+        Detail:   detail,
+        Instance: r.URL.Path,
+        Errors:   slices.Clone(fields),
+    }
+}
+
+// writeProblem escreve uma resposta application/problem+json.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string, fields ...domain.FieldError) {
+    writeProblemDetails(w, newProblem(r, status, detail, fields...))
+}
+
+func writeProblemDetails(w http.ResponseWriter, p problemDetails) {
+    w.Header().Set("Content-Type", problemContentType)
+    w.WriteHeader(p.Status)
+    json.NewEncoder(w).Encode(p)
+}
+
+// writeError traduz um erro de use case em problem+json. Erros que não são
+// de domínio viram 500 e são logados, sem vazar detalhes ao cliente.
+func writeError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error, msg string) {
+    if r.Context().Err() != nil {
+        // O cliente desistiu da requisição: não há a quem responder.
+        log.WithField("error", err).Warn(msg + ": request canceled by client")
+        return
+    }
+    writeProblemDetails(w, errorProblem(r, log, err, msg))
+}
+
+// errorProblem é o problem+json que writeError escreveria para err; usado
+// também nos resultados de cada item das operações em lote.
+func errorProblem(r *http.Request, log logger.Logger, err error, msg string) problemDetails {
+    if errors.Is(err, context.DeadlineExceeded) {
+        log.WithField("error", err).Warn(msg + ": deadline exceeded")
+        return newProblem(r, http.StatusGatewayTimeout, "request timed out")
+    }
+
+    de, ok := domain.AsError(err)
+    if !ok {
+        log.WithField("error", err).Error(msg)
+        return newProblem(r, http.StatusInternalServerError, "internal server error")
+    }
+
+    return newProblem(r, statusForKind(de.Kind), de.Message, de.Fields...)
+}
+
+// statusForKind mapeia a categoria do erro de domínio para o status HTTP.
+func statusForKind(kind domain.ErrorKind) int {
+    switch kind {
+    case domain.KindNotFound:
+        return http.StatusNotFound
+    case domain.KindValidation:
+        return http.StatusUnprocessableEntity
+    case domain.KindConflict:
+        return http.StatusConflict
+    case domain.KindForbidden:
+        return http.StatusForbidden
+    case domain.KindUnauthorized:
+        return http.StatusUnauthorized
+    case domain.KindPrecondition:
+        return http.StatusPreconditionFailed
+    case domain.KindBadRequest:
+        return http.StatusBadRequest
+    default:
+        return http.StatusInternalServerError
+    }
+}
+
+// NotFoundHandler responde rotas inexistentes no mesmo formato problem+json.
+func NotFoundHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        writeProblem(w, r, http.StatusNotFound, "route not found")
+    })
+}
+
+// MethodNotAllowedHandler responde métodos não suportados em problem+json.
+func MethodNotAllowedHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        writeProblem(w, r, http.StatusMethodNotAllowed, "method not allowed")
+    })
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+func TestErrorProblemStatus(t *testing.T) {
+    tests := []struct {
+        err  error
+        want int
+    }{
+        {domain.NewNotFoundError("task"), http.StatusNotFound},
+        {domain.NewValidationError(domain.FieldError{Field: "title", Message: "is required"}), http.StatusUnprocessableEntity},
+        {domain.NewBadRequestError("invalid query parameter", domain.FieldError{Field: "cursor", Message: "is invalid or expired"}), http.StatusBadRequest},
+        {domain.NewConflictError("version mismatch"), http.StatusConflict},
+        {domain.NewPreconditionError("stale"), http.StatusPreconditionFailed},
+    }
+
+    r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+    for _, tt := range tests {
+        if got := errorProblem(r, nil, tt.err, "test").Status; got != tt.want {
+            t.Errorf("errorProblem(%v).Status = %d, want %d", tt.err, got, tt.want)
+        }
+    }
+}
+
+// Os campos prefixados por um lote que falhou não podem vazar para o erro
+// sentinela de due_date usado pelas próximas requisições.
+func TestBatchErrorKeepsSentinelFields(t *testing.T) {
+    log := logger.New("error", "text", io.Discard)
+    batch := NewTaskBatchHandler(usecase.NewBatchTasksUseCase(nil, nil, nil, nil, nil, nil, 0), nil, false, log)
+    tasks := NewTaskHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, false, log)
+
+    fields := func(w *httptest.ResponseRecorder) []domain.FieldError {
+        var p problemDetails
+        if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+            t.Fatalf("body = %s", w.Body)
+        }
+        return p.Errors
+    }
+
+    for i := 0; i < 2; i++ {
+        body := `{"operations":[{"op":"create","task":{"title":"Report","due_date":"tomorrow"}}]}`
+        w := httptest.NewRecorder()
+        batch.Batch(w, httptest.NewRequest(http.MethodPost, "/tasks:batch", strings.NewReader(body)))
+        got := fields(w)
+        if w.Code != http.StatusUnprocessableEntity || len(got) != 1 || got[0].Field != "operations[0].due_date" {
+            t.Fatalf("batch %d = %d %+v, want 422 on operations[0].due_date", i, w.Code, got)
+        }
+    }
+
+    w := httptest.NewRecorder()
+    tasks.Create(w, httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"Report","due_date":"tomorrow"}`)))
+    if got := fields(w); w.Code != http.StatusUnprocessableEntity || len(got) != 1 || got[0].Field != "due_date" {
+        t.Errorf("POST /tasks = %d %+v, want 422 on due_date", w.Code, got)
+    }
+}
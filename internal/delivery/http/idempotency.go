@@ -0,0 +1,185 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+const (
+    // maxIdempotencyKey é o tamanho máximo aceito para o header Idempotency-Key.
+    maxIdempotencyKey = 255
+    // maxIdempotentBody limita o corpo lido para calcular o fingerprint.
+    maxIdempotentBody = 1 << 20
+)
+
+// Idempotency faz POST, PUT, PATCH e DELETE com o header Idempotency-Key
+// serem executados uma única vez por usuário e chave: a resposta é guardada
+// por ttl e repetida (com Idempotent-Replayed: true) quando o cliente reenvia
+// a mesma requisição. Reusar a chave com outra requisição resulta em 422 e
+// reenviar enquanto a original ainda roda, em 409. Respostas 5xx não são
+// guardadas, para que o cliente possa tentar de novo. Deve vir depois de
+// RequireAuth.
+func Idempotency(repo domain.IdempotencyRepository, ttl, lease time.Duration, log logger.Logger) mux.MiddlewareFunc {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            key := r.Header.Get("Idempotency-Key")
+            userID, ok := domain.UserIDFromContext(r.Context())
+            if key == "" || !ok || !idempotentMethod(r.Method) {
+                next.ServeHTTP(w, r)
+                return
+            }
+            if !validIdempotencyKey(key) {
+                writeProblem(w, r, http.StatusBadRequest, "invalid Idempotency-Key header", domain.FieldError{
+                    Field:   "Idempotency-Key",
+                    Message: "must have 1 to 255 printable ASCII characters",
+                })
+                return
+            }
+
+            body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+            if err != nil {
+                var tooLarge *http.MaxBytesError
+                if errors.As(err, &tooLarge) {
+                    writeProblem(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+                    return
+                }
+                writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+                return
+            }
+            r.Body = io.NopCloser(bytes.NewReader(body))
+
+            rec := &domain.IdempotencyRecord{
+                UserID:      userID,
+                Key:         key,
+                Method:      r.Method,
+                Path:        r.URL.RequestURI(),
+                Fingerprint: requestFingerprint(r, body),
+                ExpiresAt:   time.Now().Add(ttl),
+            }
+            existing, reserved, err := repo.Reserve(r.Context(), rec, lease)
+            if err != nil {
+                writeError(w, r, log, err, "failed to reserve idempotency key")
+                return
+            }
+            if !reserved {
+                switch {
+                case existing.Fingerprint != rec.Fingerprint:
+                    writeProblem(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+                case !existing.Completed():
+                    w.Header().Set("Retry-After", "1")
+                    writeProblem(w, r, http.StatusConflict, "a request with this Idempotency-Key is still in progress")
+                default:
+                    replayResponse(w, existing)
+                }
+                return
+            }
+
+            rw := &recordingWriter{ResponseWriter: w}
+            completed := false
+            // A gravação não depende do cliente: ele pode ter desistido e vai reenviar
+            store := context.WithoutCancel(r.Context())
+            defer func() {
+                if completed {
+                    return
+                }
+                if err := repo.Release(store, rec); err != nil {
+                    log.WithField("error", err).Error("Failed to release idempotency key")
+                }
+            }()
+
+            next.ServeHTTP(rw, r)
+
+            // Sem resposta (requisição cancelada) ou erro do servidor: a chave
+            // é liberada e a próxima tentativa executa de novo
+            if rw.status == 0 || rw.status >= 500 {
+                return
+            }
+            rec.Status = rw.status
+            header := w.Header().Clone()
+            header.Del("Date")
+            rec.Header = header
+            rec.Body = rw.body.Bytes()
+            if err := repo.Complete(store, rec); err != nil {
+                log.WithField("error", err).Error("Failed to store idempotent response")
+                return
+            }
+            completed = true
+        })
+    }
+}
+
+func idempotentMethod(method string) bool {
+    switch method {
+    case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+        return true
+    }
+    return false
+}
+
+func validIdempotencyKey(key string) bool {
+    if len(key) > maxIdempotencyKey {
+        return false
+    }
+    for i := 0; i < len(key); i++ {
+        if key[i] < 0x20 || key[i] > 0x7e {
+            return false
+        }
+    }
+    return true
+}
+
+// requestFingerprint identifica a requisição: mesma chave com outro método,
+// caminho ou corpo é um erro do cliente.
+func requestFingerprint(r *http.Request, body []byte) string {
+    h := sha256.New()
+    io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+    h.Write(body)
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayResponse repete a resposta guardada.
+func replayResponse(w http.ResponseWriter, rec *domain.IdempotencyRecord) {
+    for name, values := range rec.Header {
+        w.Header()[name] = values
+    }
+    w.Header().Set("Idempotent-Replayed", "true")
+    w.WriteHeader(rec.Status)
+    w.Write(rec.Body)
+}
+
+// recordingWriter repassa a resposta ao cliente e guarda uma cópia.
+type recordingWriter struct {
+    http.ResponseWriter
+    status int
+    body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+    if w.status == 0 {
+        w.status = status
+    }
+    w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+    if w.status == 0 {
+        w.status = http.StatusOK
+    }
+    w.body.Write(b)
+    return w.ResponseWriter.Write(b)
+}
+
+// Unwrap expõe o writer original ao http.ResponseController.
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+    return w.ResponseWriter
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// fakeIdempotencyRepo guarda as chaves em memória com as regras de
+// IdempotencyRepo: a reserva vencida é retomada com um token novo e só a
+// dona grava ou libera a chave.
+type fakeIdempotencyRepo struct {
+    domain.IdempotencyRepository
+    mu      sync.Mutex
+    records map[string]domain.IdempotencyRecord
+    tokens  int
+}
+
+func newFakeIdempotencyRepo() *fakeIdempotencyRepo {
+    return &fakeIdempotencyRepo{records: map[string]domain.IdempotencyRecord{}}
+}
+
+func (f *fakeIdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, lease time.Duration) (*domain.IdempotencyRecord, bool, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    now := time.Now()
+    id := rec.UserID + "/" + rec.Key
+    if cur, ok := f.records[id]; ok {
+        abandoned := !cur.Completed() && !cur.CreatedAt.After(now.Add(-lease))
+        if !abandoned {
+            return &cur, false, nil
+        }
+    }
+    f.tokens++
+    rec.Token = fmt.Sprint(f.tokens)
+    rec.CreatedAt = now
+    f.records[id] = *rec
+    return rec, true, nil
+}
+
+func (f *fakeIdempotencyRepo) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    id := rec.UserID + "/" + rec.Key
+    if cur, ok := f.records[id]; !ok || cur.Token != rec.Token || cur.Completed() {
+        return domain.ErrIdempotencyKeyLost
+    }
+    f.records[id] = *rec
+    return nil
+}
+
+func (f *fakeIdempotencyRepo) Release(ctx context.Context, rec *domain.IdempotencyRecord) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    id := rec.UserID + "/" + rec.Key
+    if cur, ok := f.records[id]; ok && cur.Token == rec.Token && !cur.Completed() {
+        delete(f.records, id)
+    }
+    return nil
+}
+
+// idempotentServer monta o middleware com lease longo sobre handler,
+// autenticando cada requisição como user-1.
+func idempotentServer(repo domain.IdempotencyRepository, lease time.Duration, handler http.HandlerFunc) http.Handler {
+    mw := Idempotency(repo, time.Hour, lease, logger.New("error", "text", io.Discard))
+    h := mw(handler)
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        h.ServeHTTP(w, r.WithContext(domain.WithUserID(r.Context(), "user-1")))
+    })
+}
+
+func idempotentRequest(srv http.Handler, key, body string) *httptest.ResponseRecorder {
+    r := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+    r.Header.Set("Idempotency-Key", key)
+    w := httptest.NewRecorder()
+    srv.ServeHTTP(w, r)
+    return w
+}
+
+func TestIdempotencyReplaysTheStoredResponse(t *testing.T) {
+    calls := 0
+    srv := idempotentServer(newFakeIdempotencyRepo(), time.Hour, func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        body, _ := io.ReadAll(r.Body)
+        w.Header().Set("Location", "/tasks/1")
+        w.WriteHeader(http.StatusCreated)
+        fmt.Fprintf(w, `{"call":%d,"body":%q}`, calls, body)
+    })
+
+    first := idempotentRequest(srv, "k1", `{"title":"a"}`)
+    second := idempotentRequest(srv, "k1", `{"title":"a"}`)
+    if calls != 1 {
+        t.Fatalf("handler ran %d times, want 1", calls)
+    }
+    if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+        t.Errorf("replay = %d %s, want %d %s", second.Code, second.Body, first.Code, first.Body)
+    }
+    if second.Header().Get("Location") != "/tasks/1" || second.Header().Get("Idempotent-Replayed") != "true" {
+        t.Errorf("replay headers = %v", second.Header())
+    }
+    if first.Header().Get("Idempotent-Replayed") != "" {
+        t.Errorf("original response marked as replayed")
+    }
+
+    // Outra chave executa de novo.
+    if third := idempotentRequest(srv, "k2", `{"title":"a"}`); calls != 2 || third.Header().Get("Idempotent-Replayed") != "" {
+        t.Errorf("new key: calls = %d, headers %v", calls, third.Header())
+    }
+}
+
+func TestIdempotencyRejectsADifferentRequest(t *testing.T) {
+    calls := 0
+    srv := idempotentServer(newFakeIdempotencyRepo(), time.Hour, func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        w.WriteHeader(http.StatusCreated)
+    })
+
+    idempotentRequest(srv, "k1", `{"title":"a"}`)
+    w := idempotentRequest(srv, "k1", `{"title":"b"}`)
+    if w.Code != http.StatusUnprocessableEntity {
+        t.Errorf("status = %d, want 422", w.Code)
+    }
+    if calls != 1 {
+        t.Errorf("handler ran %d times, want 1", calls)
+    }
+}
+
+func TestIdempotencyRejectsAConcurrentRequest(t *testing.T) {
+    started, release := make(chan struct{}), make(chan struct{})
+    srv := idempotentServer(newFakeIdempotencyRepo(), time.Hour, func(w http.ResponseWriter, r *http.Request) {
+        close(started)
+        <-release
+        w.WriteHeader(http.StatusCreated)
+    })
+
+    done := make(chan *httptest.ResponseRecorder)
+    go func() { done <- idempotentRequest(srv, "k1", `{}`) }()
+    <-started
+
+    w := idempotentRequest(srv, "k1", `{}`)
+    if w.Code != http.StatusConflict || w.Header().Get("Retry-After") != "1" {
+        t.Errorf("in flight = %d (Retry-After %q), want 409", w.Code, w.Header().Get("Retry-After"))
+    }
+    close(release)
+    if first := <-done; first.Code != http.StatusCreated {
+        t.Fatalf("original = %d", first.Code)
+    }
+    // Concluída a original, a mesma chave repete a resposta.
+    if w := idempotentRequest(srv, "k1", `{}`); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+        t.Errorf("after completion = %d %v", w.Code, w.Header())
+    }
+}
+
+func TestIdempotencyReleasesServerErrors(t *testing.T) {
+    calls := 0
+    srv := idempotentServer(newFakeIdempotencyRepo(), time.Hour, func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        if calls == 1 {
+            w.WriteHeader(http.StatusServiceUnavailable)
+            return
+        }
+        w.WriteHeader(http.StatusCreated)
+    })
+
+    if w := idempotentRequest(srv, "k1", `{}`); w.Code != http.StatusServiceUnavailable {
+        t.Fatalf("first = %d", w.Code)
+    }
+    if w := idempotentRequest(srv, "k1", `{}`); w.Code != http.StatusCreated || calls != 2 {
+        t.Errorf("retry = %d after %d calls, want a new execution", w.Code, calls)
+    }
+}
+
+func TestIdempotencyKeepsTheNewOwnersResponse(t *testing.T) {
+    repo := newFakeIdempotencyRepo()
+    calls := 0
+    var srv http.Handler
+    srv = idempotentServer(repo, 0, func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        if calls == 1 {
+            // Com lease zero, o reenvio retoma a chave enquanto esta ainda roda.
+            if w := idempotentRequest(srv, "k1", `{}`); w.Code != http.StatusCreated {
+                t.Errorf("takeover = %d", w.Code)
+            }
+            w.WriteHeader(http.StatusOK)
+            io.WriteString(w, "slow")
+            return
+        }
+        w.WriteHeader(http.StatusCreated)
+        io.WriteString(w, "retry")
+    })
+
+    if w := idempotentRequest(srv, "k1", `{}`); w.Code != http.StatusOK {
+        t.Fatalf("slow = %d", w.Code)
+    }
+    rec := repo.records["user-1/k1"]
+    if rec.Status != http.StatusCreated || string(rec.Body) != "retry" {
+        t.Errorf("stored = %d %q, want the new owner's response", rec.Status, rec.Body)
+    }
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// RequireAuth valida o token "Authorization: Bearer <jwt>" e anexa o ID do
+// usuário ao contexto da requisição; sem token válido responde 401.
+func RequireAuth(tokens domain.TokenService) mux.MiddlewareFunc {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            header := r.Header.Get("Authorization")
+            scheme, token, ok := strings.Cut(header, " ")
+            if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+                w.Header().Set("WWW-Authenticate", `Bearer realm="gopher-tasks"`)
+                writeProblem(w, r, http.StatusUnauthorized, "missing bearer token")
+                return
+            }
+
+            userID, err := tokens.Parse(strings.TrimSpace(token))
+            if err != nil {
+                w.Header().Set("WWW-Authenticate", `Bearer realm="gopher-tasks", error="invalid_token"`)
+                writeProblem(w, r, http.StatusUnauthorized, "invalid or expired token")
+                return
+            }
+
+            next.ServeHTTP(w, r.WithContext(domain.WithUserID(r.Context(), userID)))
+        })
+    }
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// taskListResponse é o envelope de GET /tasks.
+type taskListResponse struct {
+    Data []*domain.Task `json:"data"`
+    Page pageInfo       `json:"page"`
+}
+
+// pageInfo descreve a página retornada e como navegar para as vizinhas.
+type pageInfo struct {
+    Limit      int    `json:"limit" example:"20"`
+    NextCursor string `json:"next_cursor,omitempty" example:"eyJzIjoiY3JlYXRlZF9hdDpkZXNjIn0"`
+    PrevCursor string `json:"prev_cursor,omitempty"`
+    Total      *int   `json:"total,omitempty" example:"137"`
+}
+
+// newTaskListResponse monta o envelope a partir da página do use case.
+func newTaskListResponse(page *domain.TaskPage, limit int) taskListResponse {
+    // Garante que nunca seja retornado null, apenas um array vazio
+    tasks := page.Tasks
+    if tasks == nil {
+        tasks = make([]*domain.Task, 0)
+    }
+    return taskListResponse{
+        Data: tasks,
+        Page: pageInfo{
+            Limit:      limit,
+            NextCursor: page.NextCursor,
+            PrevCursor: page.PrevCursor,
+            Total:      page.Total,
+        },
+    }
+}
+
+// setPageLinks escreve o header Link (RFC 8288) com rel="next"/"prev",
+// preservando os demais parâmetros da requisição.
+func setPageLinks(w http.ResponseWriter, r *http.Request, info pageInfo) {
+    var links []string
+    link := func(cursor, rel string) {
+        q := r.URL.Query()
+        q.Set("cursor", cursor)
+        q.Set("limit", strconv.Itoa(info.Limit))
+        u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+        links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel))
+    }
+    if info.NextCursor != "" {
+        link(info.NextCursor, "next")
+    }
+    if info.PrevCursor != "" {
+        link(info.PrevCursor, "prev")
+    }
+    if len(links) > 0 {
+        w.Header().Set("Link", strings.Join(links, ", "))
+    }
+}
@@ -0,0 +1,312 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// createProjectRequest representa o payload para criação de Project.
+type createProjectRequest struct {
+    Name        string `json:"name" example:"Plataforma"`
+    Description string `json:"description" example:"Quadro do time de plataforma"`
+    Color       string `json:"color,omitempty" example:"#2e8b57"`
+}
+
+// updateProjectRequest representa o payload para alterar um Project.
+// Campos omitidos não são alterados.
+type updateProjectRequest struct {
+    Name        *string `json:"name,omitempty" example:"Plataforma"`
+    Description *string `json:"description,omitempty" example:"Quadro do time de plataforma"`
+    Color       *string `json:"color,omitempty" example:"#2e8b57"`
+    Archived    *bool   `json:"archived,omitempty" example:"false"`
+}
+
+// ProjectHandler agrupa os use cases e o logger para endpoints de Project.
+type ProjectHandler struct {
+    CreateUC *usecase.CreateProjectUseCase
+    ListUC   *usecase.ListProjectsUseCase
+    GetUC    *usecase.GetProjectUseCase
+    UpdateUC *usecase.UpdateProjectUseCase
+    DeleteUC *usecase.DeleteProjectUseCase
+    TasksUC  *usecase.ListProjectTasksUseCase
+    Log      logger.Logger
+}
+
+// NewProjectHandler injeta os use cases de Project, além do logger.
+func NewProjectHandler(
+    createUC *usecase.CreateProjectUseCase,
+    listUC *usecase.ListProjectsUseCase,
+    getUC *usecase.GetProjectUseCase,
+    updateUC *usecase.UpdateProjectUseCase,
+    deleteUC *usecase.DeleteProjectUseCase,
+    tasksUC *usecase.ListProjectTasksUseCase,
+    log logger.Logger,
+) *ProjectHandler {
+    return &ProjectHandler{
+        CreateUC: createUC,
+        ListUC:   listUC,
+        GetUC:    getUC,
+        UpdateUC: updateUC,
+        DeleteUC: deleteUC,
+        TasksUC:  tasksUC,
+        Log:      log,
+    }
+}
+
+// CreateProject godoc
+// @Summary      Cria um projeto
+// @Description  Cria um projeto do usuário para agrupar tasks; o nome é único sem diferenciar maiúsculas
+// @Tags         projects
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  body      createProjectRequest  true  "Payload para criar projeto"
+// @Success      201      {object}  domain.Project
+// @Failure      400      {object}  problemDetails
+// @Failure      401      {object}  problemDetails
+// @Failure      409      {object}  problemDetails
+// @Failure      422      {object}  problemDetails
+// @Failure      500      {object}  problemDetails
+// @Router       /projects [post]
+func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var req createProjectRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    project, err := h.CreateUC.Execute(r.Context(), usecase.CreateProjectInput{
+        Name:        req.Name,
+        Description: req.Description,
+        Color:       req.Color,
+    })
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to create project")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(project)
+}
+
+// ListProjects godoc
+// @Summary      Lista projetos
+// @Description  Retorna os projetos do usuário em ordem alfabética; arquivados apenas com include_archived
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        include_archived  query     bool  false  "Inclui projetos arquivados"
+// @Success      200               {array}   domain.Project
+// @Failure      400               {object}  problemDetails
+// @Failure      401               {object}  problemDetails
+// @Failure      500               {object}  problemDetails
+// @Router       /projects [get]
+func (h *ProjectHandler) List(w http.ResponseWriter, r *http.Request) {
+    var includeArchived bool
+    if v := r.URL.Query().Get("include_archived"); v != "" {
+        b, err := strconv.ParseBool(v)
+        if err != nil {
+            writeProblem(w, r, http.StatusBadRequest, "invalid query parameter",
+                domain.FieldError{Field: "include_archived", Message: "must be a boolean"})
+            return
+        }
+        includeArchived = b
+    }
+
+    projects, err := h.ListUC.Execute(r.Context(), includeArchived)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list projects")
+        return
+    }
+
+    // Garante que nunca seja retornado null, apenas um array vazio
+    if projects == nil {
+        projects = make([]*domain.Project, 0)
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(projects)
+}
+
+// GetProject godoc
+// @Summary      Busca um projeto
+// @Description  Retorna o projeto com o ID informado
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID do projeto"
+// @Success      200  {object}  domain.Project
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /projects/{id} [get]
+func (h *ProjectHandler) Get(w http.ResponseWriter, r *http.Request) {
+    project, err := h.GetUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get project")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(project)
+}
+
+// UpdateProject godoc
+// @Summary      Altera um projeto
+// @Description  Altera nome, descrição, cor ou o arquivamento do projeto
+// @Tags         projects
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "ID do projeto"
+// @Param        project  body      updateProjectRequest  true  "Campos a alterar"
+// @Success      200      {object}  domain.Project
+// @Failure      400      {object}  problemDetails
+// @Failure      401      {object}  problemDetails
+// @Failure      404      {object}  problemDetails
+// @Failure      409      {object}  problemDetails
+// @Failure      422      {object}  problemDetails
+// @Failure      500      {object}  problemDetails
+// @Router       /projects/{id} [patch]
+func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
+    var req updateProjectRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    h.update(w, r, usecase.ProjectPatch{
+        Name:        req.Name,
+        Description: req.Description,
+        Color:       req.Color,
+        Archived:    req.Archived,
+    })
+}
+
+// ArchiveProject godoc
+// @Summary      Arquiva um projeto
+// @Description  Arquiva o projeto: as tasks são mantidas, mas o projeto não recebe novas tasks
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID do projeto"
+// @Success      200  {object}  domain.Project
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /projects/{id}/archive [post]
+func (h *ProjectHandler) Archive(w http.ResponseWriter, r *http.Request) {
+    archived := true
+    h.update(w, r, usecase.ProjectPatch{Archived: &archived})
+}
+
+// UnarchiveProject godoc
+// @Summary      Reativa um projeto
+// @Description  Desfaz o arquivamento do projeto
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID do projeto"
+// @Success      200  {object}  domain.Project
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /projects/{id}/unarchive [post]
+func (h *ProjectHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+    archived := false
+    h.update(w, r, usecase.ProjectPatch{Archived: &archived})
+}
+
+func (h *ProjectHandler) update(w http.ResponseWriter, r *http.Request, patch usecase.ProjectPatch) {
+    project, err := h.UpdateUC.Execute(r.Context(), mux.Vars(r)["id"], patch)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to update project")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(project)
+}
+
+// DeleteProject godoc
+// @Summary      Remove um projeto
+// @Description  Remove o projeto. Se ainda houver tasks, responde 409, a menos que cascade=true,
+// @Description  que remove o projeto junto com todas as suas tasks.
+// @Tags         projects
+// @Security     BearerAuth
+// @Param        id       path      string  true   "ID do projeto"
+// @Param        cascade  query     bool    false  "Remove também as tasks do projeto"
+// @Success      204
+// @Failure      400      {object}  problemDetails
+// @Failure      401      {object}  problemDetails
+// @Failure      404      {object}  problemDetails
+// @Failure      409      {object}  problemDetails
+// @Failure      500      {object}  problemDetails
+// @Router       /projects/{id} [delete]
+func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    var cascade bool
+    if v := r.URL.Query().Get("cascade"); v != "" {
+        b, err := strconv.ParseBool(v)
+        if err != nil {
+            writeProblem(w, r, http.StatusBadRequest, "invalid query parameter",
+                domain.FieldError{Field: "cascade", Message: "must be a boolean"})
+            return
+        }
+        cascade = b
+    }
+
+    if err := h.DeleteUC.Execute(r.Context(), mux.Vars(r)["id"], cascade); err != nil {
+        writeError(w, r, h.Log, err, "failed to delete project")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProjectTasks godoc
+// @Summary      Lista as tasks de um projeto
+// @Description  Mesmos filtros, ordenação e paginação de GET /tasks, restritos ao projeto
+// @Tags         projects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id             path      string  true   "ID do projeto"
+// @Param        status         query     string  false  "Status separados por vírgula"
+// @Param        priority       query     string  false  "Prioridades separadas por vírgula"
+// @Param        tags           query     string  false  "Filtro por tags (any:, all:, none:)"
+// @Param        q              query     string  false  "Busca textual em título e descrição"
+// @Param        sort           query     string  false  "Ordenação, ex.: due_date,-priority"
+// @Param        limit          query     int     false  "Tamanho da página (limitado pelo servidor)"
+// @Param        cursor         query     string  false  "Cursor opaco retornado em page.next_cursor/prev_cursor"
+// @Param        include_total  query     bool    false  "Inclui page.total"
+// @Success      200            {object}  taskListResponse
+// @Failure      400            {object}  problemDetails
+// @Failure      401            {object}  problemDetails
+// @Failure      404            {object}  problemDetails
+// @Failure      422            {object}  problemDetails
+// @Failure      500            {object}  problemDetails
+// @Router       /projects/{id}/tasks [get]
+func (h *ProjectHandler) Tasks(w http.ResponseWriter, r *http.Request) {
+    filter, fields := parseTaskFilter(r.URL.Query())
+    if len(fields) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameter", fields...)
+        return
+    }
+
+    page, err := h.TasksUC.Execute(r.Context(), mux.Vars(r)["id"], filter)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list project tasks")
+        return
+    }
+
+    resp := newTaskListResponse(page, h.TasksUC.Tasks.PageSize(filter.Limit))
+    setPageLinks(w, r, resp.Page)
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
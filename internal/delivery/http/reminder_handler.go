@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// errInvalidReminderAt é o erro de validação para at em formato não reconhecido.
+var errInvalidReminderAt = domain.NewValidationError(domain.FieldError{
+    Field:   "at",
+    Message: "must be an RFC3339 timestamp",
+})
+
+// createReminderRequest representa o payload para agendar um lembrete:
+// informe at ou before_minutes.
+type createReminderRequest struct {
+    At            string `json:"at,omitempty" example:"2025-06-01T09:00:00Z"`
+    BeforeMinutes *int   `json:"before_minutes,omitempty" example:"60"`
+}
+
+// ReminderHandler agrupa os use cases e o logger para os lembretes de uma Task.
+type ReminderHandler struct {
+    CreateUC *usecase.CreateReminderUseCase
+    ListUC   *usecase.ListRemindersUseCase
+    DeleteUC *usecase.DeleteReminderUseCase
+    Log      logger.Logger
+}
+
+// NewReminderHandler injeta os use cases de lembretes, além do logger.
+func NewReminderHandler(
+    createUC *usecase.CreateReminderUseCase,
+    listUC *usecase.ListRemindersUseCase,
+    deleteUC *usecase.DeleteReminderUseCase,
+    log logger.Logger,
+) *ReminderHandler {
+    return &ReminderHandler{CreateUC: createUC, ListUC: listUC, DeleteUC: deleteUC, Log: log}
+}
+
+// ListReminders godoc
+// @Summary      Lista os lembretes de uma task
+// @Description  Retorna os lembretes, enviados ou não, em ordem de disparo
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID da task"
+// @Success      200  {array}   domain.Reminder
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tasks/{id}/reminders [get]
+func (h *ReminderHandler) List(w http.ResponseWriter, r *http.Request) {
+    reminders, err := h.ListUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list reminders")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(reminders)
+}
+
+// CreateReminder godoc
+// @Summary      Agenda um lembrete para uma task
+// @Description  O lembrete dispara em um horário fixo (at, RFC3339) ou before_minutes antes do
+// @Description  vencimento, acompanhando mudanças em due_date. É entregue pelos canais configurados
+// @Description  no servidor (log, webhook, e-mail) enquanto a task estiver aberta.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                 true  "ID da task"
+// @Param        reminder  body      createReminderRequest  true  "Gatilho do lembrete"
+// @Success      201       {object}  domain.Reminder
+// @Failure      400       {object}  problemDetails
+// @Failure      401       {object}  problemDetails
+// @Failure      404       {object}  problemDetails
+// @Failure      422       {object}  problemDetails
+// @Failure      500       {object}  problemDetails
+// @Router       /tasks/{id}/reminders [post]
+func (h *ReminderHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var req createReminderRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    in := usecase.CreateReminderInput{BeforeMinutes: req.BeforeMinutes}
+    if req.At != "" {
+        at, err := time.Parse(time.RFC3339, req.At)
+        if err != nil {
+            writeError(w, r, h.Log, errInvalidReminderAt, "invalid at")
+            return
+        }
+        in.At = &at
+    }
+
+    reminder, err := h.CreateUC.Execute(r.Context(), mux.Vars(r)["id"], in)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to create reminder")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(reminder)
+}
+
+// DeleteReminder godoc
+// @Summary      Cancela um lembrete
+// @Tags         tasks
+// @Security     BearerAuth
+// @Param        id          path      string  true  "ID da task"
+// @Param        reminderId  path      string  true  "ID do lembrete"
+// @Success      204
+// @Failure      401         {object}  problemDetails
+// @Failure      404         {object}  problemDetails
+// @Failure      500         {object}  problemDetails
+// @Router       /tasks/{id}/reminders/{reminderId} [delete]
+func (h *ReminderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    if err := h.DeleteUC.Execute(r.Context(), vars["id"], vars["reminderId"]); err != nil {
+        writeError(w, r, h.Log, err, "failed to delete reminder")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
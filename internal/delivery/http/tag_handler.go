@@ -0,0 +1,189 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// createTagRequest representa o payload para criação de Tag.
+type createTagRequest struct {
+    Name  string `json:"name" example:"backend"`
+    Color string `json:"color,omitempty" example:"#1e90ff"`
+}
+
+// updateTagRequest representa o payload para alterar uma Tag.
+// Campos omitidos não são alterados.
+type updateTagRequest struct {
+    Name  *string `json:"name,omitempty" example:"backend"`
+    Color *string `json:"color,omitempty" example:"#1e90ff"`
+}
+
+// TagHandler agrupa os use cases e o logger para endpoints de Tag.
+type TagHandler struct {
+    CreateUC *usecase.CreateTagUseCase
+    ListUC   *usecase.ListTagsUseCase
+    GetUC    *usecase.GetTagUseCase
+    UpdateUC *usecase.UpdateTagUseCase
+    DeleteUC *usecase.DeleteTagUseCase
+    Log      logger.Logger
+}
+
+// NewTagHandler injeta os use cases de Tag, além do logger.
+func NewTagHandler(
+    createUC *usecase.CreateTagUseCase,
+    listUC *usecase.ListTagsUseCase,
+    getUC *usecase.GetTagUseCase,
+    updateUC *usecase.UpdateTagUseCase,
+    deleteUC *usecase.DeleteTagUseCase,
+    log logger.Logger,
+) *TagHandler {
+    return &TagHandler{
+        CreateUC: createUC,
+        ListUC:   listUC,
+        GetUC:    getUC,
+        UpdateUC: updateUC,
+        DeleteUC: deleteUC,
+        Log:      log,
+    }
+}
+
+// CreateTag godoc
+// @Summary      Cria uma tag
+// @Description  Cria uma tag do usuário; o nome é único sem diferenciar maiúsculas
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tag  body      createTagRequest  true  "Payload para criar tag"
+// @Success      201  {object}  domain.Tag
+// @Failure      400  {object}  problemDetails
+// @Failure      401  {object}  problemDetails
+// @Failure      409  {object}  problemDetails
+// @Failure      422  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tags [post]
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var req createTagRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    tag, err := h.CreateUC.Execute(r.Context(), usecase.CreateTagInput{Name: req.Name, Color: req.Color})
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to create tag")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(tag)
+}
+
+// ListTags godoc
+// @Summary      Lista tags
+// @Description  Retorna as tags do usuário em ordem alfabética, com a quantidade de tasks de cada uma
+// @Tags         tags
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   domain.Tag
+// @Failure      401  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tags [get]
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+    tags, err := h.ListUC.Execute(r.Context())
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list tags")
+        return
+    }
+
+    // Garante que nunca seja retornado null, apenas um array vazio
+    if tags == nil {
+        tags = make([]*domain.Tag, 0)
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(tags)
+}
+
+// GetTag godoc
+// @Summary      Busca uma tag
+// @Description  Retorna a tag com o ID informado
+// @Tags         tags
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID da tag"
+// @Success      200  {object}  domain.Tag
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tags/{id} [get]
+func (h *TagHandler) Get(w http.ResponseWriter, r *http.Request) {
+    tag, err := h.GetUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get tag")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(tag)
+}
+
+// UpdateTag godoc
+// @Summary      Altera uma tag
+// @Description  Renomeia ou muda a cor da tag; o novo nome vale para todas as tasks marcadas
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string            true  "ID da tag"
+// @Param        tag  body      updateTagRequest  true  "Campos a alterar"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}  problemDetails
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      409  {object}  problemDetails
+// @Failure      422  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tags/{id} [patch]
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+    var req updateTagRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    tag, err := h.UpdateUC.Execute(r.Context(), mux.Vars(r)["id"], usecase.TagPatch{Name: req.Name, Color: req.Color})
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to update tag")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(tag)
+}
+
+// DeleteTag godoc
+// @Summary      Remove uma tag
+// @Description  Remove a tag e a desassocia de todas as tasks
+// @Tags         tags
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID da tag"
+// @Success      204
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    if err := h.DeleteUC.Execute(r.Context(), mux.Vars(r)["id"]); err != nil {
+        writeError(w, r, h.Log, err, "failed to delete tag")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,339 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+const (
+    batchAtomic     = "atomic"      // tudo ou nada, em uma transação
+    batchBestEffort = "best_effort" // cada operação por si, com resultado por item
+)
+
+// bulkFilterParams são os filtros de GET /tasks aceitos no bulk update;
+// ordenação e paginação não se aplicam.
+var bulkFilterParams = map[string]bool{
+    "project_id": true, "parent_id": true, "completed": true, "status": true, "priority": true,
+    "due_before": true, "due_after": true, "overdue": true, "created_before": true, "created_after": true,
+    "updated_before": true, "updated_after": true, "q": true, "tags": true,
+}
+
+// taskBatchRequest representa o payload de POST /tasks:batch.
+type taskBatchRequest struct {
+    Mode       string               `json:"mode,omitempty" example:"atomic" enums:"atomic,best_effort"`
+    Operations []taskBatchOperation `json:"operations"`
+}
+
+// taskBatchOperation é um item do lote. task é um createTaskRequest em create
+// e um patchTaskRequest em update; version funciona como o If-Match.
+type taskBatchOperation struct {
+    Op      string          `json:"op" example:"update" enums:"create,update,delete,complete"`
+    ID      string          `json:"id,omitempty" example:"0b7e4c1a-0000-4000-8000-000000000000"`
+    Version int             `json:"version,omitempty" example:"3"`
+    Task    json.RawMessage `json:"task,omitempty" swaggertype:"object"`
+}
+
+// taskBulkUpdateRequest representa o payload de POST /tasks:bulk-update.
+// filter usa os nomes dos parâmetros de GET /tasks, com listas como arrays
+// (ex.: {"project_id": "...", "overdue": true, "status": ["todo"]}).
+type taskBulkUpdateRequest struct {
+    Mode   string                 `json:"mode,omitempty" example:"atomic" enums:"atomic,best_effort"`
+    Filter map[string]interface{} `json:"filter" swaggertype:"object"`
+    Patch  patchTaskRequest       `json:"patch"`
+}
+
+// taskBatchResponse traz o resultado de cada operação, na ordem do pedido.
+type taskBatchResponse struct {
+    Mode      string            `json:"mode" example:"best_effort"`
+    Succeeded int               `json:"succeeded" example:"2"`
+    Failed    int               `json:"failed" example:"1"`
+    Results   []taskBatchResult `json:"results"`
+}
+
+// taskBatchResult é o resultado de uma operação: status é o que o endpoint
+// da operação isolada responderia, com a task gravada ou o erro.
+type taskBatchResult struct {
+    Index  int             `json:"index" example:"0"`
+    Op     string          `json:"op" example:"update"`
+    ID     string          `json:"id,omitempty" example:"0b7e4c1a-0000-4000-8000-000000000000"`
+    Status int             `json:"status" example:"200"`
+    Task   *domain.Task    `json:"task,omitempty"`
+    Error  *problemDetails `json:"error,omitempty"`
+}
+
+// TaskBatchHandler expõe as operações em lote sobre Tasks.
+type TaskBatchHandler struct {
+    BatchUC        *usecase.BatchTasksUseCase
+    BulkUpdateUC   *usecase.BulkUpdateTasksUseCase
+    RequireIfMatch bool // update, delete e complete do lote exigem version
+    Log            logger.Logger
+}
+
+// NewTaskBatchHandler injeta os use cases de lote, a exigência de versão e o logger.
+func NewTaskBatchHandler(batchUC *usecase.BatchTasksUseCase, bulkUpdateUC *usecase.BulkUpdateTasksUseCase, requireIfMatch bool, log logger.Logger) *TaskBatchHandler {
+    return &TaskBatchHandler{BatchUC: batchUC, BulkUpdateUC: bulkUpdateUC, RequireIfMatch: requireIfMatch, Log: log}
+}
+
+// BatchTasks godoc
+// @Summary      Executa várias operações de task
+// @Description  Aplica, em ordem, operações create, update (parcial, como o PATCH com application/json),
+// @Description  delete e complete (status done), com as mesmas regras dos endpoints de uma task. No modo
+// @Description  atomic (padrão) todas rodam em uma transação: a primeira que falha desfaz as anteriores e a
+// @Description  resposta é o erro dela, com operations[i] no detail. Em best_effort cada operação é gravada
+// @Description  por si e a resposta traz o status e a task (ou o erro) de cada uma. version funciona como o
+// @Description  If-Match (obrigatório se concurrency.requireifmatch). Os eventos só são emitidos após a gravação.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header    string            false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Param        batch            body      taskBatchRequest  true   "Modo e lista de operações"
+// @Success      200              {object}  taskBatchResponse
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      404              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      412              {object}  problemDetails
+// @Failure      422              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
+// @Router       /tasks:batch [post]
+func (h *TaskBatchHandler) Batch(w http.ResponseWriter, r *http.Request) {
+    var req taskBatchRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+    atomic, ok := batchMode(w, r, req.Mode)
+    if !ok {
+        return
+    }
+
+    ops := make([]usecase.TaskBatchOperation, len(req.Operations))
+    for i, op := range req.Operations {
+        ops[i] = h.batchOperation(op)
+    }
+
+    results, err := h.BatchUC.Execute(r.Context(), ops, atomic)
+    if err != nil {
+        h.writeBatchError(w, r, err, func(e *usecase.TaskBatchError) string {
+            return fmt.Sprintf("operations[%d]", e.Index)
+        })
+        return
+    }
+
+    opNames := make([]string, len(req.Operations))
+    for i, op := range req.Operations {
+        opNames[i] = op.Op
+    }
+    h.writeBatchResponse(w, r, req.Mode, opNames, results)
+}
+
+// batchOperation converte um item do lote; problemas no item vão em Err.
+func (h *TaskBatchHandler) batchOperation(op taskBatchOperation) usecase.TaskBatchOperation {
+    out := usecase.TaskBatchOperation{Op: usecase.TaskBatchOp(op.Op), ID: op.ID, ExpectedVersion: op.Version}
+    invalid := func(field, msg string) usecase.TaskBatchOperation {
+        out.Err = domain.NewValidationError(domain.FieldError{Field: field, Message: msg})
+        return out
+    }
+
+    switch out.Op {
+    case usecase.TaskBatchCreate:
+        var req createTaskRequest
+        if err := json.Unmarshal(op.Task, &req); err != nil {
+            return invalid("task", "must be a task object")
+        }
+        out.Create, out.Err = createTaskInput(req)
+        return out
+    case usecase.TaskBatchUpdate, usecase.TaskBatchDelete, usecase.TaskBatchComplete:
+    default:
+        return invalid("op", "must be one of create, update, delete, complete")
+    }
+
+    switch {
+    case op.ID == "":
+        return invalid("id", "is required")
+    case op.Version < 0:
+        return invalid("version", "must be a positive integer")
+    case op.Version == 0 && h.RequireIfMatch:
+        return invalid("version", "is required")
+    }
+    if out.Op == usecase.TaskBatchUpdate {
+        var req patchTaskRequest
+        if err := json.Unmarshal(op.Task, &req); err != nil {
+            return invalid("task", "must be a task object")
+        }
+        out.Patch, out.Err = taskPatchInput(req)
+    }
+    return out
+}
+
+// BulkUpdateTasks godoc
+// @Summary      Atualiza todas as tasks que casam com um filtro
+// @Description  Aplica o mesmo patch (os campos de patchTaskRequest) a cada task que satisfaz filter, que
+// @Description  aceita os filtros de GET /tasks (ex.: {"project_id": "...", "overdue": true} com patch
+// @Description  {"status": "blocked"}). Cada task é gravada na versão lida; os modos são os de POST /tasks:batch.
+// @Description  Um filtro vazio ou que casa com mais tasks que o limite do servidor resulta em 422.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header    string                 false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Param        bulk             body      taskBulkUpdateRequest  true   "Modo, filtro e patch"
+// @Success      200              {object}  taskBatchResponse
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      422              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
+// @Router       /tasks:bulk-update [post]
+func (h *TaskBatchHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
+    var req taskBulkUpdateRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+    atomic, ok := batchMode(w, r, req.Mode)
+    if !ok {
+        return
+    }
+
+    q, fields := bulkFilterQuery(req.Filter)
+    filter, filterFields := parseTaskFilter(q)
+    for _, f := range filterFields {
+        fields = append(fields, domain.FieldError{Field: "filter." + f.Field, Message: f.Message})
+    }
+    if len(fields) > 0 {
+        writeError(w, r, h.Log, domain.NewValidationError(fields...), "invalid filter")
+        return
+    }
+    patch, err := taskPatchInput(req.Patch)
+    if err != nil {
+        writeError(w, r, h.Log, err, "invalid patch")
+        return
+    }
+
+    results, err := h.BulkUpdateUC.Execute(r.Context(), filter, patch, atomic)
+    if err != nil {
+        h.writeBatchError(w, r, err, func(e *usecase.TaskBatchError) string {
+            return fmt.Sprintf("tasks[%s]", e.ID)
+        })
+        return
+    }
+
+    opNames := make([]string, len(results))
+    for i := range opNames {
+        opNames[i] = string(usecase.TaskBatchUpdate)
+    }
+    h.writeBatchResponse(w, r, req.Mode, opNames, results)
+}
+
+// bulkFilterQuery traduz o filtro em JSON para a query string que
+// parseTaskFilter entende.
+func bulkFilterQuery(filter map[string]interface{}) (url.Values, []domain.FieldError) {
+    q := url.Values{}
+    var fields []domain.FieldError
+    invalid := func(field, msg string) {
+        fields = append(fields, domain.FieldError{Field: "filter." + field, Message: msg})
+    }
+    for name, value := range filter {
+        if !bulkFilterParams[name] {
+            invalid(name, "is not a supported filter")
+            continue
+        }
+        switch v := value.(type) {
+        case nil:
+        case string:
+            q.Add(name, v)
+        case bool:
+            q.Add(name, strconv.FormatBool(v))
+        case []interface{}:
+            for _, item := range v {
+                s, ok := item.(string)
+                if !ok {
+                    invalid(name, "must be an array of strings")
+                    break
+                }
+                q.Add(name, s)
+            }
+        default:
+            invalid(name, "must be a string, a boolean or an array of strings")
+        }
+    }
+    if len(q) == 0 && len(fields) == 0 {
+        fields = append(fields, domain.FieldError{Field: "filter", Message: "must have at least one condition"})
+    }
+    return q, fields
+}
+
+// batchMode lê o modo do lote; vazio é atomic.
+func batchMode(w http.ResponseWriter, r *http.Request, mode string) (bool, bool) {
+    switch mode {
+    case "", batchAtomic:
+        return true, true
+    case batchBestEffort:
+        return false, true
+    }
+    writeProblem(w, r, http.StatusUnprocessableEntity, "validation failed", domain.FieldError{
+        Field:   "mode",
+        Message: "must be atomic or best_effort",
+    })
+    return false, false
+}
+
+// writeBatchError responde a falha do lote atômico como o erro da operação
+// que falhou, com label (ex.: operations[3]) no detail e nos campos.
+func (h *TaskBatchHandler) writeBatchError(w http.ResponseWriter, r *http.Request, err error, label func(*usecase.TaskBatchError) string) {
+    var batchErr *usecase.TaskBatchError
+    if !errors.As(err, &batchErr) || r.Context().Err() != nil {
+        writeError(w, r, h.Log, err, "failed to run batch")
+        return
+    }
+    prefix := label(batchErr)
+    p := errorProblem(r, h.Log, batchErr.Err, "failed to run batch")
+    p.Detail = prefix + ": " + p.Detail
+    fields := make([]domain.FieldError, len(p.Errors))
+    for i, f := range p.Errors {
+        fields[i] = domain.FieldError{Field: prefix + "." + f.Field, Message: f.Message}
+    }
+    p.Errors = fields
+    writeProblemDetails(w, p)
+}
+
+// writeBatchResponse escreve o resultado de cada operação com o status que
+// o endpoint dela teria respondido.
+func (h *TaskBatchHandler) writeBatchResponse(w http.ResponseWriter, r *http.Request, mode string, ops []string, results []usecase.TaskBatchResult) {
+    if mode == "" {
+        mode = batchAtomic
+    }
+    resp := taskBatchResponse{Mode: mode, Results: make([]taskBatchResult, len(results))}
+    for i, res := range results {
+        item := taskBatchResult{Index: i, Op: ops[i], ID: res.ID, Task: res.Task}
+        switch {
+        case res.Err != nil:
+            p := errorProblem(r, h.Log, res.Err, "batch operation failed")
+            item.Status, item.Error = p.Status, &p
+            resp.Failed++
+        case ops[i] == string(usecase.TaskBatchCreate):
+            item.Status = http.StatusCreated
+            resp.Succeeded++
+        case ops[i] == string(usecase.TaskBatchDelete):
+            item.Status = http.StatusNoContent
+            resp.Succeeded++
+        default:
+            item.Status = http.StatusOK
+            resp.Succeeded++
+        }
+        resp.Results[i] = item
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// encodeTask devolve o JSON da Task e a sua entity tag: a versão seguida de
+// um hash da representação. O hash muda com o que não avança a versão (o
+// progresso das subtarefas, o nome de uma tag), para que o If-None-Match
+// não responda 304 a um corpo diferente.
+func encodeTask(task *domain.Task) ([]byte, string) {
+    body, _ := json.Marshal(task)
+    body = append(body, '\n')
+    sum := sha256.Sum256(body)
+    return body, strconv.Quote(strconv.Itoa(task.Version) + "-" + hex.EncodeToString(sum[:8]))
+}
+
+// taskETag é a entity tag de uma Task (ver encodeTask).
+func taskETag(task *domain.Task) string {
+    _, etag := encodeTask(task)
+    return etag
+}
+
+// writeTask responde a Task em JSON com o seu ETag.
+func writeTask(w http.ResponseWriter, status int, task *domain.Task) {
+    body, etag := encodeTask(task)
+    w.Header().Set("ETag", etag)
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    w.Write(body)
+}
+
+// ifMatchVersion lê a versão exigida pelo If-Match: a parte da ETag antes do
+// hash (um "3" sem hash também é aceito). O que o hash cobre além da versão
+// é derivado de outras Tasks e tags, e não conflita com a escrita. Sem o
+// header (ou com *) a versão é zero, que não é conferida; se require estiver
+// ligado, a falta do header é respondida com 428. * continua aceito mesmo
+// assim: é a forma explícita de sobrescrever sem conferir (a CLI a usa). Em
+// caso de erro a resposta já foi escrita e ok é false.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request, require bool) (version int, ok bool) {
+    v := strings.TrimSpace(r.Header.Get("If-Match"))
+    switch {
+    case v == "" && require:
+        writeProblem(w, r, http.StatusPreconditionRequired, "If-Match header is required; send the ETag of the task")
+        return 0, false
+    case v == "" || v == "*":
+        return 0, true
+    case strings.HasPrefix(v, "W/"):
+        // If-Match usa comparação forte: uma tag fraca nunca casa
+        writeProblem(w, r, http.StatusPreconditionFailed, "weak entity tags never match If-Match")
+        return 0, false
+    }
+    unquoted, err := strconv.Unquote(v)
+    if err == nil {
+        unquoted, _, _ = strings.Cut(unquoted, "-")
+        version, err = strconv.Atoi(unquoted)
+    }
+    if err != nil || version < 1 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid If-Match header", domain.FieldError{
+            Field:   "If-Match",
+            Message: `must be "*" or a single task ETag such as "3-9f86d081884c7d65"`,
+        })
+        return 0, false
+    }
+    return version, true
+}
+
+// notModified diz se o If-None-Match do GET já contém etag, o ETag atual da
+// Task (comparação fraca, como manda o RFC 9110).
+func notModified(r *http.Request, etag string) bool {
+    v := r.Header.Get("If-None-Match")
+    if v == "" {
+        return false
+    }
+    for _, tag := range strings.Split(v, ",") {
+        tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+        if tag == "*" || tag == etag {
+            return true
+        }
+    }
+    return false
+}
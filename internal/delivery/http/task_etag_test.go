@@ -0,0 +1,247 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// memTaskRepo guarda as Tasks em memória e confere a versão como TaskRepo;
+// os métodos não usados caem na interface embutida (nil) e entram em pânico.
+type memTaskRepo struct {
+    domain.TaskRepository
+    tasks map[string]*domain.Task
+    // beforeDelete, se definido, roda antes de Delete conferir a versão
+    beforeDelete func()
+}
+
+func (r *memTaskRepo) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+    t, ok := r.tasks[id]
+    if !ok {
+        return nil, domain.ErrTaskNotFound
+    }
+    clone := *t
+    return &clone, nil
+}
+
+func (r *memTaskRepo) Update(ctx context.Context, t *domain.Task) error {
+    return r.UpdateFields(ctx, t, nil)
+}
+
+func (r *memTaskRepo) UpdateFields(ctx context.Context, t *domain.Task, fields []domain.TaskField) error {
+    stored, ok := r.tasks[t.ID]
+    if !ok {
+        return domain.ErrTaskNotFound
+    }
+    if stored.Version != t.Version {
+        return domain.ErrTaskVersionConflict
+    }
+    t.Version++
+    clone := *t
+    r.tasks[t.ID] = &clone
+    return nil
+}
+
+func (r *memTaskRepo) Delete(ctx context.Context, id string, version int) error {
+    if r.beforeDelete != nil {
+        r.beforeDelete()
+    }
+    stored, ok := r.tasks[id]
+    if !ok {
+        return domain.ErrTaskNotFound
+    }
+    if stored.Version != version {
+        return domain.ErrTaskVersionConflict
+    }
+    delete(r.tasks, id)
+    return nil
+}
+
+func (r *memTaskRepo) Descendants(ctx context.Context, id string) ([]*domain.Task, error) {
+    return nil, nil
+}
+
+// noDeps é um grafo de dependências vazio.
+type noDeps struct {
+    domain.TaskDependencyRepository
+}
+
+func (noDeps) BlockedBy(ctx context.Context, id string) ([]*domain.Task, error) { return nil, nil }
+func (noDeps) Blocks(ctx context.Context, id string) ([]*domain.Task, error)    { return nil, nil }
+func (noDeps) SyncBlocked(ctx context.Context, ids []string) error              { return nil }
+
+// newETagHandler monta um TaskHandler com a Task "t1" na versão 2.
+func newETagHandler(requireIfMatch bool) (*TaskHandler, *memTaskRepo) {
+    repo := &memTaskRepo{tasks: map[string]*domain.Task{
+        "t1": {ID: "t1", Title: "Write report", Status: domain.StatusTodo, Priority: domain.PriorityMedium, Tags: []string{"work"}, Version: 2},
+    }}
+    h := NewTaskHandler(
+        nil, nil,
+        usecase.NewGetTaskUseCase(repo),
+        usecase.NewUpdateTaskUseCase(repo, nil, noDeps{}, nil, nil, nil),
+        usecase.NewPatchTaskUseCase(repo, nil, noDeps{}, nil, nil, nil),
+        usecase.NewDeleteTaskUseCase(repo, noDeps{}, nil, nil),
+        nil, nil, nil,
+        requireIfMatch,
+        logger.New("error", "text", io.Discard),
+    )
+    return h, repo
+}
+
+// serveTask chama handler para a Task "t1" com os headers informados.
+func serveTask(handler http.HandlerFunc, method, body string, header map[string]string) *httptest.ResponseRecorder {
+    r := httptest.NewRequest(method, "/tasks/t1", strings.NewReader(body))
+    r = mux.SetURLVars(r, map[string]string{"id": "t1"})
+    for k, v := range header {
+        r.Header.Set(k, v)
+    }
+    w := httptest.NewRecorder()
+    handler(w, r)
+    return w
+}
+
+var etagPattern = regexp.MustCompile(`^"2-[0-9a-f]{16}"$`)
+
+func TestGetTaskETag(t *testing.T) {
+    h, repo := newETagHandler(false)
+
+    w := serveTask(h.Get, http.MethodGet, "", nil)
+    etag := w.Header().Get("ETag")
+    if w.Code != http.StatusOK || !etagPattern.MatchString(etag) {
+        t.Fatalf("GET = %d with ETag %s, want 200 with the version and a hash", w.Code, etag)
+    }
+    var task domain.Task
+    if err := json.Unmarshal(w.Body.Bytes(), &task); err != nil || task.Version != 2 {
+        t.Fatalf("body = %s", w.Body)
+    }
+
+    for _, inm := range []string{etag, "W/" + etag, `"1-0000000000000000", ` + etag, "*"} {
+        w := serveTask(h.Get, http.MethodGet, "", map[string]string{"If-None-Match": inm})
+        if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+            t.Errorf("If-None-Match %s = %d %q (ETag %s), want an empty 304", inm, w.Code, w.Body, w.Header().Get("ETag"))
+        }
+    }
+    if w := serveTask(h.Get, http.MethodGet, "", map[string]string{"If-None-Match": `"2"`}); w.Code != http.StatusOK {
+        t.Errorf("If-None-Match with the version alone = %d, want 200", w.Code)
+    }
+
+    // Progresso e nomes de tags mudam sem avançar a versão da Task, mas
+    // mudam o corpo: o ETag antigo não pode dar 304.
+    changes := map[string]func(*domain.Task){
+        "subtask completed": func(t *domain.Task) { t.Progress = &domain.TaskProgress{Total: 2, Completed: 1, Percent: 50} },
+        "tag renamed":       func(t *domain.Task) { t.Tags = []string{"office"} },
+    }
+    for name, change := range changes {
+        change(repo.tasks["t1"])
+        w := serveTask(h.Get, http.MethodGet, "", map[string]string{"If-None-Match": etag})
+        if w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+            t.Errorf("%s: GET = %d with ETag %s, want 200 with a new ETag", name, w.Code, w.Header().Get("ETag"))
+        }
+        if !etagPattern.MatchString(w.Header().Get("ETag")) {
+            t.Errorf("%s: ETag %s lost the version", name, w.Header().Get("ETag"))
+        }
+        etag = w.Header().Get("ETag")
+    }
+}
+
+func TestTaskIfMatch(t *testing.T) {
+    h, _ := newETagHandler(false)
+    current := serveTask(h.Get, http.MethodGet, "", nil).Header().Get("ETag")
+    const stale = `"1-0000000000000000"`
+    const patchBody = `{"title":"Renamed"}`
+    const putBody = `{"title":"Renamed","priority":"high"}`
+
+    tests := []struct {
+        name    string
+        method  string
+        require bool
+        ifMatch string
+        want    int
+    }{
+        {"PATCH without If-Match", http.MethodPatch, false, "", http.StatusOK},
+        {"PATCH with the current ETag", http.MethodPatch, false, current, http.StatusOK},
+        {"PATCH with the version alone", http.MethodPatch, false, `"2"`, http.StatusOK},
+        {"PATCH with a stale ETag", http.MethodPatch, false, stale, http.StatusPreconditionFailed},
+        {"PATCH with a weak ETag", http.MethodPatch, false, "W/" + current, http.StatusPreconditionFailed},
+        {"PATCH with a malformed If-Match", http.MethodPatch, false, `"two"`, http.StatusBadRequest},
+        {"PATCH with an unquoted If-Match", http.MethodPatch, false, "2", http.StatusBadRequest},
+        {"PUT with the current ETag", http.MethodPut, false, current, http.StatusOK},
+        {"PUT with a stale ETag", http.MethodPut, false, stale, http.StatusPreconditionFailed},
+        {"DELETE with the current ETag", http.MethodDelete, false, current, http.StatusNoContent},
+        {"DELETE with a stale ETag", http.MethodDelete, false, stale, http.StatusPreconditionFailed},
+
+        // concurrency.requireifmatch
+        {"required: PATCH without If-Match", http.MethodPatch, true, "", http.StatusPreconditionRequired},
+        {"required: PUT without If-Match", http.MethodPut, true, "", http.StatusPreconditionRequired},
+        {"required: DELETE without If-Match", http.MethodDelete, true, "", http.StatusPreconditionRequired},
+        {"required: PATCH with the current ETag", http.MethodPatch, true, current, http.StatusOK},
+        // * é a forma explícita de sobrescrever sem conferir e vale mesmo exigindo If-Match
+        {"required: PATCH with *", http.MethodPatch, true, "*", http.StatusOK},
+        {"required: DELETE with *", http.MethodDelete, true, "*", http.StatusNoContent},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            h, repo := newETagHandler(tt.require)
+            handler, body := h.Patch, patchBody
+            switch tt.method {
+            case http.MethodPut:
+                handler, body = h.Update, putBody
+            case http.MethodDelete:
+                handler, body = h.Delete, ""
+            }
+            header := map[string]string{"Content-Type": "application/json"}
+            if tt.ifMatch != "" {
+                header["If-Match"] = tt.ifMatch
+            }
+
+            w := serveTask(handler, tt.method, body, header)
+            if w.Code != tt.want {
+                t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+            }
+            stored := repo.tasks["t1"]
+            switch tt.want {
+            case http.StatusOK:
+                if stored.Version != 3 || stored.Title != "Renamed" {
+                    t.Errorf("stored = %+v, want the change at version 3", stored)
+                }
+                if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `"3-`) {
+                    t.Errorf("ETag = %s, want the new version", etag)
+                }
+            case http.StatusNoContent:
+                if stored != nil {
+                    t.Errorf("task not deleted")
+                }
+            default:
+                if stored == nil || stored.Version != 2 || stored.Title != "Write report" {
+                    t.Errorf("rejected request changed the task: %+v", stored)
+                }
+            }
+        })
+    }
+}
+
+func TestDeleteTaskConcurrentChange(t *testing.T) {
+    h, repo := newETagHandler(false)
+    current := serveTask(h.Get, http.MethodGet, "", nil).Header().Get("ETag")
+    // Um PUT grava a Task entre a leitura do DELETE e a remoção.
+    repo.beforeDelete = func() { repo.tasks["t1"].Version++ }
+
+    w := serveTask(h.Delete, http.MethodDelete, "", map[string]string{"If-Match": current})
+    if w.Code != http.StatusConflict {
+        t.Fatalf("status = %d, want 409: %s", w.Code, w.Body)
+    }
+    if repo.tasks["t1"] == nil {
+        t.Errorf("the concurrent change was lost: task deleted")
+    }
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// parseTaskFilter converte a query string de GET /tasks em domain.TaskFilter.
+// Listas aceitam valores separados por vírgula ou o parâmetro repetido
+// (status=todo,blocked ou status=todo&status=blocked).
+func parseTaskFilter(q url.Values) (domain.TaskFilter, []domain.FieldError) {
+    var filter domain.TaskFilter
+    var fields []domain.FieldError
+    invalid := func(field, msg string) {
+        fields = append(fields, domain.FieldError{Field: field, Message: msg})
+    }
+
+    if v := q.Get("project_id"); v != "" {
+        if _, err := uuid.Parse(v); err != nil {
+            invalid("project_id", "must be a UUID")
+        } else {
+            filter.ProjectID = v
+        }
+    }
+    if v := q.Get("parent_id"); v != "" {
+        if _, err := uuid.Parse(v); err != nil {
+            invalid("parent_id", "must be a UUID")
+        } else {
+            filter.ParentID = v
+        }
+    }
+
+    if v := q.Get("completed"); v != "" {
+        b, err := strconv.ParseBool(v)
+        if err != nil {
+            invalid("completed", "must be a boolean")
+        } else {
+            filter.Completed = &b
+        }
+    }
+
+    for _, v := range splitList(q["status"]) {
+        st, err := domain.ParseTaskStatus(v)
+        if err != nil {
+            invalid("status", "must be one of todo, in_progress, blocked, done, cancelled")
+            break
+        }
+        filter.Statuses = append(filter.Statuses, st)
+    }
+
+    for _, v := range splitList(q["priority"]) {
+        p, err := domain.ParsePriority(v)
+        if err != nil {
+            invalid("priority", "must be one of low, medium, high, urgent")
+            break
+        }
+        filter.Priorities = append(filter.Priorities, p)
+    }
+
+    for param, dest := range map[string]**time.Time{
+        "due_before":     &filter.DueBefore,
+        "due_after":      &filter.DueAfter,
+        "created_before": &filter.CreatedBefore,
+        "created_after":  &filter.CreatedAfter,
+        "updated_before": &filter.UpdatedBefore,
+        "updated_after":  &filter.UpdatedAfter,
+    } {
+        v := q.Get(param)
+        if v == "" {
+            continue
+        }
+        t, err := parseDueDate(v)
+        if err != nil {
+            invalid(param, "must be an RFC3339 timestamp or a YYYY-MM-DD date")
+            continue
+        }
+        *dest = t
+    }
+
+    if v := q.Get("overdue"); v != "" {
+        b, err := strconv.ParseBool(v)
+        if err != nil {
+            invalid("overdue", "must be a boolean")
+        } else {
+            filter.Overdue = b
+        }
+    }
+
+    filter.Search = strings.TrimSpace(q.Get("q"))
+
+    // tags=any:a,b | all:a,b | none:a,b; sem prefixo equivale a any
+    for _, v := range q["tags"] {
+        mode, names := "any", v
+        if i := strings.Index(v, ":"); i >= 0 {
+            mode, names = v[:i], v[i+1:]
+        }
+        list := splitList([]string{names})
+        switch mode {
+        case "any":
+            filter.TagsAny = append(filter.TagsAny, list...)
+        case "all":
+            filter.TagsAll = append(filter.TagsAll, list...)
+        case "none":
+            filter.TagsNone = append(filter.TagsNone, list...)
+        default:
+            invalid("tags", "must be prefixed with any:, all: or none:")
+        }
+    }
+
+    if v := strings.Join(q["sort"], ","); v != "" {
+        sorts, err := domain.ParseTaskSort(v)
+        if err != nil {
+            invalid("sort", "must be a comma-separated list of created_at, updated_at, due_date, priority, title, status; prefix with - for descending, without repeats (max 4)")
+        } else {
+            filter.Sort = sorts
+        }
+    }
+
+    if v := q.Get("limit"); v != "" {
+        l, err := strconv.Atoi(v)
+        if err != nil || l < 1 {
+            invalid("limit", "must be a positive integer")
+        } else {
+            filter.Limit = l
+        }
+    }
+    if q.Has("offset") {
+        invalid("offset", "offset pagination is not supported; use cursor")
+    }
+    filter.Cursor = q.Get("cursor")
+
+    if v := q.Get("include_total"); v != "" {
+        b, err := strconv.ParseBool(v)
+        if err != nil {
+            invalid("include_total", "must be a boolean")
+        } else {
+            filter.IncludeTotal = b
+        }
+    }
+
+    return filter, fields
+}
+
+// splitList junta valores repetidos e separados por vírgula, ignorando vazios.
+func splitList(values []string) []string {
+    var out []string
+    for _, v := range values {
+        for _, item := range strings.Split(v, ",") {
+            if item = strings.TrimSpace(item); item != "" {
+                out = append(out, item)
+            }
+        }
+    }
+    return out
+}
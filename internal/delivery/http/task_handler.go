@@ -2,129 +2,648 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/rubenfabio/gopher-tasks/internal/domain"
 	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
 	"github.com/rubenfabio/gopher-tasks/internal/usecase"
 )
 
+// errInvalidDueDate é o erro de validação para due_date em formato não reconhecido.
+var errInvalidDueDate = domain.NewValidationError(domain.FieldError{
+    Field:   "due_date",
+    Message: "must be an RFC3339 timestamp or a YYYY-MM-DD date",
+})
+
 // createTaskRequest representa o payload para criação de Task.
 type createTaskRequest struct {
-    Title       string `json:"title" example:"Testar API"`
-    Description string `json:"description" example:"Descrição da tarefa"`
-    DueDate     string `json:"due_date" example:"2025-05-11T12:00:00Z"`
+    ProjectID    string   `json:"project_id,omitempty" example:"6f1c2d3e-0000-4000-8000-000000000000"`
+    ParentID     string   `json:"parent_id,omitempty" example:"0b7e4c1a-0000-4000-8000-000000000000"`
+    Title        string   `json:"title" example:"Testar API"`
+    Description  string   `json:"description" example:"Descrição da tarefa"`
+    Status       string   `json:"status,omitempty" example:"todo" enums:"todo,in_progress,blocked,done,cancelled"`
+    Priority     string   `json:"priority,omitempty" example:"medium" enums:"low,medium,high,urgent"`
+    DueDate      string   `json:"due_date,omitempty" example:"2025-05-11T12:00:00Z"`
+    Tags         []string `json:"tags,omitempty" example:"backend,urgent"`
+    RRule        string   `json:"rrule,omitempty" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+    AllowPastDue bool     `json:"allow_past_due,omitempty" example:"false"`
+}
+
+// updateTaskRequest representa o payload para substituição completa de uma Task.
+// project_id, parent_id, tags e rrule ausentes mantêm os valores atuais;
+// project_id "" tira a Task do projeto, parent_id "" a torna uma task de
+// primeiro nível, [] remove todas as tags e rrule "" encerra a recorrência.
+type updateTaskRequest struct {
+    ProjectID    *string  `json:"project_id,omitempty"`
+    ParentID     *string  `json:"parent_id,omitempty"`
+    Title        string   `json:"title" example:"Testar API"`
+    Description  string   `json:"description" example:"Descrição da tarefa"`
+    Status       string   `json:"status,omitempty" example:"in_progress" enums:"todo,in_progress,blocked,done,cancelled"`
+    Priority     string   `json:"priority,omitempty" example:"high" enums:"low,medium,high,urgent"`
+    DueDate      string   `json:"due_date,omitempty" example:"2025-05-11T12:00:00Z"`
+    Completed    bool     `json:"completed" example:"false"`
+    Tags         []string `json:"tags" example:"backend"`
+    RRule        *string  `json:"rrule,omitempty" example:"FREQ=MONTHLY;BYMONTHDAY=-1"`
+    AllowPastDue bool     `json:"allow_past_due,omitempty" example:"false"`
+}
+
+// patchTaskRequest representa o payload para atualização parcial de uma Task.
+// Campos omitidos não são alterados; project_id "" tira a Task do projeto,
+// parent_id "" a torna uma task de primeiro nível, due_date "" remove o
+// vencimento, tags substitui o conjunto inteiro e rrule "" encerra a recorrência.
+type patchTaskRequest struct {
+    ProjectID    *string  `json:"project_id,omitempty"`
+    ParentID     *string  `json:"parent_id,omitempty"`
+    Title        *string  `json:"title,omitempty" example:"Testar API"`
+    Description  *string  `json:"description,omitempty" example:"Descrição da tarefa"`
+    Status       *string  `json:"status,omitempty" example:"done" enums:"todo,in_progress,blocked,done,cancelled"`
+    Priority     *string  `json:"priority,omitempty" example:"urgent" enums:"low,medium,high,urgent"`
+    DueDate      *string  `json:"due_date,omitempty" example:"2025-05-11T12:00:00Z"`
+    Completed    *bool    `json:"completed,omitempty" example:"true"`
+    Tags         []string `json:"tags,omitempty" example:"backend"`
+    RRule        *string  `json:"rrule,omitempty" example:"FREQ=DAILY;COUNT=10"`
+    AllowPastDue bool     `json:"allow_past_due,omitempty" example:"false"`
 }
 
 // TaskHandler agrupa os use cases e o logger para endpoints de Task.
 type TaskHandler struct {
-    CreateUC *usecase.CreateTaskUseCase
-    ListUC   *usecase.ListTasksUseCase
-    Log      logger.Logger
+    CreateUC       *usecase.CreateTaskUseCase
+    ListUC         *usecase.ListTasksUseCase
+    GetUC          *usecase.GetTaskUseCase
+    UpdateUC       *usecase.UpdateTaskUseCase
+    PatchUC        *usecase.PatchTaskUseCase
+    DeleteUC       *usecase.DeleteTaskUseCase
+    ChildrenUC     *usecase.ListTaskChildrenUseCase
+    TreeUC         *usecase.GetTaskTreeUseCase
+    OccurrencesUC  *usecase.PreviewTaskOccurrencesUseCase
+    RequireIfMatch bool // PUT, PATCH e DELETE sem If-Match recebem 428
+    Log            logger.Logger
 }
 
-// NewTaskHandler injeta os use cases de criação e listagem, além do logger.
+// NewTaskHandler injeta os use cases de Task, a exigência de If-Match e o logger.
 func NewTaskHandler(
     createUC *usecase.CreateTaskUseCase,
     listUC *usecase.ListTasksUseCase,
+    getUC *usecase.GetTaskUseCase,
+    updateUC *usecase.UpdateTaskUseCase,
+    patchUC *usecase.PatchTaskUseCase,
+    deleteUC *usecase.DeleteTaskUseCase,
+    childrenUC *usecase.ListTaskChildrenUseCase,
+    treeUC *usecase.GetTaskTreeUseCase,
+    occurrencesUC *usecase.PreviewTaskOccurrencesUseCase,
+    requireIfMatch bool,
     log logger.Logger,
 ) *TaskHandler {
-    return &TaskHandler{CreateUC: createUC, ListUC: listUC, Log: log}
+    return &TaskHandler{
+        CreateUC:       createUC,
+        ListUC:         listUC,
+        GetUC:          getUC,
+        UpdateUC:       updateUC,
+        PatchUC:        patchUC,
+        DeleteUC:       deleteUC,
+        ChildrenUC:     childrenUC,
+        TreeUC:         treeUC,
+        OccurrencesUC:  occurrencesUC,
+        RequireIfMatch: requireIfMatch,
+        Log:            log,
+    }
 }
 
 // CreateTask godoc
 // @Summary      Cria uma nova task
-// @Description  Cria uma task com título, descrição e data de vencimento
+// @Description  Cria uma task com título, descrição, data de vencimento e tags opcionais.
+// @Description  Tags ainda não cadastradas são criadas automaticamente. rrule torna a task
+// @Description  recorrente (subconjunto da RRULE do iCalendar: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL,
+// @Description  BYDAY, BYMONTHDAY, UNTIL ou COUNT; exige due_date): ao concluir uma ocorrência a
+// @Description  seguinte é criada com o próximo vencimento, calculado no fuso do usuário.
 // @Tags         tasks
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        task  body      createTaskRequest  true  "Payload para criar task"
-// @Success      201   {object}  domain.Task
-// @Failure      400   {object}  string
-// @Failure      500   {object}  string
+// @Param        Idempotency-Key  header    string             false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Param        task             body      createTaskRequest  true   "Payload para criar task"
+// @Success      201              {object}  domain.Task
+// @Header       201              {string}  ETag  "Versão da task, para o If-Match"
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      422              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
 // @Router       /tasks [post]
 func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
     var req createTaskRequest
     if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-        http.Error(w, "invalid payload", http.StatusBadRequest)
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
         return
     }
 
-    due, err := time.Parse(time.RFC3339, req.DueDate)
+    in, err := createTaskInput(req)
     if err != nil {
-        http.Error(w, "invalid due_date format", http.StatusBadRequest)
+        writeError(w, r, h.Log, err, "invalid task")
         return
     }
 
-    task, err := h.CreateUC.Execute(req.Title, req.Description, due)
+    task, err := h.CreateUC.Execute(r.Context(), in)
     if err != nil {
-        h.Log.WithField("error", err).Error("failed to create task")
-        http.Error(w, "internal server error", http.StatusInternalServerError)
+        writeError(w, r, h.Log, err, "failed to create task")
         return
     }
 
-    w.Header().Set("Content-Type", "application/json")
-    w.WriteHeader(http.StatusCreated)
-    json.NewEncoder(w).Encode(task)
+    writeTask(w, http.StatusCreated, task)
+}
+
+// createTaskInput converte o payload de criação; status, prioridade e
+// due_date inválidos voltam como erro de validação.
+func createTaskInput(req createTaskRequest) (usecase.CreateTaskInput, error) {
+    due, err := parseDueDate(req.DueDate)
+    if err != nil {
+        return usecase.CreateTaskInput{}, err
+    }
+
+    in := usecase.CreateTaskInput{
+        ProjectID:        req.ProjectID,
+        ParentID:         req.ParentID,
+        Title:            req.Title,
+        Description:      req.Description,
+        DueDate:          due,
+        Tags:             req.Tags,
+        RRule:            req.RRule,
+        AllowPastDueDate: req.AllowPastDue,
+    }
+    var fields []domain.FieldError
+    if req.Status != "" {
+        in.Status = parseStatusField(req.Status, &fields)
+    }
+    if req.Priority != "" {
+        in.Priority = parsePriorityField(req.Priority, &fields)
+    }
+    if len(fields) > 0 {
+        return usecase.CreateTaskInput{}, domain.NewValidationError(fields...)
+    }
+    return in, nil
 }
 
 // ListTasks godoc
 // @Summary      Lista tasks
-// @Description  Retorna lista de tasks com filtros opcionais
+// @Description  Retorna uma página de tasks com filtros opcionais. A paginação é por
+// @Description  cursor: use page.next_cursor/prev_cursor (ou o header Link) no parâmetro cursor.
 // @Tags         tasks
+// @Security     BearerAuth
 // @Produce      json
-// @Param        completed  query     bool   false  "Filtrar por concluídas"
-// @Param        limit      query     int    false  "Limite de resultados"
-// @Param        offset     query     int    false  "Offset para paginação"
-// @Success      200        {array}   domain.Task
-// @Failure      500        {object}  string
+// @Param        project_id      query     string  false  "Apenas tasks do projeto"
+// @Param        parent_id       query     string  false  "Apenas subtarefas diretas da task"
+// @Param        completed       query     bool    false  "Filtrar por concluídas"
+// @Param        status          query     string  false  "Status separados por vírgula (todo,in_progress,blocked,done,cancelled)"
+// @Param        priority        query     string  false  "Prioridades separadas por vírgula (low,medium,high,urgent)"
+// @Param        due_after       query     string  false  "Vencimento a partir de (RFC3339 ou YYYY-MM-DD)"
+// @Param        due_before      query     string  false  "Vencimento antes de (RFC3339 ou YYYY-MM-DD)"
+// @Param        overdue         query     bool    false  "Apenas vencidas e ainda abertas"
+// @Param        created_after   query     string  false  "Criadas a partir de"
+// @Param        created_before  query     string  false  "Criadas antes de"
+// @Param        updated_after   query     string  false  "Atualizadas a partir de"
+// @Param        updated_before  query     string  false  "Atualizadas antes de"
+// @Param        q               query     string  false  "Busca textual em título e descrição"
+// @Param        tags            query     string  false  "Filtro por tags: any:a,b (alguma), all:a,b (todas) ou none:a,b (nenhuma); pode repetir"
+// @Param        sort            query     string  false  "Ordenação, ex.: due_date,-priority,title (- = decrescente; padrão -created_at; sem due_date por último)"
+// @Param        limit           query     int     false  "Tamanho da página (limitado pelo servidor)"
+// @Param        cursor          query     string  false  "Cursor opaco retornado em page.next_cursor/prev_cursor"
+// @Param        include_total   query     bool    false  "Inclui page.total (contagem de todos os resultados)"
+// @Success      200             {object}  taskListResponse
+// @Header       200             {string}  Link  "Links rel=next/prev para as páginas vizinhas"
+// @Failure      400             {object}  problemDetails
+// @Failure      401             {object}  problemDetails
+// @Failure      422             {object}  problemDetails
+// @Failure      500             {object}  problemDetails
 // @Router       /tasks [get]
 func (h *TaskHandler) List(w http.ResponseWriter, r *http.Request) {
-    q := r.URL.Query()
-    var completed *bool
-    if v := q.Get("completed"); v != "" {
-        b, err := strconv.ParseBool(v)
+    filter, fields := parseTaskFilter(r.URL.Query())
+    if len(fields) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameter", fields...)
+        return
+    }
+
+    page, err := h.ListUC.Execute(r.Context(), filter)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list tasks")
+        return
+    }
+
+    resp := newTaskListResponse(page, h.ListUC.PageSize(filter.Limit))
+    setPageLinks(w, r, resp.Page)
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
+
+// GetTask godoc
+// @Summary      Busca uma task
+// @Description  Retorna a task com o ID informado. O header ETag traz a versão da task e um hash da
+// @Description  representação (ex.: "3-9f86d081884c7d65"), a ser enviado no If-Match de PUT, PATCH e
+// @Description  DELETE; com If-None-Match igual ao ETag atual a resposta é 304 sem corpo.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id             path      string  true   "ID da task"
+// @Param        If-None-Match  header    string  false  "ETag já conhecido pelo cliente"
+// @Success      200            {object}  domain.Task
+// @Header       200            {string}  ETag  "Versão e hash da task, para o If-Match"
+// @Success      304            "A task não mudou"
+// @Failure      401            {object}  problemDetails
+// @Failure      404            {object}  problemDetails
+// @Failure      500            {object}  problemDetails
+// @Router       /tasks/{id} [get]
+func (h *TaskHandler) Get(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+
+    task, err := h.GetUC.Execute(r.Context(), id)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get task")
+        return
+    }
+
+    if etag := taskETag(task); notModified(r, etag) {
+        w.Header().Set("ETag", etag)
+        w.WriteHeader(http.StatusNotModified)
+        return
+    }
+    w.Header().Set("Accept-Patch", acceptPatch)
+    writeTask(w, http.StatusOK, task)
+}
+
+// UpdateTask godoc
+// @Summary      Substitui uma task
+// @Description  Substitui título, descrição, data de vencimento e status de conclusão da task.
+// @Description  Com If-Match, a task só é alterada se ainda estiver na versão informada (senão 412);
+// @Description  uma alteração concorrente entre a leitura e a gravação resulta em 409.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id               path      string             true   "ID da task"
+// @Param        If-Match         header    string             false  "ETag da versão lida (obrigatório se concurrency.requireifmatch; * não confere)"
+// @Param        Idempotency-Key  header    string             false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Param        task             body      updateTaskRequest  true   "Payload completo da task"
+// @Success      200              {object}  domain.Task
+// @Header       200              {string}  ETag  "Nova versão da task"
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      404              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      412              {object}  problemDetails
+// @Failure      422              {object}  problemDetails
+// @Failure      428              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
+// @Router       /tasks/{id} [put]
+func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    version, ok := ifMatchVersion(w, r, h.RequireIfMatch)
+    if !ok {
+        return
+    }
+
+    var req updateTaskRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    due, err := parseDueDate(req.DueDate)
+    if err != nil {
+        writeError(w, r, h.Log, err, "invalid due_date")
+        return
+    }
+
+    in := usecase.UpdateTaskInput{
+        ProjectID:        req.ProjectID,
+        ParentID:         req.ParentID,
+        Title:            req.Title,
+        Description:      req.Description,
+        DueDate:          due,
+        Completed:        req.Completed,
+        Tags:             req.Tags,
+        RRule:            req.RRule,
+        AllowPastDueDate: req.AllowPastDue,
+        ExpectedVersion:  version,
+    }
+    var fields []domain.FieldError
+    if req.Status != "" {
+        in.Status = parseStatusField(req.Status, &fields)
+    }
+    if req.Priority != "" {
+        in.Priority = parsePriorityField(req.Priority, &fields)
+    }
+    if len(fields) > 0 {
+        writeError(w, r, h.Log, domain.NewValidationError(fields...), "invalid task")
+        return
+    }
+
+    task, err := h.UpdateUC.Execute(r.Context(), id, in)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to update task")
+        return
+    }
+
+    writeTask(w, http.StatusOK, task)
+}
+
+// PatchTask godoc
+// @Summary      Atualiza parcialmente uma task
+// @Description  Altera apenas os campos informados e grava só as colunas que mudaram. O formato vem do
+// @Description  Content-Type: application/json (os campos de patchTaskRequest; null equivale a ausente),
+// @Description  application/merge-patch+json (RFC 7396 sobre a representação de GET /tasks/{id}; null
+// @Description  limpa o campo, ex.: {"due_date": null}) ou application/json-patch+json (RFC 6902, ex.:
+// @Description  [{"op": "replace", "path": "/completed", "value": true}]; um test que falha resulta em 409).
+// @Description  Apenas project_id, parent_id, title, description, status, priority, due_date, completed,
+// @Description  tags e recurrence.rule podem mudar. If-Match funciona como no PUT.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Accept       json
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id               path      string            true   "ID da task"
+// @Param        If-Match         header    string            false  "ETag da versão lida (obrigatório se concurrency.requireifmatch; * não confere)"
+// @Param        Idempotency-Key  header    string            false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Param        allow_past_due   query     bool              false  "Aceita vencimento no passado (merge-patch e json-patch)"
+// @Param        task             body      patchTaskRequest  true   "Campos a alterar, merge patch ou lista de operações"
+// @Success      200              {object}  domain.Task
+// @Header       200              {string}  ETag  "Nova versão da task"
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      404              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      412              {object}  problemDetails
+// @Failure      415              {object}  problemDetails
+// @Failure      422              {object}  problemDetails
+// @Failure      428              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
+// @Router       /tasks/{id} [patch]
+func (h *TaskHandler) Patch(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    version, ok := ifMatchVersion(w, r, h.RequireIfMatch)
+    if !ok {
+        return
+    }
+
+    var patch usecase.TaskPatch
+    // implicit: a versão conferida é a lida para aplicar o documento, não a do cliente
+    implicit := false
+    mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+    switch mediaType {
+    case "", "application/json":
+        patch, ok = h.patchFromJSON(w, r)
+        patch.ExpectedVersion = version
+    case mergePatchType, jsonPatchType:
+        patch, ok = h.patchFromDocument(w, r, id, mediaType, version)
+        implicit = version == 0
+    default:
+        w.Header().Set("Accept-Patch", acceptPatch)
+        writeProblem(w, r, http.StatusUnsupportedMediaType, "unsupported patch format; use "+acceptPatch)
+        return
+    }
+    if !ok {
+        return
+    }
+
+    task, err := h.PatchUC.Execute(r.Context(), id, patch)
+    // Sem If-Match, a Task ter mudado depois da leitura é um conflito, não uma pré-condição do cliente
+    if implicit && errors.Is(err, domain.ErrPrecondition) {
+        err = domain.ErrTaskVersionConflict
+    }
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to patch task")
+        return
+    }
+
+    writeTask(w, http.StatusOK, task)
+}
+
+// patchFromJSON lê o payload application/json (patchTaskRequest).
+func (h *TaskHandler) patchFromJSON(w http.ResponseWriter, r *http.Request) (usecase.TaskPatch, bool) {
+    var req patchTaskRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return usecase.TaskPatch{}, false
+    }
+    patch, err := taskPatchInput(req)
+    if err != nil {
+        writeError(w, r, h.Log, err, "invalid task")
+        return usecase.TaskPatch{}, false
+    }
+    return patch, true
+}
+
+// taskPatchInput converte o payload de patchTaskRequest; status, prioridade
+// e due_date inválidos voltam como erro de validação.
+func taskPatchInput(req patchTaskRequest) (usecase.TaskPatch, error) {
+    patch := usecase.TaskPatch{
+        ProjectID:        req.ProjectID,
+        ParentID:         req.ParentID,
+        Title:            req.Title,
+        Description:      req.Description,
+        Completed:        req.Completed,
+        Tags:             req.Tags,
+        RRule:            req.RRule,
+        AllowPastDueDate: req.AllowPastDue,
+    }
+    if req.DueDate != nil {
+        due, err := parseDueDate(*req.DueDate)
         if err != nil {
-            http.Error(w, "invalid completed filter", http.StatusBadRequest)
-            return
+            return usecase.TaskPatch{}, err
         }
-        completed = &b
+        patch.DueDate = due
+        patch.ClearDueDate = due == nil
+    }
+    var fields []domain.FieldError
+    if req.Status != nil {
+        st := parseStatusField(*req.Status, &fields)
+        patch.Status = &st
+    }
+    if req.Priority != nil {
+        p := parsePriorityField(*req.Priority, &fields)
+        patch.Priority = &p
+    }
+    if len(fields) > 0 {
+        return usecase.TaskPatch{}, domain.NewValidationError(fields...)
     }
+    return patch, nil
+}
 
-    limit := 0
-    if v := q.Get("limit"); v != "" {
-        if l, err := strconv.Atoi(v); err == nil {
-            limit = l
-        }
+// DeleteTask godoc
+// @Summary      Remove uma task
+// @Description  Remove a task com o ID informado e todas as suas subtarefas. Com If-Match, só remove
+// @Description  se a task ainda estiver na versão informada (senão 412); uma alteração concorrente
+// @Description  entre a leitura e a remoção resulta em 409.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Param        id               path      string  true   "ID da task"
+// @Param        If-Match         header    string  false  "ETag da versão lida (obrigatório se concurrency.requireifmatch; * não confere)"
+// @Param        Idempotency-Key  header    string  false  "Executa a requisição uma única vez; repetições devolvem a resposta original"
+// @Success      204
+// @Failure      400              {object}  problemDetails
+// @Failure      401              {object}  problemDetails
+// @Failure      404              {object}  problemDetails
+// @Failure      409              {object}  problemDetails
+// @Failure      412              {object}  problemDetails
+// @Failure      428              {object}  problemDetails
+// @Failure      500              {object}  problemDetails
+// @Router       /tasks/{id} [delete]
+func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    version, ok := ifMatchVersion(w, r, h.RequireIfMatch)
+    if !ok {
+        return
     }
 
-    offset := 0
-    if v := q.Get("offset"); v != "" {
-        if o, err := strconv.Atoi(v); err == nil {
-            offset = o
-        }
+    if err := h.DeleteUC.Execute(r.Context(), id, version); err != nil {
+        writeError(w, r, h.Log, err, "failed to delete task")
+        return
     }
 
-    filter := domain.TaskFilter{
-        Completed: completed,
-        Limit:     limit,
-        Offset:    offset,
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTaskChildren godoc
+// @Summary      Lista as subtarefas de uma task
+// @Description  Subtarefas diretas da task, com os mesmos filtros, ordenação e paginação de GET /tasks
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id             path      string  true   "ID da task"
+// @Param        status         query     string  false  "Status separados por vírgula"
+// @Param        priority       query     string  false  "Prioridades separadas por vírgula"
+// @Param        tags           query     string  false  "Filtro por tags (any:, all:, none:)"
+// @Param        q              query     string  false  "Busca textual em título e descrição"
+// @Param        sort           query     string  false  "Ordenação, ex.: due_date,-priority"
+// @Param        limit          query     int     false  "Tamanho da página (limitado pelo servidor)"
+// @Param        cursor         query     string  false  "Cursor opaco retornado em page.next_cursor/prev_cursor"
+// @Param        include_total  query     bool    false  "Inclui page.total"
+// @Success      200            {object}  taskListResponse
+// @Failure      400            {object}  problemDetails
+// @Failure      401            {object}  problemDetails
+// @Failure      404            {object}  problemDetails
+// @Failure      422            {object}  problemDetails
+// @Failure      500            {object}  problemDetails
+// @Router       /tasks/{id}/children [get]
+func (h *TaskHandler) Children(w http.ResponseWriter, r *http.Request) {
+    filter, fields := parseTaskFilter(r.URL.Query())
+    if len(fields) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameter", fields...)
+        return
     }
 
-    tasks, err := h.ListUC.Execute(filter)
+    page, err := h.ChildrenUC.Execute(r.Context(), mux.Vars(r)["id"], filter)
     if err != nil {
-        h.Log.WithField("error", err).Error("failed to list tasks")
-        http.Error(w, "internal server error", http.StatusInternalServerError)
+        writeError(w, r, h.Log, err, "failed to list subtasks")
         return
     }
 
-    // Garante que nunca seja retornado null, apenas um array vazio
-    if tasks == nil {
-        tasks = make([]*domain.Task, 0)
+    resp := newTaskListResponse(page, h.ChildrenUC.Tasks.PageSize(filter.Limit))
+    setPageLinks(w, r, resp.Page)
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
+
+// GetTaskTree godoc
+// @Summary      Árvore de subtarefas
+// @Description  Retorna a task com todas as subtarefas aninhadas em children e o
+// @Description  progresso (subtarefas concluídas, em qualquer nível) de cada task com filhas.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID da task"
+// @Success      200  {object}  domain.TaskNode
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /tasks/{id}/tree [get]
+func (h *TaskHandler) Tree(w http.ResponseWriter, r *http.Request) {
+    tree, err := h.TreeUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to build task tree")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(tree)
+}
+
+// occurrencesResponse é a prévia das próximas ocorrências de uma task recorrente.
+type occurrencesResponse struct {
+    Recurrence  *domain.TaskRecurrence `json:"recurrence"`
+    Occurrences []time.Time            `json:"occurrences"`
+}
+
+// PreviewTaskOccurrences godoc
+// @Summary      Próximas ocorrências de uma task recorrente
+// @Description  Calcula os vencimentos das próximas ocorrências da série, a partir do vencimento
+// @Description  da task e no fuso da série, respeitando UNTIL/COUNT. Nenhuma task é criada.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path      string  true   "ID da task"
+// @Param        count  query     int     false  "Quantidade de ocorrências (padrão 5, máximo 50)"
+// @Success      200    {object}  occurrencesResponse
+// @Failure      400    {object}  problemDetails
+// @Failure      401    {object}  problemDetails
+// @Failure      404    {object}  problemDetails
+// @Failure      409    {object}  problemDetails
+// @Failure      500    {object}  problemDetails
+// @Router       /tasks/{id}/occurrences [get]
+func (h *TaskHandler) Occurrences(w http.ResponseWriter, r *http.Request) {
+    var count int
+    if v := r.URL.Query().Get("count"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 1 {
+            writeProblem(w, r, http.StatusBadRequest, "invalid query parameter",
+                domain.FieldError{Field: "count", Message: "must be a positive integer"})
+            return
+        }
+        count = n
+    }
+
+    recurrence, occurrences, err := h.OccurrencesUC.Execute(r.Context(), mux.Vars(r)["id"], count)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to preview occurrences")
+        return
     }
 
     w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(tasks)
+    json.NewEncoder(w).Encode(occurrencesResponse{Recurrence: recurrence, Occurrences: occurrences})
+}
+
+// parseDueDate aceita RFC3339 ou apenas a data (YYYY-MM-DD, à meia-noite UTC).
+// String vazia significa "sem vencimento".
+func parseDueDate(v string) (*time.Time, error) {
+    if v == "" {
+        return nil, nil
+    }
+    for _, layout := range []string{time.RFC3339, time.DateOnly} {
+        if t, err := time.Parse(layout, v); err == nil {
+            return &t, nil
+        }
+    }
+    return nil, errInvalidDueDate
+}
+
+// parseStatusField converte o status do payload, acumulando o erro de campo.
+func parseStatusField(v string, fields *[]domain.FieldError) domain.TaskStatus {
+    st, err := domain.ParseTaskStatus(v)
+    if err != nil {
+        *fields = append(*fields, domain.FieldError{Field: "status", Message: "must be one of todo, in_progress, blocked, done, cancelled"})
+    }
+    return st
+}
+
+// parsePriorityField converte a prioridade do payload, acumulando o erro de campo.
+func parsePriorityField(v string, fields *[]domain.FieldError) domain.Priority {
+    p, err := domain.ParsePriority(v)
+    if err != nil {
+        *fields = append(*fields, domain.FieldError{Field: "priority", Message: "must be one of low, medium, high, urgent"})
+    }
+    return p
 }
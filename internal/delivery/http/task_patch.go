@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+	"github.com/rubenfabio/gopher-tasks/pkg/jsonpatch"
+)
+
+const (
+    mergePatchType = "application/merge-patch+json"
+    jsonPatchType  = "application/json-patch+json"
+    // acceptPatch é o valor do header Accept-Patch (RFC 5789)
+    acceptPatch = "application/json, " + mergePatchType + ", " + jsonPatchType
+)
+
+// readOnlyTaskFields são os membros da representação que nenhum patch altera.
+var readOnlyTaskFields = map[string]bool{
+    "id": true, "user_id": true, "progress": true, "version": true, "created_at": true, "updated_at": true,
+}
+
+// patchFromDocument aplica o merge patch ou o JSON Patch do corpo à
+// representação atual da Task e traduz a diferença em um TaskPatch. Sem
+// If-Match, a versão lida aqui é a conferida ao gravar, para que o patch não
+// seja aplicado sobre uma Task que mudou nesse meio-tempo.
+func (h *TaskHandler) patchFromDocument(w http.ResponseWriter, r *http.Request, id, mediaType string, version int) (usecase.TaskPatch, bool) {
+    var patch usecase.TaskPatch
+    body, err := io.ReadAll(r.Body)
+    if err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return patch, false
+    }
+    if v := r.URL.Query().Get("allow_past_due"); v != "" {
+        allow, err := strconv.ParseBool(v)
+        if err != nil {
+            writeProblem(w, r, http.StatusBadRequest, "invalid query parameter",
+                domain.FieldError{Field: "allow_past_due", Message: "must be a boolean"})
+            return patch, false
+        }
+        patch.AllowPastDueDate = allow
+    }
+
+    var ops jsonpatch.Patch
+    if mediaType == jsonPatchType {
+        if ops, err = jsonpatch.DecodePatch(body); err != nil {
+            writeProblem(w, r, http.StatusBadRequest, "invalid JSON Patch: "+err.Error())
+            return patch, false
+        }
+    }
+
+    task, err := h.GetUC.Execute(r.Context(), id)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get task")
+        return patch, false
+    }
+    if version != 0 && version != task.Version {
+        writeError(w, r, h.Log, domain.NewPreconditionError("task is at version %d, not %d", task.Version, version), "task version mismatch")
+        return patch, false
+    }
+    patch.ExpectedVersion = task.Version
+
+    doc, err := json.Marshal(task)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to encode task")
+        return patch, false
+    }
+    var patched []byte
+    if mediaType == jsonPatchType {
+        patched, err = ops.Apply(doc)
+    } else {
+        patched, err = jsonpatch.MergePatch(doc, body)
+    }
+    if errors.Is(err, jsonpatch.ErrInvalidPatch) {
+        writeProblem(w, r, http.StatusBadRequest, "invalid patch: "+err.Error())
+        return patch, false
+    }
+    if err != nil {
+        writeError(w, r, h.Log, patchError(err), "invalid patch")
+        return patch, false
+    }
+
+    var before, after map[string]interface{}
+    json.Unmarshal(doc, &before)
+    if err := json.Unmarshal(patched, &after); err != nil || after == nil {
+        writeError(w, r, h.Log, domain.NewValidationError(domain.FieldError{
+            Field:   "patch",
+            Message: "must produce a JSON object",
+        }), "invalid patch")
+        return patch, false
+    }
+    if fields := diffTaskDocument(before, after, &patch); len(fields) > 0 {
+        writeError(w, r, h.Log, domain.NewValidationError(fields...), "invalid task")
+        return patch, false
+    }
+    return patch, true
+}
+
+// patchError traduz as falhas do pacote jsonpatch: test que não casa é um
+// conflito com o estado atual; os demais tornam o patch inaplicável.
+func patchError(err error) error {
+    field := "patch"
+    var opErr *jsonpatch.OperationError
+    if errors.As(err, &opErr) {
+        field = fmt.Sprintf("patch[%d]", opErr.Index)
+    }
+    if errors.Is(err, jsonpatch.ErrTestFailed) {
+        return domain.NewConflictError("%s: test failed at %q", field, opErr.Op.Path)
+    }
+    return domain.NewValidationError(domain.FieldError{Field: field, Message: err.Error()})
+}
+
+// diffTaskDocument compara a representação da Task antes e depois do patch e
+// preenche patch com os membros alterados. Um membro removido equivale a null.
+func diffTaskDocument(before, after map[string]interface{}, patch *usecase.TaskPatch) []domain.FieldError {
+    keys := make([]string, 0, len(after))
+    for k := range after {
+        keys = append(keys, k)
+    }
+    for k := range before {
+        if _, ok := after[k]; !ok {
+            keys = append(keys, k)
+        }
+    }
+    sort.Strings(keys)
+
+    var fields []domain.FieldError
+    invalid := func(field, msg string) {
+        fields = append(fields, domain.FieldError{Field: field, Message: msg})
+    }
+    for _, key := range keys {
+        old, value := before[key], after[key]
+        if reflect.DeepEqual(old, value) {
+            continue
+        }
+        switch key {
+        case "title", "description", "project_id", "parent_id":
+            s, ok := stringOrNull(value)
+            if !ok {
+                invalid(key, "must be a string")
+                continue
+            }
+            switch key {
+            case "title":
+                patch.Title = &s
+            case "description":
+                patch.Description = &s
+            case "project_id":
+                patch.ProjectID = &s
+            default:
+                patch.ParentID = &s
+            }
+        case "status":
+            s, _ := value.(string)
+            st := parseStatusField(s, &fields)
+            patch.Status = &st
+        case "priority":
+            s, _ := value.(string)
+            p := parsePriorityField(s, &fields)
+            patch.Priority = &p
+        case "due_date":
+            s, ok := stringOrNull(value)
+            if !ok {
+                invalid(key, "must be an RFC3339 timestamp, a YYYY-MM-DD date or null")
+                continue
+            }
+            due, err := parseDueDate(s)
+            if err != nil {
+                invalid(key, "must be an RFC3339 timestamp, a YYYY-MM-DD date or null")
+                continue
+            }
+            patch.DueDate = due
+            patch.ClearDueDate = due == nil
+        case "completed":
+            b, ok := value.(bool)
+            if !ok {
+                invalid(key, "must be a boolean")
+                continue
+            }
+            patch.Completed = &b
+        case "tags":
+            tags, ok := stringList(value)
+            if !ok {
+                invalid(key, "must be an array of strings")
+                continue
+            }
+            patch.Tags = tags
+        case "recurrence":
+            rule, msg := recurrenceRule(old, value)
+            if msg != "" {
+                invalid("recurrence", msg)
+                continue
+            }
+            patch.RRule = &rule
+        default:
+            if readOnlyTaskFields[key] {
+                invalid(key, "is read-only")
+            } else {
+                invalid(key, "is not a task field")
+            }
+        }
+    }
+    return fields
+}
+
+// recurrenceRule extrai a regra pedida para a recorrência: null a remove e,
+// no objeto, apenas rule pode mudar.
+func recurrenceRule(old, value interface{}) (string, string) {
+    if value == nil {
+        return "", ""
+    }
+    rec, ok := value.(map[string]interface{})
+    if !ok {
+        return "", "must be an object or null"
+    }
+    prev, _ := old.(map[string]interface{})
+    for k, v := range rec {
+        if k != "rule" && prev != nil && !reflect.DeepEqual(prev[k], v) {
+            return "", "only recurrence.rule can be changed"
+        }
+    }
+    rule, ok := stringOrNull(rec["rule"])
+    if !ok {
+        return "", "rule must be a string"
+    }
+    return rule, ""
+}
+
+// stringOrNull aceita uma string ou null (que vale "").
+func stringOrNull(v interface{}) (string, bool) {
+    if v == nil {
+        return "", true
+    }
+    s, ok := v.(string)
+    return s, ok
+}
+
+// stringList aceita um array de strings ou null (que vale a lista vazia).
+func stringList(v interface{}) ([]string, bool) {
+    items, ok := v.([]interface{})
+    if v != nil && !ok {
+        return nil, false
+    }
+    list := make([]string, 0, len(items))
+    for _, item := range items {
+        s, ok := item.(string)
+        if !ok {
+            return nil, false
+        }
+        list = append(list, s)
+    }
+    return list, true
+}
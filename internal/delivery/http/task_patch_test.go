@@ -0,0 +1,177 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+	"github.com/rubenfabio/gopher-tasks/pkg/jsonpatch"
+)
+
+// patchedTask monta uma Task recorrente com subtarefas, aplica o patch à sua
+// representação, como patchFromDocument, e devolve a diferença.
+func patchedTask(t *testing.T, mediaType, body string) (usecase.TaskPatch, []domain.FieldError) {
+    t.Helper()
+    created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+    task := &domain.Task{
+        ID:          "task-1",
+        UserID:      "user-1",
+        Title:       "Pay rent",
+        Description: "monthly",
+        Status:      domain.StatusTodo,
+        Priority:    domain.PriorityMedium,
+        DueDate:     &created,
+        Tags:        []string{"home"},
+        Progress:    &domain.TaskProgress{Total: 2, Completed: 1, Percent: 50},
+        Recurrence: &domain.TaskRecurrence{
+            Rule:       "FREQ=MONTHLY;BYMONTHDAY=1",
+            Timezone:   "America/Sao_Paulo",
+            SeriesID:   "task-1",
+            Start:      created,
+            Occurrence: 1,
+        },
+        Version:   3,
+        CreatedAt: created,
+        UpdatedAt: created,
+    }
+    doc, err := json.Marshal(task)
+    if err != nil {
+        t.Fatalf("json.Marshal: %v", err)
+    }
+
+    var patched []byte
+    if mediaType == jsonPatchType {
+        ops, err := jsonpatch.DecodePatch([]byte(body))
+        if err != nil {
+            t.Fatalf("DecodePatch: %v", err)
+        }
+        patched, err = ops.Apply(doc)
+    } else {
+        patched, err = jsonpatch.MergePatch(doc, []byte(body))
+    }
+    if err != nil {
+        t.Fatalf("applying %s: %v", body, err)
+    }
+
+    var before, after map[string]interface{}
+    json.Unmarshal(doc, &before)
+    json.Unmarshal(patched, &after)
+    var patch usecase.TaskPatch
+    fields := diffTaskDocument(before, after, &patch)
+    return patch, fields
+}
+
+func TestDiffTaskDocumentRejectsReadOnlyFields(t *testing.T) {
+    tests := []struct {
+        name      string
+        mediaType string
+        body      string
+        field     string
+        message   string
+    }{
+        {"id", mergePatchType, `{"id":"task-2"}`, "id", "is read-only"},
+        {"version", mergePatchType, `{"version":9}`, "version", "is read-only"},
+        {"created_at", mergePatchType, `{"created_at":"2020-01-01T00:00:00Z"}`, "created_at", "is read-only"},
+        {"user_id", jsonPatchType, `[{"op":"replace","path":"/user_id","value":"user-2"}]`, "user_id", "is read-only"},
+        {"removing progress", jsonPatchType, `[{"op":"remove","path":"/progress"}]`, "progress", "is read-only"},
+        {"removing created_at", mergePatchType, `{"created_at":null}`, "created_at", "is read-only"},
+        {"unknown member", mergePatchType, `{"owner":"me"}`, "owner", "is not a task field"},
+        {"recurrence series", mergePatchType, `{"recurrence":{"series_id":"other"}}`, "recurrence", "only recurrence.rule can be changed"},
+        {"recurrence timezone", jsonPatchType, `[{"op":"replace","path":"/recurrence/timezone","value":"UTC"}]`, "recurrence", "only recurrence.rule can be changed"},
+        {"recurrence not an object", mergePatchType, `{"recurrence":"FREQ=DAILY"}`, "recurrence", "must be an object or null"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            patch, fields := patchedTask(t, tt.mediaType, tt.body)
+            if len(fields) != 1 || fields[0].Field != tt.field || fields[0].Message != tt.message {
+                t.Fatalf("fields = %+v, want %s %q", fields, tt.field, tt.message)
+            }
+            if patch.RRule != nil {
+                t.Errorf("RRule = %q, want unchanged", *patch.RRule)
+            }
+        })
+    }
+}
+
+func TestDiffTaskDocumentRecurrenceRule(t *testing.T) {
+    tests := []struct {
+        name      string
+        mediaType string
+        body      string
+        want      *string // nil: recorrência inalterada
+    }{
+        {"unchanged document", mergePatchType, `{}`, nil},
+        {"same rule", mergePatchType, `{"recurrence":{"rule":"FREQ=MONTHLY;BYMONTHDAY=1"}}`, nil},
+        {"merge patch rule", mergePatchType, `{"recurrence":{"rule":"FREQ=WEEKLY;BYDAY=MO"}}`, ptr("FREQ=WEEKLY;BYDAY=MO")},
+        {"JSON Patch rule", jsonPatchType, `[{"op":"replace","path":"/recurrence/rule","value":"FREQ=DAILY"}]`, ptr("FREQ=DAILY")},
+        {"whole object with a new rule", jsonPatchType,
+            `[{"op":"test","path":"/recurrence/occurrence","value":1},{"op":"copy","from":"/recurrence","path":"/r"},` +
+                `{"op":"replace","path":"/r/rule","value":"FREQ=YEARLY"},{"op":"move","from":"/r","path":"/recurrence"}]`,
+            ptr("FREQ=YEARLY")},
+        {"merge patch null removes", mergePatchType, `{"recurrence":null}`, ptr("")},
+        {"JSON Patch remove", jsonPatchType, `[{"op":"remove","path":"/recurrence"}]`, ptr("")},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            patch, fields := patchedTask(t, tt.mediaType, tt.body)
+            if len(fields) != 0 {
+                t.Fatalf("fields = %+v", fields)
+            }
+            if got, want := fmt.Sprint(deref(patch.RRule)), fmt.Sprint(deref(tt.want)); got != want || (patch.RRule == nil) != (tt.want == nil) {
+                t.Errorf("RRule = %s, want %s", got, want)
+            }
+            // Só a recorrência mudou: nada mais entra no patch.
+            patch.RRule = nil
+            if fmt.Sprintf("%+v", patch) != fmt.Sprintf("%+v", usecase.TaskPatch{}) {
+                t.Errorf("patch = %+v, want only RRule", patch)
+            }
+        })
+    }
+}
+
+func TestDiffTaskDocumentFields(t *testing.T) {
+    patch, fields := patchedTask(t, mergePatchType,
+        `{"title":"Pay the rent","description":null,"due_date":null,"tags":["home","bills"],"priority":"high"}`)
+    if len(fields) != 0 {
+        t.Fatalf("fields = %+v", fields)
+    }
+    if deref(patch.Title) != "Pay the rent" || patch.Description == nil || *patch.Description != "" {
+        t.Errorf("title/description = %v/%v", patch.Title, patch.Description)
+    }
+    if !patch.ClearDueDate || patch.DueDate != nil {
+        t.Errorf("due date = %v, clear %v; want cleared", patch.DueDate, patch.ClearDueDate)
+    }
+    if fmt.Sprint(patch.Tags) != "[home bills]" {
+        t.Errorf("tags = %v", patch.Tags)
+    }
+    if patch.Priority == nil || *patch.Priority != domain.PriorityHigh {
+        t.Errorf("priority = %v", patch.Priority)
+    }
+    if patch.Status != nil || patch.Completed != nil || patch.ProjectID != nil {
+        t.Errorf("unchanged members in the patch: %+v", patch)
+    }
+
+    _, fields = patchedTask(t, mergePatchType, `{"title":1,"tags":"home","completed":"yes"}`)
+    got := map[string]string{}
+    for _, f := range fields {
+        got[f.Field] = f.Message
+    }
+    want := map[string]string{"title": "must be a string", "tags": "must be an array of strings", "completed": "must be a boolean"}
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("fields = %v, want %v", got, want)
+    }
+}
+
+func ptr(s string) *string { return &s }
+
+func deref(s *string) string {
+    if s == nil {
+        return "<nil>"
+    }
+    return *s
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// streamRetry é o intervalo de reconexão sugerido aos clientes de SSE.
+const streamRetry = 3 * time.Second
+
+// TaskStreamHandler expõe os eventos das Tasks como Server-Sent Events.
+type TaskStreamHandler struct {
+    StreamUC  *usecase.StreamTaskEventsUseCase
+    Heartbeat time.Duration // intervalo dos comentários que mantêm a conexão viva
+    Log       logger.Logger
+}
+
+// NewTaskStreamHandler injeta o use case de stream, o intervalo de heartbeat e o logger.
+func NewTaskStreamHandler(streamUC *usecase.StreamTaskEventsUseCase, heartbeat time.Duration, log logger.Logger) *TaskStreamHandler {
+    return &TaskStreamHandler{StreamUC: streamUC, Heartbeat: heartbeat, Log: log}
+}
+
+// StreamTasks godoc
+// @Summary      Acompanha mudanças nas tasks ao vivo (SSE)
+// @Description  Mantém a conexão aberta e envia, como Server-Sent Events, os eventos task.created,
+// @Description  task.updated, task.completed e task.deleted das tasks do usuário. Aceita os mesmos filtros
+// @Description  de GET /tasks (ordenação e paginação são ignoradas); uma task é enviada se satisfaz o filtro
+// @Description  antes ou depois da mudança. Cada mensagem tem id, event (o tipo) e data (o evento em JSON).
+// @Description  Ao reconectar, Last-Event-ID (ou last_event_id) reenvia os eventos perdidos ainda guardados
+// @Description  no servidor; se não estiverem mais, chega um evento reset e a lista deve ser recarregada.
+// @Description  Comentários de heartbeat são enviados periodicamente.
+// @Tags         tasks
+// @Security     BearerAuth
+// @Produce      text/event-stream
+// @Param        Last-Event-ID   header    string  false  "ID do último evento recebido"
+// @Param        last_event_id   query     string  false  "Alternativa ao header, para clientes que não o enviam"
+// @Param        project_id      query     string  false  "Apenas tasks do projeto"
+// @Param        parent_id       query     string  false  "Apenas subtarefas diretas da task"
+// @Param        completed       query     bool    false  "Filtrar por concluídas"
+// @Param        status          query     string  false  "Status separados por vírgula (todo,in_progress,blocked,done,cancelled)"
+// @Param        priority        query     string  false  "Prioridades separadas por vírgula (low,medium,high,urgent)"
+// @Param        due_after       query     string  false  "Vencimento a partir de (RFC3339 ou YYYY-MM-DD)"
+// @Param        due_before      query     string  false  "Vencimento antes de (RFC3339 ou YYYY-MM-DD)"
+// @Param        overdue         query     bool    false  "Apenas vencidas e ainda abertas"
+// @Param        q               query     string  false  "Busca textual em título e descrição"
+// @Param        tags            query     string  false  "Filtro por tags: any:a,b, all:a,b ou none:a,b; pode repetir"
+// @Success      200             {object}  domain.Event  "Um evento por mensagem"
+// @Failure      400             {object}  problemDetails
+// @Failure      401             {object}  problemDetails
+// @Failure      500             {object}  problemDetails
+// @Router       /tasks/stream [get]
+func (h *TaskStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+    q := r.URL.Query()
+    filter, fields := parseTaskFilter(q)
+    if len(fields) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameter", fields...)
+        return
+    }
+    lastEventID := r.Header.Get("Last-Event-ID")
+    if lastEventID == "" {
+        lastEventID = q.Get("last_event_id")
+    }
+
+    sub, err := h.StreamUC.Execute(r.Context(), filter, lastEventID)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to open task stream")
+        return
+    }
+
+    // A conexão dura mais que o WriteTimeout do servidor
+    rc := http.NewResponseController(w)
+    if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+        h.Log.WithField("error", err).Warn("Task stream may be cut by the server write timeout")
+    }
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("X-Accel-Buffering", "no") // desliga o buffer de proxies como o nginx
+    w.WriteHeader(http.StatusOK)
+
+    fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+    if sub.Gap {
+        fmt.Fprint(w, "event: reset\ndata: {\"reason\":\"missed events are no longer available; reload the task list\"}\n\n")
+    }
+    for _, e := range sub.Missed {
+        if err := writeEvent(w, e); err != nil {
+            return
+        }
+    }
+    if err := rc.Flush(); err != nil {
+        return
+    }
+
+    heartbeat := time.NewTicker(h.Heartbeat)
+    defer heartbeat.Stop()
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case e, ok := <-sub.Events:
+            // Canal fechado: shutdown ou cliente lento; ele reconecta com Last-Event-ID
+            if !ok {
+                return
+            }
+            if err := writeEvent(w, e); err != nil {
+                return
+            }
+        case <-heartbeat.C:
+            if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+                return
+            }
+        }
+        if err := rc.Flush(); err != nil {
+            return
+        }
+    }
+}
+
+// writeEvent escreve o evento no formato do SSE.
+func writeEvent(w io.Writer, e *domain.Event) error {
+    data, err := json.Marshal(e)
+    if err != nil {
+        return err
+    }
+    _, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+    return err
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// updateUserRequest representa o payload para alterar o perfil.
+// Campos omitidos não são alterados.
+type updateUserRequest struct {
+    Name     *string `json:"name,omitempty" example:"Gopher"`
+    Timezone *string `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+}
+
+// UserHandler agrupa os use cases e o logger para o perfil do usuário autenticado.
+type UserHandler struct {
+    GetUC    *usecase.GetCurrentUserUseCase
+    UpdateUC *usecase.UpdateCurrentUserUseCase
+    Log      logger.Logger
+}
+
+// NewUserHandler injeta os use cases de perfil, além do logger.
+func NewUserHandler(
+    getUC *usecase.GetCurrentUserUseCase,
+    updateUC *usecase.UpdateCurrentUserUseCase,
+    log logger.Logger,
+) *UserHandler {
+    return &UserHandler{GetUC: getUC, UpdateUC: updateUC, Log: log}
+}
+
+// GetCurrentUser godoc
+// @Summary      Perfil do usuário autenticado
+// @Description  Retorna o usuário dono do token
+// @Tags         users
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  domain.User
+// @Failure      401  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /users/me [get]
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+    user, err := h.GetUC.Execute(r.Context())
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get user")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(user)
+}
+
+// UpdateCurrentUser godoc
+// @Summary      Altera o perfil do usuário autenticado
+// @Description  Altera nome e fuso horário. O fuso é usado no cálculo das ocorrências das
+// @Description  tasks recorrentes criadas a partir de então.
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        user  body      updateUserRequest  true  "Campos a alterar"
+// @Success      200   {object}  domain.User
+// @Failure      400   {object}  problemDetails
+// @Failure      401   {object}  problemDetails
+// @Failure      422   {object}  problemDetails
+// @Failure      500   {object}  problemDetails
+// @Router       /users/me [patch]
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+    var req updateUserRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    user, err := h.UpdateUC.Execute(r.Context(), usecase.UserPatch{Name: req.Name, Timezone: req.Timezone})
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to update user")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(user)
+}
@@ -0,0 +1,292 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/internal/usecase"
+)
+
+// createWebhookRequest representa o payload para assinar eventos de Task.
+type createWebhookRequest struct {
+    URL    string             `json:"url" example:"https://example.com/hooks/tasks"`
+    Events []domain.EventType `json:"events" swaggertype:"array,string" example:"task.created,task.completed"`
+    Secret string             `json:"secret,omitempty" example:"a-long-random-shared-secret"`
+}
+
+// updateWebhookRequest representa o payload para alterar uma assinatura.
+// Campos omitidos não são alterados.
+type updateWebhookRequest struct {
+    URL          *string            `json:"url,omitempty" example:"https://example.com/hooks/tasks"`
+    Events       []domain.EventType `json:"events,omitempty" swaggertype:"array,string" example:"*"`
+    Active       *bool              `json:"active,omitempty" example:"false"`
+    RotateSecret bool               `json:"rotate_secret,omitempty" example:"false"`
+}
+
+// webhookResponse é a assinatura com o segredo, exibido apenas na criação e
+// ao gerar um segredo novo.
+type webhookResponse struct {
+    *domain.Webhook
+    Secret string `json:"secret,omitempty" example:"whsec_3f9a..."`
+}
+
+// WebhookHandler agrupa os use cases e o logger para os endpoints de webhook.
+type WebhookHandler struct {
+    CreateUC     *usecase.CreateWebhookUseCase
+    ListUC       *usecase.ListWebhooksUseCase
+    GetUC        *usecase.GetWebhookUseCase
+    UpdateUC     *usecase.UpdateWebhookUseCase
+    DeleteUC     *usecase.DeleteWebhookUseCase
+    DeliveriesUC *usecase.ListWebhookDeliveriesUseCase
+    RedeliverUC  *usecase.RedeliverWebhookUseCase
+    Log          logger.Logger
+}
+
+// NewWebhookHandler injeta os use cases de webhook, além do logger.
+func NewWebhookHandler(
+    createUC *usecase.CreateWebhookUseCase,
+    listUC *usecase.ListWebhooksUseCase,
+    getUC *usecase.GetWebhookUseCase,
+    updateUC *usecase.UpdateWebhookUseCase,
+    deleteUC *usecase.DeleteWebhookUseCase,
+    deliveriesUC *usecase.ListWebhookDeliveriesUseCase,
+    redeliverUC *usecase.RedeliverWebhookUseCase,
+    log logger.Logger,
+) *WebhookHandler {
+    return &WebhookHandler{
+        CreateUC:     createUC,
+        ListUC:       listUC,
+        GetUC:        getUC,
+        UpdateUC:     updateUC,
+        DeleteUC:     deleteUC,
+        DeliveriesUC: deliveriesUC,
+        RedeliverUC:  redeliverUC,
+        Log:          log,
+    }
+}
+
+// CreateWebhook godoc
+// @Summary      Cria uma assinatura de webhook
+// @Description  Eventos das tasks do usuário (task.created, task.updated, task.completed, task.deleted ou "*")
+// @Description  são enviados por POST para url. Cada entrega leva o cabeçalho
+// @Description  X-Gopher-Tasks-Signature: t=<unix>,v1=<hex>, o HMAC-SHA256 de "<t>.<corpo>" com o segredo.
+// @Description  Sem secret um segredo aleatório é gerado; ele só aparece nesta resposta.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      createWebhookRequest  true  "Dados da assinatura"
+// @Success      201      {object}  webhookResponse
+// @Failure      400      {object}  problemDetails
+// @Failure      401      {object}  problemDetails
+// @Failure      409      {object}  problemDetails
+// @Failure      422      {object}  problemDetails
+// @Failure      500      {object}  problemDetails
+// @Router       /webhooks [post]
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var req createWebhookRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    webhook, err := h.CreateUC.Execute(r.Context(), usecase.CreateWebhookInput{
+        URL:    req.URL,
+        Events: req.Events,
+        Secret: req.Secret,
+    })
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to create webhook")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(webhookResponse{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// ListWebhooks godoc
+// @Summary      Lista as assinaturas de webhook
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   domain.Webhook
+// @Failure      401  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /webhooks [get]
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+    webhooks, err := h.ListUC.Execute(r.Context())
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list webhooks")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(webhooks)
+}
+
+// GetWebhook godoc
+// @Summary      Busca uma assinatura de webhook
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID da assinatura"
+// @Success      200  {object}  domain.Webhook
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+    webhook, err := h.GetUC.Execute(r.Context(), mux.Vars(r)["id"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to get webhook")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary      Altera uma assinatura de webhook
+// @Description  Altera url e eventos, pausa (active=false) ou reativa a assinatura. rotate_secret gera
+// @Description  um segredo novo, devolvido apenas nesta resposta. Entregas pendentes de uma assinatura
+// @Description  pausada aguardam a reativação.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "ID da assinatura"
+// @Param        webhook  body      updateWebhookRequest  true  "Campos a alterar"
+// @Success      200      {object}  webhookResponse
+// @Failure      400      {object}  problemDetails
+// @Failure      401      {object}  problemDetails
+// @Failure      404      {object}  problemDetails
+// @Failure      422      {object}  problemDetails
+// @Failure      500      {object}  problemDetails
+// @Router       /webhooks/{id} [patch]
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+    var req updateWebhookRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeProblem(w, r, http.StatusBadRequest, "invalid payload")
+        return
+    }
+
+    webhook, err := h.UpdateUC.Execute(r.Context(), mux.Vars(r)["id"], usecase.WebhookPatch{
+        URL:          req.URL,
+        Events:       req.Events,
+        Active:       req.Active,
+        RotateSecret: req.RotateSecret,
+    })
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to update webhook")
+        return
+    }
+
+    resp := webhookResponse{Webhook: webhook}
+    if req.RotateSecret {
+        resp.Secret = webhook.Secret
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteWebhook godoc
+// @Summary      Remove uma assinatura de webhook
+// @Description  Remove a assinatura, as entregas pendentes e o histórico
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID da assinatura"
+// @Success      204
+// @Failure      401  {object}  problemDetails
+// @Failure      404  {object}  problemDetails
+// @Failure      500  {object}  problemDetails
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    if err := h.DeleteUC.Execute(r.Context(), mux.Vars(r)["id"]); err != nil {
+        writeError(w, r, h.Log, err, "failed to delete webhook")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      Histórico de entregas de um webhook
+// @Description  Entregas da assinatura, das mais recentes para as mais antigas, com o resultado da última
+// @Description  tentativa. Falhas são tentadas de novo com espera exponencial; depois do máximo de
+// @Description  tentativas a entrega fica como dead até ser reenviada.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID da assinatura"
+// @Param        status  query     string  false  "Filtra pela situação"  Enums(pending, delivered, dead)
+// @Param        limit   query     int     false  "Máximo de entregas (padrão 50, máximo 200)"
+// @Success      200     {array}   domain.WebhookDelivery
+// @Failure      400     {object}  problemDetails
+// @Failure      401     {object}  problemDetails
+// @Failure      404     {object}  problemDetails
+// @Failure      500     {object}  problemDetails
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+    q := r.URL.Query()
+    var filter domain.WebhookDeliveryFilter
+    var fields []domain.FieldError
+    if v := q.Get("status"); v != "" {
+        st, err := domain.ParseDeliveryStatus(v)
+        if err != nil {
+            fields = append(fields, domain.FieldError{Field: "status", Message: "must be one of pending, delivered, dead"})
+        }
+        filter.Status = st
+    }
+    if v := q.Get("limit"); v != "" {
+        l, err := strconv.Atoi(v)
+        if err != nil || l < 1 {
+            fields = append(fields, domain.FieldError{Field: "limit", Message: "must be a positive integer"})
+        }
+        filter.Limit = l
+    }
+    if len(fields) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameter", fields...)
+        return
+    }
+
+    deliveries, err := h.DeliveriesUC.Execute(r.Context(), mux.Vars(r)["id"], filter)
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to list webhook deliveries")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(deliveries)
+}
+
+// RedeliverWebhook godoc
+// @Summary      Reenvia uma entrega de webhook
+// @Description  Devolve a entrega (inclusive uma dead) para a fila; o envio acontece na próxima varredura
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id          path      string  true  "ID da assinatura"
+// @Param        deliveryId  path      string  true  "ID da entrega"
+// @Success      202         {object}  domain.WebhookDelivery
+// @Failure      401         {object}  problemDetails
+// @Failure      404         {object}  problemDetails
+// @Failure      500         {object}  problemDetails
+// @Router       /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    delivery, err := h.RedeliverUC.Execute(r.Context(), vars["id"], vars["deliveryId"])
+    if err != nil {
+        writeError(w, r, h.Log, err, "failed to redeliver webhook")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusAccepted)
+    json.NewEncoder(w).Encode(delivery)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ErrInvalidCredentials é retornado quando e-mail/senha ou token não conferem.
+var ErrInvalidCredentials = NewUnauthorizedError("invalid credentials")
+
+// PasswordHasher gera e confere hashes de senha.
+type PasswordHasher interface {
+    Hash(password string) (string, error)
+    Compare(hash, password string) error
+}
+
+// TokenService emite e valida tokens de acesso.
+type TokenService interface {
+    Issue(userID string) (token string, expiresAt time.Time, err error)
+    Parse(token string) (userID string, err error)
+}
+
+type userIDKey struct{}
+
+// WithUserID anexa ao contexto o ID do usuário autenticado. Os repositórios
+// usam esse valor para restringir as consultas aos dados do próprio usuário.
+func WithUserID(ctx context.Context, userID string) context.Context {
+    return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext retorna o ID do usuário autenticado, se houver.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+    id, ok := ctx.Value(userIDKey{}).(string)
+    return id, ok && id != ""
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifica os erros de domínio para que as camadas de entrega
+// (HTTP, CLI) consigam traduzi-los sem comparar strings.
+type ErrorKind string
+
+const (
+    KindNotFound     ErrorKind = "not_found"
+    KindValidation   ErrorKind = "validation"
+    KindConflict     ErrorKind = "conflict"
+    KindForbidden    ErrorKind = "forbidden"
+    KindUnauthorized ErrorKind = "unauthorized"
+    KindPrecondition ErrorKind = "precondition_failed"
+    KindBadRequest   ErrorKind = "bad_request" // requisição malformada, não uma entrada inválida
+)
+
+// Sentinelas por categoria: errors.Is(err, ErrNotFound) vale para qualquer
+// *Error do mesmo Kind.
+var (
+    ErrNotFound     = &Error{Kind: KindNotFound}
+    ErrValidation   = &Error{Kind: KindValidation}
+    ErrConflict     = &Error{Kind: KindConflict}
+    ErrForbidden    = &Error{Kind: KindForbidden}
+    ErrUnauthorized = &Error{Kind: KindUnauthorized}
+    ErrPrecondition = &Error{Kind: KindPrecondition}
+    ErrBadRequest   = &Error{Kind: KindBadRequest}
+)
+
+// FieldError descreve um problema de validação em um campo específico.
+type FieldError struct {
+    Field   string `json:"field" example:"title"`
+    Message string `json:"message" example:"is required"`
+}
+
+// Error é o erro de domínio retornado pelos use cases e repositórios.
+type Error struct {
+    Kind    ErrorKind
+    Message string
+    Fields  []FieldError
+    Err     error
+}
+
+func (e *Error) Error() string {
+    msg := e.Message
+    if msg == "" {
+        msg = string(e.Kind)
+    }
+    if len(e.Fields) > 0 {
+        parts := make([]string, 0, len(e.Fields))
+        for _, f := range e.Fields {
+            parts = append(parts, f.Field+": "+f.Message)
+        }
+        msg += " (" + strings.Join(parts, "; ") + ")"
+    }
+    if e.Err != nil {
+        msg += ": " + e.Err.Error()
+    }
+    return msg
+}
+
+func (e *Error) Unwrap() error {
+    return e.Err
+}
+
+// Is casa com as sentinelas de categoria (sem mensagem) ou com outro *Error
+// de mesmo Kind e mesma mensagem.
+func (e *Error) Is(target error) bool {
+    t, ok := target.(*Error)
+    if !ok {
+        return false
+    }
+    return t.Kind == e.Kind && (t.Message == "" || t.Message == e.Message)
+}
+
+// NewNotFoundError indica que o recurso informado não existe.
+func NewNotFoundError(resource string) *Error {
+    return &Error{Kind: KindNotFound, Message: resource + " not found"}
+}
+
+// NewValidationError agrupa os erros de campo de uma entrada inválida.
+func NewValidationError(fields ...FieldError) *Error {
+    return &Error{Kind: KindValidation, Message: "validation failed", Fields: fields}
+}
+
+// NewConflictError indica que a operação conflita com o estado atual do recurso.
+func NewConflictError(format string, args ...interface{}) *Error {
+    return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewForbiddenError indica que o solicitante não pode executar a operação.
+func NewForbiddenError(format string, args ...interface{}) *Error {
+    return &Error{Kind: KindForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewUnauthorizedError indica que o solicitante não está autenticado.
+func NewUnauthorizedError(format string, args ...interface{}) *Error {
+    return &Error{Kind: KindUnauthorized, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewPreconditionError indica que o recurso não está na versão que o
+// solicitante esperava (If-Match).
+func NewPreconditionError(format string, args ...interface{}) *Error {
+    return &Error{Kind: KindPrecondition, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewBadRequestError indica um parâmetro malformado (ex.: um cursor
+// adulterado), que o cliente não deveria ter enviado.
+func NewBadRequestError(message string, fields ...FieldError) *Error {
+    return &Error{Kind: KindBadRequest, Message: message, Fields: fields}
+}
+
+// AsError extrai o *Error de domínio da cadeia de err, se houver.
+func AsError(err error) (*Error, bool) {
+    var de *Error
+    if errors.As(err, &de) {
+        return de, true
+    }
+    return nil, false
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifica um acontecimento no ciclo de vida de uma Task.
+type EventType string
+
+const (
+    EventTaskCreated   EventType = "task.created"
+    EventTaskUpdated   EventType = "task.updated"
+    EventTaskCompleted EventType = "task.completed" // emitido junto com task.updated
+    EventTaskDeleted   EventType = "task.deleted"
+)
+
+// EventTypes lista todos os tipos de evento publicados.
+var EventTypes = []EventType{EventTaskCreated, EventTaskUpdated, EventTaskCompleted, EventTaskDeleted}
+
+// Valid indica se o tipo de evento é conhecido.
+func (t EventType) Valid() bool {
+    for _, known := range EventTypes {
+        if t == known {
+            return true
+        }
+    }
+    return false
+}
+
+// Event é uma mudança já gravada em uma Task. Task é o estado depois da
+// mudança (em task.deleted, o último estado antes da remoção) e Previous, em
+// task.updated e task.completed, o estado anterior.
+type Event struct {
+    ID         string    `json:"id"` // único por evento; serve para deduplicar entregas
+    Type       EventType `json:"type" swaggertype:"string" enums:"task.created,task.updated,task.completed,task.deleted"`
+    OccurredAt time.Time `json:"occurred_at"`
+    UserID     string    `json:"user_id"`
+    Task       *Task     `json:"task"`
+    Previous   *Task     `json:"previous,omitempty"`
+}
+
+// EventPublisher recebe os eventos emitidos pelos use cases.
+type EventPublisher interface {
+    Publish(ctx context.Context, events ...*Event) error
+}
+
+// EventStream acompanha ao vivo os eventos publicados, mantendo os mais
+// recentes em um log limitado para quem reconecta.
+type EventStream interface {
+    // Subscribe passa a receber os eventos publicados a partir de agora.
+    // Com lastEventID, os eventos guardados depois dele vêm em Missed.
+    Subscribe(ctx context.Context, lastEventID string) (*EventSubscription, error)
+}
+
+// EventSubscription é uma assinatura de EventStream.
+type EventSubscription struct {
+    Missed []*Event      // eventos após lastEventID ainda presentes no log
+    Gap    bool          // lastEventID saiu do log (ou é desconhecido): eventos podem ter sido perdidos
+    Events <-chan *Event // fechado quando o contexto termina, o stream é encerrado ou o assinante fica para trás
+}
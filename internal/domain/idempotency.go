@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// IdempotencyRecord guarda uma requisição feita com Idempotency-Key e, depois
+// de concluída, a resposta a repetir quando o cliente reenviar a mesma chave.
+type IdempotencyRecord struct {
+    UserID      string
+    Key         string
+    Token       string // identifica a reserva; só a dona grava ou libera a chave
+    Method      string
+    Path        string
+    Fingerprint string              // hash do método, do caminho e do corpo da requisição
+    Status      int                 // zero enquanto a requisição original está em andamento
+    Header      map[string][]string // headers da resposta que são repetidos
+    Body        []byte
+    CreatedAt   time.Time
+    ExpiresAt   time.Time
+}
+
+// ErrIdempotencyKeyLost indica que a reserva da chave passou para outra
+// requisição depois de vencido o lease.
+var ErrIdempotencyKeyLost = errors.New("idempotency key reservation was taken over")
+
+// Completed indica se a resposta já foi gravada.
+func (r *IdempotencyRecord) Completed() bool {
+    return r.Status != 0
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRepository persiste as chaves de idempotência.
+type IdempotencyRepository interface {
+    // Reserve grava rec como em andamento se a chave do usuário está livre,
+    // expirou ou ficou em andamento por mais que lease (a réplica que a
+    // reservou caiu), e devolve true; caso contrário devolve o registro atual.
+    Reserve(ctx context.Context, rec *IdempotencyRecord, lease time.Duration) (*IdempotencyRecord, bool, error)
+    // Complete grava a resposta de uma chave reservada por rec (mesmo
+    // Token); se a reserva foi retomada por outra requisição, devolve
+    // ErrIdempotencyKeyLost sem alterar nada.
+    Complete(ctx context.Context, rec *IdempotencyRecord) error
+    // Release apaga a reserva de rec cuja requisição falhou, para que o
+    // cliente possa tentar de novo com a mesma chave.
+    Release(ctx context.Context, rec *IdempotencyRecord) error
+    // DeleteExpired apaga até limit chaves expiradas antes de now e devolve
+    // quantas foram apagadas.
+    DeleteExpired(ctx context.Context, now time.Time, limit int) (int, error)
+}
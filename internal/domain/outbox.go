@@ -0,0 +1,10 @@
+package domain
+
+// OutboxEntry é um evento gravado na outbox e ainda não publicado.
+type OutboxEntry struct {
+    ID        int64 // crescente: dá a ordem de publicação dos eventos de uma Task
+    TaskID    string
+    Event     *Event
+    Attempts  int      // publicações que já falharam
+    Delivered []string // sinks que já receberam o evento em tentativas anteriores
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxRepository persiste os eventos de Task até que sejam publicados. Como
+// EventPublisher, grava os eventos na transação do contexto, junto com a
+// mudança que os originou; os demais métodos são usados pelo relay.
+type OutboxRepository interface {
+    EventPublisher
+
+    // ClaimPending reserva por lease até limit eventos pendentes, em ordem de
+    // ID. Uma Task só é reservada se o seu evento mais antigo estiver na hora
+    // e não estiver reservado por outra réplica, então os eventos de uma Task
+    // são publicados por um relay de cada vez.
+    ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*OutboxEntry, error)
+    // MarkPublished registra que os eventos foram entregues aos sinks.
+    MarkPublished(ctx context.Context, ids []int64) error
+    // MarkFailed registra a falha, os sinks que já receberam o evento (que
+    // a nova tentativa pula) e libera o evento para nova tentativa em at; até
+    // lá, os eventos seguintes da mesma Task esperam.
+    MarkFailed(ctx context.Context, id int64, delivered []string, cause string, at time.Time) error
+    // Release solta a reserva de eventos que não foram publicados nem
+    // tentados, sem contar tentativa.
+    Release(ctx context.Context, ids []int64) error
+    // DeletePublished apaga até limit eventos publicados antes de before e
+    // devolve quantos foram apagados.
+    DeletePublished(ctx context.Context, before time.Time, limit int) (int, error)
+}
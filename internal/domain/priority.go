@@ -0,0 +1,62 @@
+package domain
+
+import "fmt"
+
+// Priority é a prioridade de uma Task; valores maiores são mais urgentes.
+// O zero significa "não informada" e é trocado por PriorityMedium na criação.
+type Priority int
+
+const (
+    PriorityLow Priority = iota + 1
+    PriorityMedium
+    PriorityHigh
+    PriorityUrgent
+)
+
+var priorityNames = map[Priority]string{
+    PriorityLow:    "low",
+    PriorityMedium: "medium",
+    PriorityHigh:   "high",
+    PriorityUrgent: "urgent",
+}
+
+// ParsePriority converte o nome ("low", "medium", "high", "urgent") em Priority.
+func ParsePriority(s string) (Priority, error) {
+    for p, name := range priorityNames {
+        if name == s {
+            return p, nil
+        }
+    }
+    return 0, fmt.Errorf("invalid priority %q", s)
+}
+
+// Valid indica se a prioridade é conhecida.
+func (p Priority) Valid() bool {
+    _, ok := priorityNames[p]
+    return ok
+}
+
+func (p Priority) String() string {
+    if name, ok := priorityNames[p]; ok {
+        return name
+    }
+    return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+// MarshalText serializa a prioridade pelo nome.
+func (p Priority) MarshalText() ([]byte, error) {
+    if !p.Valid() {
+        return nil, fmt.Errorf("invalid priority %d", int(p))
+    }
+    return []byte(p.String()), nil
+}
+
+// UnmarshalText aceita o nome da prioridade.
+func (p *Priority) UnmarshalText(b []byte) error {
+    parsed, err := ParsePriority(string(b))
+    if err != nil {
+        return err
+    }
+    *p = parsed
+    return nil
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limites aplicados aos campos de texto de um Project.
+const (
+    ProjectNameMaxLength        = 100
+    ProjectDescriptionMaxLength = 2000
+)
+
+// Project agrupa Tasks de um usuário (um quadro ou lista). Projetos
+// arquivados continuam consultáveis mas não recebem novas Tasks.
+type Project struct {
+    ID          string     `json:"id"`
+    UserID      string     `json:"user_id,omitempty"`
+    Name        string     `json:"name"`
+    Description string     `json:"description"`
+    Color       string     `json:"color,omitempty"` // opcional, #RRGGBB
+    Archived    bool       `json:"archived"`
+    ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+    TaskCount   int        `json:"task_count"` // Tasks do projeto
+    CreatedAt   time.Time  `json:"created_at"`
+    UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Validate normaliza e verifica os campos do Project.
+func (p *Project) Validate() error {
+    p.Name = strings.TrimSpace(p.Name)
+    p.Description = strings.TrimSpace(p.Description)
+    p.Color = strings.TrimSpace(p.Color)
+
+    var fields []FieldError
+    switch {
+    case p.Name == "":
+        fields = append(fields, FieldError{Field: "name", Message: "is required"})
+    case utf8.RuneCountInString(p.Name) > ProjectNameMaxLength:
+        fields = append(fields, FieldError{Field: "name", Message: fmt.Sprintf("must be at most %d characters", ProjectNameMaxLength)})
+    }
+    if utf8.RuneCountInString(p.Description) > ProjectDescriptionMaxLength {
+        fields = append(fields, FieldError{Field: "description", Message: fmt.Sprintf("must be at most %d characters", ProjectDescriptionMaxLength)})
+    }
+    if p.Color != "" && !hexColor.MatchString(p.Color) {
+        fields = append(fields, FieldError{Field: "color", Message: "must be a hex color like #1e90ff"})
+    }
+    if len(fields) > 0 {
+        return NewValidationError(fields...)
+    }
+    return nil
+}
+
+// SetArchived arquiva ou reativa o Project, registrando quando foi arquivado.
+func (p *Project) SetArchived(archived bool, now time.Time) {
+    if p.Archived == archived {
+        return
+    }
+    p.Archived = archived
+    if archived {
+        p.ArchivedAt = &now
+    } else {
+        p.ArchivedAt = nil
+    }
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+var (
+    // ErrProjectNotFound é retornado quando o Project buscado não existe.
+    ErrProjectNotFound = NewNotFoundError("project")
+    // ErrProjectNameTaken é retornado ao criar ou renomear para um nome já usado.
+    ErrProjectNameTaken = NewConflictError("project name already in use")
+    // ErrProjectNotEmpty é retornado ao remover sem cascade um Project com Tasks.
+    ErrProjectNotEmpty = NewConflictError("project still has tasks; archive it or delete with cascade=true")
+    // ErrProjectArchived é retornado ao incluir ou mover Tasks para um Project arquivado.
+    ErrProjectArchived = NewConflictError("project is archived")
+)
+
+// ProjectRepository define as operações de persistência de Project, sempre
+// restritas ao usuário do contexto.
+type ProjectRepository interface {
+    Create(ctx context.Context, project *Project) error
+    FindByID(ctx context.Context, id string) (*Project, error)
+    List(ctx context.Context, includeArchived bool) ([]*Project, error)
+    Update(ctx context.Context, project *Project) error
+    // Delete remove o Project; com cascade remove também suas Tasks, sem
+    // cascade falha com ErrProjectNotEmpty se houver Tasks.
+    Delete(ctx context.Context, id string, cascade bool) error
+}
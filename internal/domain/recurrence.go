@@ -0,0 +1,320 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limites de uma regra de recorrência.
+const (
+    RecurrenceMaxInterval = 366
+    RecurrenceMaxCount    = 1000
+    // recurrenceMaxPeriods interrompe regras que não geram mais ocorrências
+    // (ex.: BYMONTHDAY=31 com INTERVAL=12 a partir de abril).
+    recurrenceMaxPeriods = 50000
+)
+
+// Frequency é a unidade de repetição de uma RRule.
+type Frequency string
+
+const (
+    FreqDaily   Frequency = "DAILY"
+    FreqWeekly  Frequency = "WEEKLY"
+    FreqMonthly Frequency = "MONTHLY"
+)
+
+// weekdayCodes mapeia os dias de BYDAY (RFC 5545) para time.Weekday.
+var weekdayCodes = map[string]time.Weekday{
+    "MO": time.Monday,
+    "TU": time.Tuesday,
+    "WE": time.Wednesday,
+    "TH": time.Thursday,
+    "FR": time.Friday,
+    "SA": time.Saturday,
+    "SU": time.Sunday,
+}
+
+// RRule é o subconjunto suportado da RRULE do iCalendar (RFC 5545):
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY (semanal), BYMONTHDAY (mensal)
+// e UNTIL ou COUNT.
+type RRule struct {
+    Freq       Frequency
+    Interval   int            // a cada quantas unidades; mínimo 1
+    ByDay      []time.Weekday // WEEKLY; vazio repete o dia da semana do início
+    ByMonthDay []int          // MONTHLY; negativos contam do fim do mês (-1 = último dia)
+    Until      *time.Time     // última data possível (inclusiva)
+    UntilDate  bool           // UNTIL sem horário: vale o dia inteiro no fuso da série
+    Count      int            // total de ocorrências, contando a primeira
+}
+
+// ParseRRule interpreta uma regra como "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// O prefixo "RRULE:" é opcional.
+func ParseRRule(s string) (*RRule, error) {
+    s = strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(s)), "RRULE:")
+    if s == "" {
+        return nil, fmt.Errorf("empty rule")
+    }
+
+    r := &RRule{Interval: 1}
+    seen := map[string]bool{}
+    for _, part := range strings.Split(s, ";") {
+        key, value, ok := strings.Cut(part, "=")
+        if !ok || value == "" {
+            return nil, fmt.Errorf("invalid rule part %q", part)
+        }
+        if seen[key] {
+            return nil, fmt.Errorf("%s is repeated", key)
+        }
+        seen[key] = true
+
+        switch key {
+        case "FREQ":
+            switch f := Frequency(value); f {
+            case FreqDaily, FreqWeekly, FreqMonthly:
+                r.Freq = f
+            default:
+                return nil, fmt.Errorf("FREQ must be DAILY, WEEKLY or MONTHLY")
+            }
+        case "INTERVAL":
+            n, err := strconv.Atoi(value)
+            if err != nil || n < 1 || n > RecurrenceMaxInterval {
+                return nil, fmt.Errorf("INTERVAL must be between 1 and %d", RecurrenceMaxInterval)
+            }
+            r.Interval = n
+        case "BYDAY":
+            for _, code := range strings.Split(value, ",") {
+                wd, ok := weekdayCodes[code]
+                if !ok {
+                    return nil, fmt.Errorf("invalid BYDAY value %q", code)
+                }
+                r.ByDay = append(r.ByDay, wd)
+            }
+        case "BYMONTHDAY":
+            for _, v := range strings.Split(value, ",") {
+                d, err := strconv.Atoi(v)
+                if err != nil || d == 0 || d < -31 || d > 31 {
+                    return nil, fmt.Errorf("invalid BYMONTHDAY value %q", v)
+                }
+                r.ByMonthDay = append(r.ByMonthDay, d)
+            }
+        case "COUNT":
+            n, err := strconv.Atoi(value)
+            if err != nil || n < 1 || n > RecurrenceMaxCount {
+                return nil, fmt.Errorf("COUNT must be between 1 and %d", RecurrenceMaxCount)
+            }
+            r.Count = n
+        case "UNTIL":
+            if t, err := time.Parse("20060102T150405Z", value); err == nil {
+                r.Until = &t
+            } else if t, err := time.Parse("20060102", value); err == nil {
+                r.Until = &t
+                r.UntilDate = true
+            } else {
+                return nil, fmt.Errorf("UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+            }
+        default:
+            return nil, fmt.Errorf("%s is not supported", key)
+        }
+    }
+
+    switch {
+    case r.Freq == "":
+        return nil, fmt.Errorf("FREQ is required")
+    case r.Count > 0 && r.Until != nil:
+        return nil, fmt.Errorf("COUNT and UNTIL are mutually exclusive")
+    case len(r.ByDay) > 0 && r.Freq != FreqWeekly:
+        return nil, fmt.Errorf("BYDAY is only supported with FREQ=WEEKLY")
+    case len(r.ByMonthDay) > 0 && r.Freq != FreqMonthly:
+        return nil, fmt.Errorf("BYMONTHDAY is only supported with FREQ=MONTHLY")
+    }
+    r.ByDay = uniqueWeekdays(r.ByDay)
+    r.ByMonthDay = uniqueInts(r.ByMonthDay)
+    return r, nil
+}
+
+// String devolve a regra na forma canônica, com as partes sempre na mesma ordem.
+func (r *RRule) String() string {
+    parts := []string{"FREQ=" + string(r.Freq)}
+    if r.Interval > 1 {
+        parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+    }
+    if len(r.ByDay) > 0 {
+        codes := make([]string, len(r.ByDay))
+        for i, wd := range r.ByDay {
+            codes[i] = strings.ToUpper(wd.String()[:2])
+        }
+        parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+    }
+    if len(r.ByMonthDay) > 0 {
+        days := make([]string, len(r.ByMonthDay))
+        for i, d := range r.ByMonthDay {
+            days[i] = strconv.Itoa(d)
+        }
+        parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+    }
+    if r.Count > 0 {
+        parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+    }
+    if r.Until != nil {
+        if r.UntilDate {
+            parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+        } else {
+            parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+        }
+    }
+    return strings.Join(parts, ";")
+}
+
+// Next retorna a primeira ocorrência estritamente posterior a after na série
+// que começa em start, e sua posição na série (a primeira é 1). ok é false
+// quando a série já terminou.
+func (r *RRule) Next(start, after time.Time, loc *time.Location) (next time.Time, n int, ok bool) {
+    r.each(start, loc, func(i int, t time.Time) bool {
+        if !t.After(after) {
+            return true
+        }
+        next, n, ok = t, i, true
+        return false
+    })
+    return next, n, ok
+}
+
+// Occurrences retorna até limit ocorrências posteriores a after.
+func (r *RRule) Occurrences(start, after time.Time, loc *time.Location, limit int) []time.Time {
+    out := []time.Time{}
+    if limit <= 0 {
+        return out
+    }
+    r.each(start, loc, func(_ int, t time.Time) bool {
+        if t.After(after) {
+            out = append(out, t)
+        }
+        return len(out) < limit
+    })
+    return out
+}
+
+// each percorre as ocorrências em ordem, a partir de start, chamando fn até
+// ela retornar false ou a série acabar. As datas são calculadas no calendário
+// de loc mantendo o horário local de start, então "toda segunda às 9h"
+// continua às 9h depois de uma mudança de horário de verão.
+func (r *RRule) each(start time.Time, loc *time.Location, fn func(n int, t time.Time) bool) {
+    start = start.In(loc)
+    year, month, day := start.Date()
+    hour, min, sec := start.Clock()
+    at := func(y int, m time.Month, d int) time.Time {
+        return time.Date(y, m, d, hour, min, sec, 0, loc)
+    }
+
+    n := 0
+    emit := func(t time.Time) bool {
+        if n > 0 && !t.After(start) {
+            return true
+        }
+        if r.pastUntil(t, loc) {
+            return false
+        }
+        n++
+        if r.Count > 0 && n > r.Count {
+            return false
+        }
+        return fn(n, t)
+    }
+
+    byDay := r.ByDay
+    if len(byDay) == 0 {
+        byDay = []time.Weekday{start.Weekday()}
+    }
+    byMonthDay := r.ByMonthDay
+    if len(byMonthDay) == 0 {
+        byMonthDay = []int{day}
+    }
+    // Semanas começam na segunda (WKST=MO, padrão da RFC)
+    monday := day - (int(start.Weekday())+6)%7
+
+    // Como na RFC, o início é sempre a primeira ocorrência, mesmo que não
+    // case com a regra
+    if !emit(start) {
+        return
+    }
+
+    for period := 0; period < recurrenceMaxPeriods; period++ {
+        switch r.Freq {
+        case FreqDaily:
+            if !emit(at(year, month, day+period*r.Interval)) {
+                return
+            }
+        case FreqWeekly:
+            for _, wd := range byDay {
+                offset := (int(wd) + 6) % 7
+                if !emit(at(year, month, monday+period*7*r.Interval+offset)) {
+                    return
+                }
+            }
+        case FreqMonthly:
+            first := time.Date(year, month+time.Month(period*r.Interval), 1, 0, 0, 0, 0, loc)
+            for _, d := range monthDays(byMonthDay, first) {
+                if !emit(at(first.Year(), first.Month(), d)) {
+                    return
+                }
+            }
+        default:
+            return
+        }
+    }
+}
+
+// pastUntil indica se t já passou de UNTIL.
+func (r *RRule) pastUntil(t time.Time, loc *time.Location) bool {
+    if r.Until == nil {
+        return false
+    }
+    if !r.UntilDate {
+        return t.After(*r.Until)
+    }
+    y, m, d := r.Until.Date()
+    return !t.Before(time.Date(y, m, d+1, 0, 0, 0, 0, loc))
+}
+
+// monthDays resolve os dias de BYMONTHDAY para o mês de first, em ordem e
+// ignorando os que não existem nele (como a RFC manda).
+func monthDays(days []int, first time.Time) []int {
+    last := first.AddDate(0, 1, -1).Day()
+    var out []int
+    for _, d := range days {
+        if d < 0 {
+            d = last + 1 + d
+        }
+        if d >= 1 && d <= last {
+            out = append(out, d)
+        }
+    }
+    return uniqueInts(out)
+}
+
+// uniqueWeekdays ordena (segunda primeiro) e remove repetições.
+func uniqueWeekdays(days []time.Weekday) []time.Weekday {
+    sort.Slice(days, func(i, j int) bool { return (days[i]+6)%7 < (days[j]+6)%7 })
+    var out []time.Weekday
+    for i, d := range days {
+        if i == 0 || d != days[i-1] {
+            out = append(out, d)
+        }
+    }
+    return out
+}
+
+// uniqueInts ordena e remove repetições.
+func uniqueInts(values []int) []int {
+    sort.Ints(values)
+    var out []int
+    for i, v := range values {
+        if i == 0 || v != values[i-1] {
+            out = append(out, v)
+        }
+    }
+    return out
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+    t.Helper()
+    loc, err := LoadTimezone(name)
+    if err != nil {
+        t.Fatalf("LoadTimezone(%q): %v", name, err)
+    }
+    return loc
+}
+
+func formatTimes(times []time.Time) []string {
+    out := make([]string, len(times))
+    for i, t := range times {
+        out[i] = t.Format("2006-01-02 15:04 -0700")
+    }
+    return out
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+    tests := []struct {
+        name  string
+        rule  string
+        zone  string
+        start string // no fuso da série
+        limit int
+        want  []string
+    }{
+        {
+            name:  "daily with interval",
+            rule:  "FREQ=DAILY;INTERVAL=2",
+            zone:  "UTC",
+            start: "2026-01-30 09:00",
+            limit: 4,
+            want:  []string{"2026-01-30 09:00 +0000", "2026-02-01 09:00 +0000", "2026-02-03 09:00 +0000", "2026-02-05 09:00 +0000"},
+        },
+        {
+            name:  "weekly by day",
+            rule:  "FREQ=WEEKLY;BYDAY=WE,MO",
+            zone:  "UTC",
+            start: "2026-03-02 09:00", // segunda
+            limit: 4,
+            want:  []string{"2026-03-02 09:00 +0000", "2026-03-04 09:00 +0000", "2026-03-09 09:00 +0000", "2026-03-11 09:00 +0000"},
+        },
+        {
+            name:  "weekly start off the rule is still the first occurrence",
+            rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+            zone:  "UTC",
+            start: "2026-03-03 09:00", // terça
+            limit: 3,
+            want:  []string{"2026-03-03 09:00 +0000", "2026-03-16 09:00 +0000", "2026-03-30 09:00 +0000"},
+        },
+        {
+            name:  "monthly on the 31st skips short months",
+            rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+            zone:  "UTC",
+            start: "2026-01-31 09:00",
+            limit: 5,
+            want:  []string{"2026-01-31 09:00 +0000", "2026-03-31 09:00 +0000", "2026-05-31 09:00 +0000", "2026-07-31 09:00 +0000", "2026-08-31 09:00 +0000"},
+        },
+        {
+            name:  "monthly on the last day",
+            rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+            zone:  "UTC",
+            start: "2028-01-31 09:00",
+            limit: 4,
+            want:  []string{"2028-01-31 09:00 +0000", "2028-02-29 09:00 +0000", "2028-03-31 09:00 +0000", "2028-04-30 09:00 +0000"},
+        },
+        {
+            name:  "count includes the first occurrence",
+            rule:  "FREQ=DAILY;COUNT=3",
+            zone:  "UTC",
+            start: "2026-03-01 09:00",
+            limit: 10,
+            want:  []string{"2026-03-01 09:00 +0000", "2026-03-02 09:00 +0000", "2026-03-03 09:00 +0000"},
+        },
+        {
+            name:  "until date covers the whole day in the series zone",
+            rule:  "FREQ=DAILY;UNTIL=20260305",
+            zone:  "America/Sao_Paulo",
+            start: "2026-03-03 22:00",
+            limit: 10,
+            want:  []string{"2026-03-03 22:00 -0300", "2026-03-04 22:00 -0300", "2026-03-05 22:00 -0300"},
+        },
+        {
+            name:  "until date-time is an instant",
+            rule:  "FREQ=DAILY;UNTIL=20260306T000000Z",
+            zone:  "America/Sao_Paulo",
+            start: "2026-03-03 22:00", // 01:00Z do dia seguinte
+            limit: 10,
+            want:  []string{"2026-03-03 22:00 -0300", "2026-03-04 22:00 -0300"},
+        },
+        {
+            name:  "daily keeps the local time across DST",
+            rule:  "FREQ=DAILY",
+            zone:  "America/New_York",
+            start: "2026-03-07 09:00", // o horário de verão começa em 8/3
+            limit: 3,
+            want:  []string{"2026-03-07 09:00 -0500", "2026-03-08 09:00 -0400", "2026-03-09 09:00 -0400"},
+        },
+        {
+            name:  "weekly keeps the local time across DST",
+            rule:  "FREQ=WEEKLY;BYDAY=MO",
+            zone:  "America/New_York",
+            start: "2026-10-26 09:00", // e termina em 1/11
+            limit: 2,
+            want:  []string{"2026-10-26 09:00 -0400", "2026-11-02 09:00 -0500"},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rr, err := ParseRRule(tt.rule)
+            if err != nil {
+                t.Fatalf("ParseRRule(%q): %v", tt.rule, err)
+            }
+            loc := mustLocation(t, tt.zone)
+            start, err := time.ParseInLocation("2006-01-02 15:04", tt.start, loc)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            got := formatTimes(rr.Occurrences(start, start.Add(-time.Second), loc, tt.limit))
+            if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+                t.Errorf("Occurrences() =\n  %v\nwant\n  %v", got, tt.want)
+            }
+
+            // Next a partir de cada ocorrência leva à seguinte, com a posição certa
+            after := start
+            for i := 1; i < len(tt.want); i++ {
+                next, n, ok := rr.Next(start, after, loc)
+                if !ok || n != i+1 || formatTimes([]time.Time{next})[0] != tt.want[i] {
+                    t.Fatalf("Next(after %v) = %v, %d, %v; want %s, %d", after, next, n, ok, tt.want[i], i+1)
+                }
+                after = next
+            }
+        })
+    }
+}
+
+func TestRRuleNextEndsWithTheSeries(t *testing.T) {
+    loc := mustLocation(t, "UTC")
+    start := time.Date(2026, 3, 1, 9, 0, 0, 0, loc)
+
+    for _, rule := range []string{"FREQ=DAILY;COUNT=2", "FREQ=DAILY;UNTIL=20260302", "FREQ=DAILY;UNTIL=20260302T090000Z"} {
+        rr, err := ParseRRule(rule)
+        if err != nil {
+            t.Fatalf("ParseRRule(%q): %v", rule, err)
+        }
+        second := start.AddDate(0, 0, 1)
+        if next, n, ok := rr.Next(start, start, loc); !ok || n != 2 || !next.Equal(second) {
+            t.Errorf("%s: Next(first) = %v, %d, %v; want %v, 2", rule, next, n, ok, second)
+        }
+        if next, _, ok := rr.Next(start, second, loc); ok {
+            t.Errorf("%s: Next(last) = %v, want the end of the series", rule, next)
+        }
+    }
+}
+
+func TestParseRRule(t *testing.T) {
+    tests := []struct {
+        rule    string
+        want    string
+        wantErr bool
+    }{
+        {rule: "rrule:freq=weekly;byday=we,mo,we", want: "FREQ=WEEKLY;BYDAY=MO,WE"},
+        {rule: "FREQ=MONTHLY;BYMONTHDAY=-1,15;INTERVAL=1", want: "FREQ=MONTHLY;BYMONTHDAY=-1,15"},
+        {rule: "FREQ=DAILY;UNTIL=20260305", want: "FREQ=DAILY;UNTIL=20260305"},
+        {rule: "FREQ=DAILY;UNTIL=20260305T120000Z", want: "FREQ=DAILY;UNTIL=20260305T120000Z"},
+        {rule: "FREQ=DAILY;COUNT=2;UNTIL=20260305", wantErr: true},
+        {rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+        {rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+        {rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+        {rule: "FREQ=YEARLY", wantErr: true},
+        {rule: "FREQ=DAILY;FREQ=DAILY", wantErr: true},
+        {rule: "INTERVAL=2", wantErr: true},
+        {rule: "FREQ=DAILY;UNTIL=2026-03-05", wantErr: true},
+    }
+
+    for _, tt := range tests {
+        rr, err := ParseRRule(tt.rule)
+        if tt.wantErr {
+            if err == nil {
+                t.Errorf("ParseRRule(%q) = %s, want an error", tt.rule, rr)
+            }
+            continue
+        }
+        if err != nil {
+            t.Errorf("ParseRRule(%q): %v", tt.rule, err)
+            continue
+        }
+        if got := rr.String(); got != tt.want {
+            t.Errorf("ParseRRule(%q).String() = %q, want %q", tt.rule, got, tt.want)
+        }
+    }
+}
+
+func TestTaskNextOccurrence(t *testing.T) {
+    start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+    recurrence, err := NewTaskRecurrence("FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", "UTC", start)
+    if err != nil {
+        t.Fatal(err)
+    }
+    recurrence.SeriesID = "series"
+    task := &Task{ID: "first", Title: "pay rent", Status: StatusDone, Priority: PriorityHigh, DueDate: &start, Tags: []string{"home"}, Recurrence: recurrence}
+
+    next, ok, err := task.NextOccurrence()
+    if err != nil || !ok {
+        t.Fatalf("NextOccurrence() = %v, %v", ok, err)
+    }
+    if want := time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC); !next.DueDate.Equal(want) {
+        t.Errorf("due = %v, want %v", next.DueDate, want)
+    }
+    if next.Status != StatusTodo || next.Title != task.Title || next.Priority != task.Priority {
+        t.Errorf("next = %+v, want a todo copy of the task", next)
+    }
+    if next.Recurrence.Occurrence != 2 || next.Recurrence.SeriesID != "series" || !next.Recurrence.Start.Equal(start) {
+        t.Errorf("recurrence = %+v", next.Recurrence)
+    }
+    next.Tags[0] = "changed"
+    if task.Tags[0] != "home" {
+        t.Error("next occurrence shares the tags slice")
+    }
+
+    last, ok, err := next.NextOccurrence()
+    if err != nil || !ok || last.Recurrence.Occurrence != 3 {
+        t.Fatalf("third occurrence = %+v, %v, %v", last, ok, err)
+    }
+    if _, ok, _ := last.NextOccurrence(); ok {
+        t.Error("series with COUNT=3 has a fourth occurrence")
+    }
+
+    if _, ok, _ := (&Task{DueDate: &start}).NextOccurrence(); ok {
+        t.Error("task without recurrence has a next occurrence")
+    }
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Limites aplicados aos lembretes.
+const (
+    ReminderMaxPerTask       = 10
+    ReminderMaxBeforeMinutes = 60 * 24 * 30 // 30 dias antes do vencimento
+)
+
+// Reminder é um aviso agendado para uma Task: em um horário fixo (At) ou
+// alguns minutos antes do vencimento (BeforeMinutes). Lembretes relativos
+// acompanham mudanças no vencimento enquanto não foram enviados.
+type Reminder struct {
+    ID            string     `json:"id"`
+    TaskID        string     `json:"task_id"`
+    At            *time.Time `json:"at,omitempty"`                          // horário absoluto
+    BeforeMinutes *int       `json:"before_minutes,omitempty" example:"60"` // antecedência em relação a due_date
+    FireAt        *time.Time `json:"fire_at"`                               // próximo disparo; nil se a Task não tem vencimento
+    Attempts      int        `json:"attempts"`                              // entregas já tentadas
+    LastError     string     `json:"last_error,omitempty"`                  // erro da última tentativa que falhou
+    SentAt        *time.Time `json:"sent_at,omitempty"`
+    FailedAt      *time.Time `json:"failed_at,omitempty"` // desistiu após o máximo de tentativas
+    CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Validate verifica que o lembrete tem exatamente um gatilho e que ele faz
+// sentido para a Task: At no futuro, ou BeforeMinutes com a Task tendo vencimento.
+func (r *Reminder) Validate(task *Task, now time.Time) error {
+    var fields []FieldError
+    switch {
+    case r.At == nil && r.BeforeMinutes == nil:
+        fields = append(fields, FieldError{Field: "at", Message: "either at or before_minutes is required"})
+    case r.At != nil && r.BeforeMinutes != nil:
+        fields = append(fields, FieldError{Field: "at", Message: "must not be combined with before_minutes"})
+    case r.At != nil && !r.At.After(now):
+        fields = append(fields, FieldError{Field: "at", Message: "must be in the future"})
+    case r.BeforeMinutes != nil && (*r.BeforeMinutes < 1 || *r.BeforeMinutes > ReminderMaxBeforeMinutes):
+        fields = append(fields, FieldError{Field: "before_minutes", Message: fmt.Sprintf("must be between 1 and %d", ReminderMaxBeforeMinutes)})
+    case r.BeforeMinutes != nil && task.DueDate == nil:
+        fields = append(fields, FieldError{Field: "before_minutes", Message: "requires the task to have a due_date"})
+    }
+    if len(fields) > 0 {
+        return NewValidationError(fields...)
+    }
+    return nil
+}
+
+// ReminderNotification é o que os Notifiers recebem quando um lembrete dispara.
+type ReminderNotification struct {
+    ReminderID string     `json:"reminder_id"`
+    TaskID     string     `json:"task_id"`
+    TaskTitle  string     `json:"task_title"`
+    DueDate    *time.Time `json:"due_date,omitempty"`
+    FireAt     time.Time  `json:"fire_at"`
+    Overdue    bool       `json:"overdue"` // o vencimento já passou no momento do envio
+    UserID     string     `json:"user_id"`
+    UserEmail  string     `json:"user_email"`
+    UserName   string     `json:"user_name"`
+    Timezone   string     `json:"timezone"` // fuso do usuário, para formatar datas
+    Attempt    int        `json:"attempt"`  // 1 na primeira tentativa
+}
+
+// Notifier entrega lembretes por algum canal (log, webhook, e-mail...).
+// Um erro faz o lembrete ser tentado de novo mais tarde.
+type Notifier interface {
+    Notify(ctx context.Context, n *ReminderNotification) error
+}
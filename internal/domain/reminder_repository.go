@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ErrReminderNotFound é retornado quando o lembrete buscado não existe.
+var ErrReminderNotFound = NewNotFoundError("reminder")
+
+// ReminderRepository define as operações de persistência de Reminder. As
+// operações por Task são restritas ao usuário do contexto; as de entrega são
+// usadas pelo scheduler, sem usuário.
+type ReminderRepository interface {
+    Create(ctx context.Context, r *Reminder) error
+    // ListByTask retorna os lembretes da Task em ordem de disparo.
+    ListByTask(ctx context.Context, taskID string) ([]*Reminder, error)
+    Delete(ctx context.Context, taskID, id string) error
+
+    // ClaimDue reserva até limit lembretes pendentes cujo horário já chegou,
+    // de Tasks ainda abertas, por lease. Lembretes reservados por outra
+    // réplica são pulados; cada reserva conta como uma tentativa.
+    ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*ReminderNotification, error)
+    // MarkSent registra a entrega do lembrete.
+    MarkSent(ctx context.Context, id string) error
+    // MarkRetry registra a falha e libera o lembrete para nova tentativa em at.
+    MarkRetry(ctx context.Context, id, cause string, at time.Time) error
+    // MarkFailed registra a falha definitiva; o lembrete não é mais tentado.
+    MarkFailed(ctx context.Context, id, cause string) error
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limites aplicados às tags.
+const (
+    TagNameMaxLength = 50
+    TaskMaxTags      = 20 // tags por Task
+)
+
+// hexColor aceita cores no formato #RRGGBB.
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag é um rótulo do usuário usado para categorizar Tasks. O nome é único
+// por usuário, sem diferenciar maiúsculas de minúsculas.
+type Tag struct {
+    ID        string    `json:"id"`
+    UserID    string    `json:"user_id,omitempty"`
+    Name      string    `json:"name"`
+    Color     string    `json:"color,omitempty"` // opcional, #RRGGBB
+    TaskCount int       `json:"task_count"`      // Tasks marcadas com a tag
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate normaliza e verifica o nome e a cor da Tag.
+func (t *Tag) Validate() error {
+    t.Name = strings.TrimSpace(t.Name)
+    t.Color = strings.TrimSpace(t.Color)
+
+    var fields []FieldError
+    if msg := validateTagName(t.Name); msg != "" {
+        fields = append(fields, FieldError{Field: "name", Message: msg})
+    }
+    if t.Color != "" && !hexColor.MatchString(t.Color) {
+        fields = append(fields, FieldError{Field: "color", Message: "must be a hex color like #1e90ff"})
+    }
+    if len(fields) > 0 {
+        return NewValidationError(fields...)
+    }
+    return nil
+}
+
+// validateTagName devolve o motivo de o nome ser inválido ou "".
+// Vírgulas são proibidas porque separam tags nos filtros de listagem.
+func validateTagName(name string) string {
+    switch {
+    case name == "":
+        return "is required"
+    case utf8.RuneCountInString(name) > TagNameMaxLength:
+        return fmt.Sprintf("must be at most %d characters", TagNameMaxLength)
+    case strings.Contains(name, ","):
+        return "must not contain commas"
+    }
+    return ""
+}
+
+// normalizeTagNames remove espaços e vazios e descarta nomes repetidos
+// (sem diferenciar maiúsculas), mantendo a primeira grafia.
+func normalizeTagNames(names []string) []string {
+    if names == nil {
+        return nil
+    }
+    out := make([]string, 0, len(names))
+    seen := map[string]bool{}
+    for _, n := range names {
+        n = strings.TrimSpace(n)
+        key := strings.ToLower(n)
+        if n == "" || seen[key] {
+            continue
+        }
+        seen[key] = true
+        out = append(out, n)
+    }
+    return out
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+var (
+    // ErrTagNotFound é retornado quando a Tag buscada não existe.
+    ErrTagNotFound = NewNotFoundError("tag")
+    // ErrTagNameTaken é retornado ao criar ou renomear para um nome já usado.
+    ErrTagNameTaken = NewConflictError("tag name already in use")
+)
+
+// TagRepository define as operações de persistência de Tag, sempre restritas
+// ao usuário do contexto.
+type TagRepository interface {
+    Create(ctx context.Context, tag *Tag) error
+    FindByID(ctx context.Context, id string) (*Tag, error)
+    List(ctx context.Context) ([]*Tag, error)
+    Update(ctx context.Context, tag *Tag) error
+    Delete(ctx context.Context, id string) error
+}
@@ -4,11 +4,20 @@ import "time"
 
 // Task representa uma tarefa do usuário.
 type Task struct {
-    ID          string    // UUID gerado
-    Title       string
-    Description string
-    DueDate     time.Time
-    Completed   bool
-    CreatedAt   time.Time
-    UpdatedAt   time.Time
+    ID          string          `json:"id"`                   // UUID gerado
+    UserID      string          `json:"user_id,omitempty"`    // dono da tarefa
+    ProjectID   string          `json:"project_id,omitempty"` // projeto, se houver
+    ParentID    string          `json:"parent_id,omitempty"`  // Task pai, se for subtarefa
+    Title       string          `json:"title"`
+    Description string          `json:"description"`
+    Status      TaskStatus      `json:"status" enums:"todo,in_progress,blocked,done,cancelled"`
+    Priority    Priority        `json:"priority" swaggertype:"string" enums:"low,medium,high,urgent"`
+    DueDate     *time.Time      `json:"due_date"`             // opcional
+    Completed   bool            `json:"completed"`            // espelha Status == done
+    Tags        []string        `json:"tags"`                 // nomes das tags, em ordem alfabética
+    Progress    *TaskProgress   `json:"progress,omitempty"`   // apenas em Tasks com subtarefas
+    Recurrence  *TaskRecurrence `json:"recurrence,omitempty"` // série recorrente, se houver
+    Version     int             `json:"version" example:"1"`  // incrementada a cada alteração; é o ETag
+    CreatedAt   time.Time       `json:"created_at"`
+    UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// TaskDependency é uma aresta do grafo de dependências: TaskID só pode
+// começar depois que DependsOnID terminar (concluída ou cancelada).
+type TaskDependency struct {
+    TaskID      string `json:"task_id"`
+    DependsOnID string `json:"depends_on_id"`
+}
+
+// TaskDependencies são as vizinhanças de uma Task no grafo.
+type TaskDependencies struct {
+    BlockedBy []*Task `json:"blocked_by"` // pré-requisitos
+    Blocks    []*Task `json:"blocks"`     // Tasks que esperam por esta
+}
+
+// CriticalPath é a maior cadeia de Tasks abertas de um projeto ligadas por
+// dependências: nenhuma delas pode começar antes da anterior terminar.
+type CriticalPath struct {
+    Tasks []*Task `json:"tasks"`
+    // Maior vencimento do caminho: a data mínima para concluir a cadeia
+    DueDate *time.Time `json:"due_date"`
+    // Arestas do caminho em que o pré-requisito vence depois da Task que
+    // depende dele, ou seja, prazos que não podem ser cumpridos
+    Conflicts []TaskDependency `json:"conflicts"`
+}
+
+// ComputeCriticalPath calcula o caminho crítico do grafo formado por tasks e
+// edges (arestas com pontas fora de tasks são ignoradas). O grafo precisa ser
+// acíclico. Entre caminhos do mesmo tamanho vence o de vencimento mais tardio;
+// Tasks sem vencimento não contam para a data.
+func ComputeCriticalPath(tasks []*Task, edges []TaskDependency) *CriticalPath {
+    path := &CriticalPath{Tasks: []*Task{}, Conflicts: []TaskDependency{}}
+
+    // Ordem estável para que empates tenham sempre o mesmo resultado
+    byID := make(map[string]*Task, len(tasks))
+    ordered := make([]*Task, len(tasks))
+    copy(ordered, tasks)
+    sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+    for _, t := range ordered {
+        byID[t.ID] = t
+    }
+
+    prereqs := map[string][]string{}
+    dependents := map[string][]string{}
+    pending := map[string]int{}
+    for _, e := range edges {
+        if byID[e.TaskID] == nil || byID[e.DependsOnID] == nil {
+            continue
+        }
+        prereqs[e.TaskID] = append(prereqs[e.TaskID], e.DependsOnID)
+        dependents[e.DependsOnID] = append(dependents[e.DependsOnID], e.TaskID)
+        pending[e.TaskID]++
+    }
+
+    // Kahn: cada Task é visitada depois de todos os seus pré-requisitos
+    type best struct {
+        length int
+        due    *time.Time
+        prev   string
+    }
+    bests := make(map[string]best, len(ordered))
+    var queue []string
+    for _, t := range ordered {
+        if pending[t.ID] == 0 {
+            queue = append(queue, t.ID)
+        }
+    }
+    var end string
+    for len(queue) > 0 {
+        id := queue[0]
+        queue = queue[1:]
+
+        b := best{length: 1}
+        for _, p := range prereqs[id] {
+            if pb := bests[p]; longerPath(pb.length+1, pb.due, b.length, b.due) {
+                b = best{length: pb.length + 1, due: pb.due, prev: p}
+            }
+        }
+        b.due = laterDate(b.due, byID[id].DueDate)
+        bests[id] = b
+        if end == "" || longerPath(b.length, b.due, bests[end].length, bests[end].due) {
+            end = id
+        }
+
+        for _, d := range dependents[id] {
+            if pending[d]--; pending[d] == 0 {
+                queue = append(queue, d)
+            }
+        }
+    }
+    if end == "" {
+        return path
+    }
+
+    for id := end; id != ""; id = bests[id].prev {
+        path.Tasks = append([]*Task{byID[id]}, path.Tasks...)
+    }
+    path.DueDate = bests[end].due
+    for i := 1; i < len(path.Tasks); i++ {
+        prev, next := path.Tasks[i-1], path.Tasks[i]
+        if prev.DueDate != nil && next.DueDate != nil && prev.DueDate.After(*next.DueDate) {
+            path.Conflicts = append(path.Conflicts, TaskDependency{TaskID: next.ID, DependsOnID: prev.ID})
+        }
+    }
+    return path
+}
+
+// longerPath indica se o caminho (length, due) supera (otherLength, otherDue).
+func longerPath(length int, due *time.Time, otherLength int, otherDue *time.Time) bool {
+    if length != otherLength {
+        return length > otherLength
+    }
+    return due != nil && (otherDue == nil || due.After(*otherDue))
+}
+
+// laterDate retorna a mais tardia das duas datas, ignorando nil.
+func laterDate(a, b *time.Time) *time.Time {
+    if a == nil || (b != nil && b.After(*a)) {
+        return b
+    }
+    return a
+}
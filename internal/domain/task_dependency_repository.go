@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+var (
+    // ErrDependencyNotFound é retornado ao remover uma dependência que não existe.
+    ErrDependencyNotFound = NewNotFoundError("dependency")
+    // ErrDependencyCycle é retornado quando a nova dependência fecharia um ciclo.
+    ErrDependencyCycle = NewConflictError("dependency would create a cycle")
+    // ErrTaskHasOpenDependencies é retornado ao iniciar ou concluir uma Task
+    // cujos pré-requisitos ainda estão abertos.
+    ErrTaskHasOpenDependencies = NewConflictError("task has open dependencies")
+)
+
+// TaskDependencyRepository define as operações sobre o grafo de dependências
+// entre Tasks, sempre restritas ao usuário do contexto.
+type TaskDependencyRepository interface {
+    // Add cria a aresta (repetir é no-op) ou falha com ErrDependencyCycle.
+    Add(ctx context.Context, taskID, dependsOnID string) error
+    Remove(ctx context.Context, taskID, dependsOnID string) error
+    // BlockedBy retorna os pré-requisitos diretos da Task.
+    BlockedBy(ctx context.Context, taskID string) ([]*Task, error)
+    // Blocks retorna as Tasks que dependem diretamente da Task.
+    Blocks(ctx context.Context, taskID string) ([]*Task, error)
+    // SyncBlocked move para blocked as Tasks em todo com pré-requisitos
+    // abertos e devolve para todo as bloqueadas que não têm mais nenhum.
+    SyncBlocked(ctx context.Context, taskIDs []string) error
+    // ProjectGraph retorna as Tasks abertas do projeto e as dependências entre elas.
+    ProjectGraph(ctx context.Context, projectID string) ([]*Task, []TaskDependency, error)
+}
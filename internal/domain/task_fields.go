@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// TaskField identifica um campo gravável da Task, para atualizações que
+// escrevem apenas o que mudou (TaskRepository.UpdateFields).
+type TaskField string
+
+const (
+    TaskFieldProject     TaskField = "project_id"
+    TaskFieldParent      TaskField = "parent_id"
+    TaskFieldTitle       TaskField = "title"
+    TaskFieldDescription TaskField = "description"
+    TaskFieldStatus      TaskField = "status"
+    TaskFieldPriority    TaskField = "priority"
+    TaskFieldDueDate     TaskField = "due_date"
+    TaskFieldCompleted   TaskField = "completed"
+    TaskFieldTags        TaskField = "tags"
+    TaskFieldRecurrence  TaskField = "recurrence"
+)
+
+// Clone devolve uma cópia da Task que não compartilha tags, vencimento nem
+// recorrência com a original, para comparar o antes e o depois de uma alteração.
+func (t *Task) Clone() *Task {
+    c := *t
+    c.Tags = slices.Clone(t.Tags)
+    if t.DueDate != nil {
+        due := *t.DueDate
+        c.DueDate = &due
+    }
+    if t.Recurrence != nil {
+        rec := *t.Recurrence
+        c.Recurrence = &rec
+    }
+    if t.Progress != nil {
+        progress := *t.Progress
+        c.Progress = &progress
+    }
+    return &c
+}
+
+// ChangedFields lista os campos graváveis em que a Task difere de before.
+func (t *Task) ChangedFields(before *Task) []TaskField {
+    var fields []TaskField
+    changed := func(field TaskField, differ bool) {
+        if differ {
+            fields = append(fields, field)
+        }
+    }
+    changed(TaskFieldProject, t.ProjectID != before.ProjectID)
+    changed(TaskFieldParent, t.ParentID != before.ParentID)
+    changed(TaskFieldTitle, t.Title != before.Title)
+    changed(TaskFieldDescription, t.Description != before.Description)
+    changed(TaskFieldStatus, t.Status != before.Status)
+    changed(TaskFieldPriority, t.Priority != before.Priority)
+    changed(TaskFieldDueDate, !sameTime(t.DueDate, before.DueDate))
+    changed(TaskFieldCompleted, t.Completed != before.Completed)
+    changed(TaskFieldTags, t.Tags != nil && !slices.Equal(t.Tags, before.Tags))
+    changed(TaskFieldRecurrence, !sameRecurrence(t.Recurrence, before.Recurrence))
+    return fields
+}
+
+func sameTime(a, b *time.Time) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return a.Equal(*b)
+}
+
+func sameRecurrence(a, b *TaskRecurrence) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return a.Rule == b.Rule && a.Timezone == b.Timezone && a.SeriesID == b.SeriesID &&
+        a.Start.Equal(b.Start) && a.Occurrence == b.Occurrence
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Matches avalia o filtro em memória, com a mesma semântica da listagem,
+// para quem acompanha mudanças sem consultar o banco. Ordenação e paginação
+// são ignoradas; a busca textual exige todas as palavras de Search em título
+// ou descrição, sem diferenciar maiúsculas.
+func (f TaskFilter) Matches(t *Task, now time.Time) bool {
+    if f.ProjectID != "" && t.ProjectID != f.ProjectID {
+        return false
+    }
+    if f.ParentID != "" && t.ParentID != f.ParentID {
+        return false
+    }
+    if f.Completed != nil && t.Completed != *f.Completed {
+        return false
+    }
+    if len(f.Statuses) > 0 && !containsStatus(f.Statuses, t.Status) {
+        return false
+    }
+    if len(f.Priorities) > 0 && !containsPriority(f.Priorities, t.Priority) {
+        return false
+    }
+    if f.DueAfter != nil && (t.DueDate == nil || t.DueDate.Before(*f.DueAfter)) {
+        return false
+    }
+    if f.DueBefore != nil && (t.DueDate == nil || !t.DueDate.Before(*f.DueBefore)) {
+        return false
+    }
+    if f.Overdue && (t.DueDate == nil || !t.DueDate.Before(now) || t.Status == StatusDone || t.Status == StatusCancelled) {
+        return false
+    }
+    if f.CreatedAfter != nil && t.CreatedAt.Before(*f.CreatedAfter) {
+        return false
+    }
+    if f.CreatedBefore != nil && !t.CreatedAt.Before(*f.CreatedBefore) {
+        return false
+    }
+    if f.UpdatedAfter != nil && t.UpdatedAt.Before(*f.UpdatedAfter) {
+        return false
+    }
+    if f.UpdatedBefore != nil && !t.UpdatedAt.Before(*f.UpdatedBefore) {
+        return false
+    }
+    if f.Search != "" && !matchesSearch(t, f.Search) {
+        return false
+    }
+    return matchesTags(t.Tags, f.TagsAny, f.TagsAll, f.TagsNone)
+}
+
+func containsStatus(list []TaskStatus, st TaskStatus) bool {
+    for _, s := range list {
+        if s == st {
+            return true
+        }
+    }
+    return false
+}
+
+func containsPriority(list []Priority, p Priority) bool {
+    for _, item := range list {
+        if item == p {
+            return true
+        }
+    }
+    return false
+}
+
+// matchesSearch aproxima o plainto_tsquery('simple', ...) da listagem: cada
+// palavra da busca precisa aparecer como palavra em título ou descrição.
+func matchesSearch(t *Task, search string) bool {
+    words := make(map[string]bool)
+    for _, w := range searchWords(t.Title + " " + t.Description) {
+        words[w] = true
+    }
+    for _, w := range searchWords(search) {
+        if !words[w] {
+            return false
+        }
+    }
+    return true
+}
+
+func searchWords(s string) []string {
+    return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+    })
+}
+
+// matchesTags aplica os filtros any/all/none sem diferenciar maiúsculas.
+func matchesTags(tags, anyOf, allOf, noneOf []string) bool {
+    has := make(map[string]bool, len(tags))
+    for _, tag := range tags {
+        has[strings.ToLower(tag)] = true
+    }
+    if len(anyOf) > 0 {
+        found := false
+        for _, name := range anyOf {
+            if has[strings.ToLower(name)] {
+                found = true
+                break
+            }
+        }
+        if !found {
+            return false
+        }
+    }
+    for _, name := range allOf {
+        if !has[strings.ToLower(name)] {
+            return false
+        }
+    }
+    for _, name := range noneOf {
+        if has[strings.ToLower(name)] {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,69 @@
+package domain
+
+// TaskMaxDepth é o número máximo de níveis de uma hierarquia de Tasks,
+// contando a raiz (raiz → subtarefa → ... → 5º nível).
+const TaskMaxDepth = 5
+
+// TaskProgress resume as subtarefas (todos os descendentes) de uma Task.
+// Subtarefas canceladas não entram na conta.
+type TaskProgress struct {
+    Total     int `json:"total" example:"8"`
+    Completed int `json:"completed" example:"6"`
+    Percent   int `json:"percent" example:"75"` // Completed/Total, arredondado para baixo
+}
+
+// NewTaskProgress calcula o percentual; sem subtarefas ativas ele é zero.
+func NewTaskProgress(total, completed int) *TaskProgress {
+    p := &TaskProgress{Total: total, Completed: completed}
+    if total > 0 {
+        p.Percent = completed * 100 / total
+    }
+    return p
+}
+
+// TaskNode é uma Task com suas subtarefas, usada na resposta da árvore.
+type TaskNode struct {
+    *Task
+    Children []*TaskNode `json:"children"`
+}
+
+// BuildTaskTree monta a árvore a partir da raiz e de todos os seus
+// descendentes (em qualquer ordem), preenchendo Progress de cada nó que
+// tem filhos. Retorna também a altura da árvore (0 para uma Task sem filhos).
+func BuildTaskTree(root *Task, descendants []*Task) (*TaskNode, int) {
+    nodes := map[string]*TaskNode{root.ID: {Task: root, Children: []*TaskNode{}}}
+    for _, t := range descendants {
+        nodes[t.ID] = &TaskNode{Task: t, Children: []*TaskNode{}}
+    }
+    for _, t := range descendants {
+        if parent, ok := nodes[t.ParentID]; ok {
+            parent.Children = append(parent.Children, nodes[t.ID])
+        }
+    }
+    tree := nodes[root.ID]
+    _, _, height := tree.rollUp()
+    return tree, height
+}
+
+// rollUp percorre a subárvore preenchendo Progress e devolve os totais de
+// descendentes (ativos e concluídos) e a altura.
+func (n *TaskNode) rollUp() (total, completed, height int) {
+    for _, c := range n.Children {
+        t, done, h := c.rollUp()
+        total += t
+        completed += done
+        if c.Status != StatusCancelled {
+            total++
+            if c.Status == StatusDone {
+                completed++
+            }
+        }
+        if h+1 > height {
+            height = h + 1
+        }
+    }
+    if len(n.Children) > 0 {
+        n.Progress = NewTaskProgress(total, completed)
+    }
+    return total, completed, height
+}
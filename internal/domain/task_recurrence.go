@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaskRecurrence liga uma Task a uma série recorrente. Cada ocorrência é uma
+// Task própria; ao concluir uma, a seguinte é criada com o próximo vencimento.
+type TaskRecurrence struct {
+    Rule       string    `json:"rule" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+    Timezone   string    `json:"timezone" example:"America/Sao_Paulo"` // fuso usado no cálculo das datas
+    SeriesID   string    `json:"series_id"`                            // ID da primeira ocorrência
+    Start      time.Time `json:"start"`                                // vencimento da primeira ocorrência (DTSTART)
+    Occurrence int       `json:"occurrence" example:"1"`               // posição desta Task na série
+}
+
+// LoadTimezone carrega um fuso IANA (ex.: America/Sao_Paulo). "Local" é
+// recusado porque depende da máquina em que o servidor roda.
+func LoadTimezone(name string) (*time.Location, error) {
+    if name == "" || name == "Local" {
+        return nil, fmt.Errorf("unknown time zone %q", name)
+    }
+    return time.LoadLocation(name)
+}
+
+// NewTaskRecurrence inicia uma série com a regra informada, começando em
+// start (o vencimento da Task) e calculada no fuso timezone.
+func NewTaskRecurrence(rule, timezone string, start time.Time) (*TaskRecurrence, error) {
+    var fields []FieldError
+    rr, err := ParseRRule(rule)
+    if err != nil {
+        fields = append(fields, FieldError{Field: "recurrence", Message: err.Error()})
+    }
+    if _, err := LoadTimezone(timezone); err != nil {
+        fields = append(fields, FieldError{Field: "timezone", Message: "must be an IANA time zone such as America/Sao_Paulo"})
+    }
+    if len(fields) > 0 {
+        return nil, NewValidationError(fields...)
+    }
+    return &TaskRecurrence{Rule: rr.String(), Timezone: timezone, Start: start, Occurrence: 1}, nil
+}
+
+// Upcoming retorna até limit ocorrências da série posteriores a after.
+func (r *TaskRecurrence) Upcoming(after time.Time, limit int) ([]time.Time, error) {
+    rr, loc, err := r.parse()
+    if err != nil {
+        return nil, err
+    }
+    return rr.Occurrences(r.Start, after, loc, limit), nil
+}
+
+// NextOccurrence monta a Task da ocorrência seguinte a t, com os mesmos dados
+// e o próximo vencimento da série. ok é false se a Task não é recorrente ou
+// se a série terminou (COUNT/UNTIL).
+func (t *Task) NextOccurrence() (next *Task, ok bool, err error) {
+    if t.Recurrence == nil || t.DueDate == nil {
+        return nil, false, nil
+    }
+    rr, loc, err := t.Recurrence.parse()
+    if err != nil {
+        return nil, false, err
+    }
+    due, n, ok := rr.Next(t.Recurrence.Start, *t.DueDate, loc)
+    if !ok {
+        return nil, false, nil
+    }
+
+    recurrence := *t.Recurrence
+    recurrence.Occurrence = n
+    tags := make([]string, len(t.Tags))
+    copy(tags, t.Tags)
+    return &Task{
+        UserID:      t.UserID,
+        ProjectID:   t.ProjectID,
+        ParentID:    t.ParentID,
+        Title:       t.Title,
+        Description: t.Description,
+        Status:      StatusTodo,
+        Priority:    t.Priority,
+        DueDate:     &due,
+        Tags:        tags,
+        Recurrence:  &recurrence,
+    }, true, nil
+}
+
+func (r *TaskRecurrence) parse() (*RRule, *time.Location, error) {
+    rr, err := ParseRRule(r.Rule)
+    if err != nil {
+        return nil, nil, fmt.Errorf("recurrence rule %q: %w", r.Rule, err)
+    }
+    loc, err := LoadTimezone(r.Timezone)
+    if err != nil {
+        return nil, nil, fmt.Errorf("recurrence time zone: %w", err)
+    }
+    return rr, loc, nil
+}
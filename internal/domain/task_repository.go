@@ -1,17 +1,80 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
+var (
+    // ErrTaskNotFound é retornado quando a Task buscada não existe.
+    ErrTaskNotFound = NewNotFoundError("task")
+    // ErrOccurrenceExists é retornado ao criar uma ocorrência que a série já tem.
+    ErrOccurrenceExists = NewConflictError("recurrence occurrence already exists")
+    // ErrTaskVersionConflict é retornado por Update e Delete quando a Task mudou desde
+    // que foi lida (a versão no banco não é mais Task.Version).
+    ErrTaskVersionConflict = NewConflictError("task was modified concurrently; reload it and try again")
+    // ErrTaskNotRecurring é retornado ao pedir ocorrências de uma Task sem recorrência.
+    ErrTaskNotRecurring = NewConflictError("task is not recurring")
+)
+
 // TaskRepository define as operações de persistência de Task.
 type TaskRepository interface {
-    Create(task *Task) error
-    FindByID(id string) (*Task, error)
-    Update(task *Task) error
-    Delete(id string) error
-    List(filter TaskFilter) ([]*Task, error)
+    Create(ctx context.Context, task *Task) error
+    FindByID(ctx context.Context, id string) (*Task, error)
+    // Update grava a Task se ela ainda estiver em task.Version (senão devolve
+    // ErrTaskVersionConflict) e avança a versão.
+    Update(ctx context.Context, task *Task) error
+    // UpdateFields grava apenas os campos informados, com a mesma conferência
+    // de versão de Update.
+    UpdateFields(ctx context.Context, task *Task, fields []TaskField) error
+    // Delete remove a Task (e suas subtarefas) se ela ainda estiver em
+    // version (senão devolve ErrTaskVersionConflict).
+    Delete(ctx context.Context, id string, version int) error
+    List(ctx context.Context, filter TaskFilter) (*TaskPage, error)
+    // Ancestors retorna os IDs dos ancestrais de uma Task, do pai até a raiz.
+    Ancestors(ctx context.Context, id string) ([]string, error)
+    // Descendants retorna todas as subtarefas de uma Task, em qualquer nível.
+    Descendants(ctx context.Context, id string) ([]*Task, error)
 }
 
-// TaskFilter para paginação/filtros
+// TaskFilter para paginação/filtros. Campos vazios/nil não filtram; os
+// intervalos de data são semiabertos: After é inclusivo, Before exclusivo.
 type TaskFilter struct {
-    Completed *bool
-    Limit     int
-    Offset    int
+    ProjectID  string // apenas Tasks do projeto
+    ParentID   string // apenas subtarefas diretas da Task
+    Completed  *bool
+    Statuses   []TaskStatus // qualquer um dos status
+    Priorities []Priority   // qualquer uma das prioridades
+
+    DueBefore *time.Time
+    DueAfter  *time.Time
+    Overdue   bool // vencidas e ainda abertas (nem done nem cancelled)
+
+    CreatedBefore *time.Time
+    CreatedAfter  *time.Time
+    UpdatedBefore *time.Time
+    UpdatedAfter  *time.Time
+
+    Search string // busca textual em título e descrição
+
+    // Tags por nome, sem diferenciar maiúsculas
+    TagsAny  []string // tem ao menos uma
+    TagsAll  []string // tem todas
+    TagsNone []string // não tem nenhuma
+
+    Sort []TaskSort // vazio usa DefaultTaskSort
+
+    // Paginação por cursor (keyset): Cursor é o valor opaco de
+    // TaskPage.NextCursor/PrevCursor de uma página anterior.
+    Limit        int
+    Cursor       string
+    IncludeTotal bool // calcula TaskPage.Total (custa um COUNT extra)
+}
+
+// TaskPage é uma página de resultados de List.
+type TaskPage struct {
+    Tasks      []*Task
+    NextCursor string // vazio na última página
+    PrevCursor string // vazio na primeira página
+    Total      *int   // preenchido apenas com TaskFilter.IncludeTotal
 }
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskSortField é um campo pelo qual as listagens de Task podem ser ordenadas.
+type TaskSortField string
+
+const (
+    SortByCreatedAt TaskSortField = "created_at"
+    SortByUpdatedAt TaskSortField = "updated_at"
+    SortByDueDate   TaskSortField = "due_date"
+    SortByPriority  TaskSortField = "priority"
+    SortByTitle     TaskSortField = "title"
+    SortByStatus    TaskSortField = "status"
+)
+
+// TaskSortFields é a allowlist de campos aceitos em TaskFilter.Sort.
+var TaskSortFields = []TaskSortField{
+    SortByCreatedAt,
+    SortByUpdatedAt,
+    SortByDueDate,
+    SortByPriority,
+    SortByTitle,
+    SortByStatus,
+}
+
+// MaxTaskSortFields limita quantos campos uma ordenação pode combinar.
+const MaxTaskSortFields = 4
+
+// TaskSort é um critério de ordenação. Tasks sem due_date ficam sempre
+// depois das que têm, em qualquer direção; o desempate final é pelo ID.
+type TaskSort struct {
+    Field TaskSortField
+    Desc  bool
+}
+
+// DefaultTaskSort é a ordenação usada quando TaskFilter.Sort está vazio.
+var DefaultTaskSort = []TaskSort{{Field: SortByCreatedAt, Desc: true}}
+
+// Valid indica se o campo está na allowlist.
+func (f TaskSortField) Valid() bool {
+    for _, known := range TaskSortFields {
+        if f == known {
+            return true
+        }
+    }
+    return false
+}
+
+func (s TaskSort) String() string {
+    if s.Desc {
+        return "-" + string(s.Field)
+    }
+    return string(s.Field)
+}
+
+// ParseTaskSort interpreta "due_date,-priority,title": campos separados por
+// vírgula, com "-" para ordem decrescente. Campos repetidos são rejeitados.
+func ParseTaskSort(s string) ([]TaskSort, error) {
+    var sorts []TaskSort
+    seen := map[TaskSortField]bool{}
+    for _, item := range strings.Split(s, ",") {
+        item = strings.TrimSpace(item)
+        if item == "" {
+            continue
+        }
+        var sort TaskSort
+        if strings.HasPrefix(item, "-") {
+            sort.Desc = true
+            item = item[1:]
+        } else {
+            item = strings.TrimPrefix(item, "+")
+        }
+        sort.Field = TaskSortField(item)
+        if !sort.Field.Valid() {
+            return nil, fmt.Errorf("unknown sort field %q", item)
+        }
+        if seen[sort.Field] {
+            return nil, fmt.Errorf("sort field %q repeated", item)
+        }
+        seen[sort.Field] = true
+        sorts = append(sorts, sort)
+    }
+    if len(sorts) > MaxTaskSortFields {
+        return nil, fmt.Errorf("at most %d sort fields are allowed", MaxTaskSortFields)
+    }
+    return sorts, nil
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "fmt"
+
+// TaskStatus é o estado de uma Task no fluxo de trabalho.
+type TaskStatus string
+
+const (
+    StatusTodo       TaskStatus = "todo"
+    StatusInProgress TaskStatus = "in_progress"
+    StatusBlocked    TaskStatus = "blocked"
+    StatusDone       TaskStatus = "done"
+    StatusCancelled  TaskStatus = "cancelled"
+)
+
+// TaskStatuses lista todos os status válidos, na ordem do fluxo.
+var TaskStatuses = []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}
+
+// taskTransitions define para quais status cada status pode ir. Tasks
+// concluídas ou canceladas só podem ser reabertas (voltar para todo).
+var taskTransitions = map[TaskStatus][]TaskStatus{
+    StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+    StatusInProgress: {StatusTodo, StatusBlocked, StatusDone, StatusCancelled},
+    StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+    StatusDone:       {StatusTodo},
+    StatusCancelled:  {StatusTodo},
+}
+
+// ParseTaskStatus converte a string em TaskStatus validando o valor.
+func ParseTaskStatus(s string) (TaskStatus, error) {
+    st := TaskStatus(s)
+    if !st.Valid() {
+        return "", fmt.Errorf("invalid status %q", s)
+    }
+    return st, nil
+}
+
+// Valid indica se o status é conhecido.
+func (s TaskStatus) Valid() bool {
+    _, ok := taskTransitions[s]
+    return ok
+}
+
+// Closed indica se o status encerra a Task (concluída ou cancelada).
+func (s TaskStatus) Closed() bool {
+    return s == StatusDone || s == StatusCancelled
+}
+
+// CanTransitionTo indica se a mudança de s para next é permitida.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+    for _, allowed := range taskTransitions[s] {
+        if allowed == next {
+            return true
+        }
+    }
+    return false
+}
+
+// TransitionTo muda o status da Task respeitando o fluxo e mantém Completed
+// em sincronia. Repetir o status atual não é erro.
+func (t *Task) TransitionTo(next TaskStatus) error {
+    if !next.Valid() {
+        return NewValidationError(FieldError{Field: "status", Message: fmt.Sprintf("invalid status %q", next)})
+    }
+    if t.Status == next {
+        return nil
+    }
+    if t.Status != "" && !t.Status.CanTransitionTo(next) {
+        return NewConflictError("cannot change status from %s to %s", t.Status, next)
+    }
+    t.Status = next
+    t.Completed = next == StatusDone
+    return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limites aplicados aos campos de texto de uma Task.
+const (
+    TaskTitleMaxLength       = 200
+    TaskDescriptionMaxLength = 5000
+)
+
+// TaskValidationOptions ajusta as regras dependentes de contexto.
+type TaskValidationOptions struct {
+    // Now é o instante de referência para decidir se o vencimento está no passado.
+    Now time.Time
+    // AllowPastDueDate libera datas de vencimento anteriores a Now.
+    AllowPastDueDate bool
+}
+
+// Normalize remove espaços nas bordas dos campos de texto.
+func (t *Task) Normalize() {
+    t.Title = strings.TrimSpace(t.Title)
+    t.Description = strings.TrimSpace(t.Description)
+    t.Tags = normalizeTagNames(t.Tags)
+}
+
+// Validate normaliza a Task e verifica as regras de domínio, retornando um
+// erro de validação com todos os campos inválidos de uma vez.
+func (t *Task) Validate(opts TaskValidationOptions) error {
+    t.Normalize()
+
+    var fields []FieldError
+    switch {
+    case t.Title == "":
+        fields = append(fields, FieldError{Field: "title", Message: "is required"})
+    case utf8.RuneCountInString(t.Title) > TaskTitleMaxLength:
+        fields = append(fields, FieldError{Field: "title", Message: fmt.Sprintf("must be at most %d characters", TaskTitleMaxLength)})
+    }
+    if utf8.RuneCountInString(t.Description) > TaskDescriptionMaxLength {
+        fields = append(fields, FieldError{Field: "description", Message: fmt.Sprintf("must be at most %d characters", TaskDescriptionMaxLength)})
+    }
+    if !t.Status.Valid() {
+        fields = append(fields, FieldError{Field: "status", Message: "must be one of todo, in_progress, blocked, done, cancelled"})
+    }
+    if !t.Priority.Valid() {
+        fields = append(fields, FieldError{Field: "priority", Message: "must be one of low, medium, high, urgent"})
+    }
+    if len(t.Tags) > TaskMaxTags {
+        fields = append(fields, FieldError{Field: "tags", Message: fmt.Sprintf("must have at most %d tags", TaskMaxTags)})
+    }
+    for _, name := range t.Tags {
+        if msg := validateTagName(name); msg != "" {
+            fields = append(fields, FieldError{Field: "tags", Message: fmt.Sprintf("%q %s", name, msg)})
+            break
+        }
+    }
+    if t.Recurrence != nil && t.DueDate == nil {
+        fields = append(fields, FieldError{Field: "due_date", Message: "is required for recurring tasks"})
+    }
+    if t.DueDate != nil && !opts.AllowPastDueDate && t.DueDate.Before(opts.Now) {
+        fields = append(fields, FieldError{Field: "due_date", Message: "must not be in the past"})
+    }
+
+    if len(fields) > 0 {
+        return NewValidationError(fields...)
+    }
+    return nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// TxManager executa operações de vários repositórios como uma unidade.
+type TxManager interface {
+    // WithinTx roda fn em uma transação, confirmada se fn retornar nil e
+    // desfeita caso contrário. Os repositórios chamados com o contexto
+    // recebido por fn participam dela. Em conflitos de concorrência fn pode
+    // rodar de novo, então não deve ter efeitos fora do banco (os eventos
+    // vão para a outbox, gravada na mesma transação).
+    WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
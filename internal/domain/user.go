@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// DefaultTimezone é o fuso de quem não escolheu outro.
+const DefaultTimezone = "UTC"
+
+// User representa uma conta que possui tarefas.
+type User struct {
+    ID           string    `json:"id"`
+    Email        string    `json:"email"`
+    Name         string    `json:"name"`
+    PasswordHash string    `json:"-"`
+    Timezone     string    `json:"timezone" example:"America/Sao_Paulo"` // fuso IANA; padrão UTC
+    CreatedAt    time.Time `json:"created_at"`
+    UpdatedAt    time.Time `json:"updated_at"`
+}
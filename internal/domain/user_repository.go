@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+var (
+    // ErrUserNotFound é retornado quando o usuário buscado não existe.
+    ErrUserNotFound = NewNotFoundError("user")
+    // ErrEmailTaken é retornado ao registrar um e-mail já cadastrado.
+    ErrEmailTaken = NewConflictError("email already registered")
+)
+
+// UserRepository define as operações de persistência de User.
+type UserRepository interface {
+    Create(ctx context.Context, user *User) error
+    FindByID(ctx context.Context, id string) (*User, error)
+    FindByEmail(ctx context.Context, email string) (*User, error)
+    // Update grava nome e fuso do User.
+    Update(ctx context.Context, user *User) error
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limites aplicados às assinaturas de webhook.
+const (
+    WebhookURLMaxLength    = 2000
+    WebhookSecretMinLength = 16
+    WebhookSecretMaxLength = 200
+    WebhookMaxPerUser      = 20
+)
+
+// WebhookAllEvents na lista de eventos assina todos os tipos, inclusive os
+// que forem criados no futuro.
+const WebhookAllEvents EventType = "*"
+
+// Webhook é a assinatura de um usuário para receber, por POST, os eventos
+// das suas Tasks. Cada entrega é assinada com HMAC-SHA256 usando Secret.
+type Webhook struct {
+    ID        string      `json:"id"`
+    UserID    string      `json:"user_id,omitempty"`
+    URL       string      `json:"url" example:"https://example.com/hooks/tasks"`
+    Events    []EventType `json:"events" swaggertype:"array,string" example:"task.created,task.completed"`
+    Secret    string      `json:"-"` // exibido só na criação
+    Active    bool        `json:"active"`
+    CreatedAt time.Time   `json:"created_at"`
+    UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Validate normaliza e verifica URL, eventos e segredo.
+func (w *Webhook) Validate() error {
+    w.URL = strings.TrimSpace(w.URL)
+    w.Events = uniqueEventTypes(w.Events)
+
+    var fields []FieldError
+    if msg := validateWebhookURL(w.URL); msg != "" {
+        fields = append(fields, FieldError{Field: "url", Message: msg})
+    }
+    if len(w.Events) == 0 {
+        fields = append(fields, FieldError{Field: "events", Message: "must list at least one event"})
+    }
+    for _, e := range w.Events {
+        if e != WebhookAllEvents && !e.Valid() {
+            fields = append(fields, FieldError{Field: "events", Message: fmt.Sprintf("unknown event %q", e)})
+        }
+    }
+    if n := len(w.Secret); n < WebhookSecretMinLength || n > WebhookSecretMaxLength {
+        fields = append(fields, FieldError{
+            Field:   "secret",
+            Message: fmt.Sprintf("must be between %d and %d characters", WebhookSecretMinLength, WebhookSecretMaxLength),
+        })
+    }
+    if len(fields) > 0 {
+        return NewValidationError(fields...)
+    }
+    return nil
+}
+
+// Subscribes indica se a assinatura recebe eventos do tipo t.
+func (w *Webhook) Subscribes(t EventType) bool {
+    for _, e := range w.Events {
+        if e == t || e == WebhookAllEvents {
+            return true
+        }
+    }
+    return false
+}
+
+// validateWebhookURL devolve o motivo de a URL ser inválida ou "".
+func validateWebhookURL(raw string) string {
+    if raw == "" {
+        return "is required"
+    }
+    if utf8.RuneCountInString(raw) > WebhookURLMaxLength {
+        return fmt.Sprintf("must be at most %d characters", WebhookURLMaxLength)
+    }
+    u, err := url.Parse(raw)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+        return "must be an absolute http or https URL"
+    }
+    if u.User != nil {
+        return "must not contain credentials"
+    }
+    return ""
+}
+
+// uniqueEventTypes remove espaços e repetições, mantendo a ordem.
+func uniqueEventTypes(events []EventType) []EventType {
+    out := make([]EventType, 0, len(events))
+    seen := map[EventType]bool{}
+    for _, e := range events {
+        e = EventType(strings.TrimSpace(string(e)))
+        if e == "" || seen[e] {
+            continue
+        }
+        seen[e] = true
+        out = append(out, e)
+    }
+    return out
+}
+
+// DeliveryStatus é a situação de uma entrega de webhook.
+type DeliveryStatus string
+
+const (
+    DeliveryPending   DeliveryStatus = "pending"   // aguardando (nova) tentativa
+    DeliveryDelivered DeliveryStatus = "delivered" // o destino respondeu 2xx
+    DeliveryDead      DeliveryStatus = "dead"      // desistiu após o máximo de tentativas
+)
+
+// ParseDeliveryStatus converte a string em DeliveryStatus validando o valor.
+func ParseDeliveryStatus(s string) (DeliveryStatus, error) {
+    switch st := DeliveryStatus(s); st {
+    case DeliveryPending, DeliveryDelivered, DeliveryDead:
+        return st, nil
+    }
+    return "", fmt.Errorf("invalid delivery status %q", s)
+}
+
+// WebhookDelivery é o envio de um evento para uma assinatura, com o
+// resultado da última tentativa.
+type WebhookDelivery struct {
+    ID             string         `json:"id"`
+    WebhookID      string         `json:"webhook_id"`
+    EventID        string         `json:"event_id"`
+    EventType      EventType      `json:"event_type" swaggertype:"string"`
+    Status         DeliveryStatus `json:"status" swaggertype:"string" enums:"pending,delivered,dead"`
+    Attempts       int            `json:"attempts"`
+    NextAttemptAt  *time.Time     `json:"next_attempt_at,omitempty"` // apenas em pending
+    LastStatusCode *int           `json:"last_status_code,omitempty"`
+    LastError      string         `json:"last_error,omitempty"`
+    DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+    Payload        string         `json:"payload"` // corpo JSON enviado
+    CreatedAt      time.Time      `json:"created_at"`
+    UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// WebhookRequest é uma entrega reservada pelo worker, pronta para envio.
+type WebhookRequest struct {
+    DeliveryID string
+    EventID    string
+    EventType  EventType
+    URL        string
+    Secret     string
+    Payload    []byte
+    Attempt    int // 1 na primeira tentativa
+}
+
+// WebhookSender faz o POST assinado de uma entrega e devolve o status HTTP
+// da resposta (0 se não houve resposta). Erro significa que o destino não
+// confirmou o recebimento: sem resposta ou status fora de 2xx.
+type WebhookSender interface {
+    Send(ctx context.Context, req *WebhookRequest) (status int, err error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+    // ErrWebhookNotFound é retornado quando a assinatura buscada não existe.
+    ErrWebhookNotFound = NewNotFoundError("webhook")
+    // ErrWebhookDeliveryNotFound é retornado quando a entrega buscada não existe.
+    ErrWebhookDeliveryNotFound = NewNotFoundError("webhook delivery")
+)
+
+// WebhookDeliveryFilter filtra o histórico de entregas de uma assinatura.
+type WebhookDeliveryFilter struct {
+    Status DeliveryStatus // vazio não filtra
+    Limit  int
+}
+
+// WebhookRepository define as operações de persistência das assinaturas e da
+// fila de entregas. As operações de assinatura são restritas ao usuário do
+// contexto; as de entrega são usadas pelo worker, sem usuário.
+type WebhookRepository interface {
+    Create(ctx context.Context, w *Webhook) error
+    FindByID(ctx context.Context, id string) (*Webhook, error)
+    List(ctx context.Context) ([]*Webhook, error)
+    Update(ctx context.Context, w *Webhook) error
+    Delete(ctx context.Context, id string) error
+
+    // Enqueue cria uma entrega pendente com payload para cada assinatura
+    // ativa do usuário do evento que assina o seu tipo. Um evento que já
+    // tem entrega para a assinatura não é enfileirado de novo.
+    Enqueue(ctx context.Context, event *Event, payload []byte) error
+    // Deliveries retorna as entregas da assinatura, das mais recentes para as mais antigas.
+    Deliveries(ctx context.Context, webhookID string, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+    // Redeliver devolve uma entrega para a fila, para envio imediato.
+    Redeliver(ctx context.Context, webhookID, deliveryID string) (*WebhookDelivery, error)
+
+    // ClaimDue reserva até limit entregas pendentes cujo horário chegou, de
+    // assinaturas ativas, por lease, pulando as reservadas por outra réplica.
+    // Cada reserva conta como uma tentativa.
+    ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*WebhookRequest, error)
+    // MarkDelivered registra a confirmação do destino.
+    MarkDelivered(ctx context.Context, id string, status int) error
+    // MarkRetry registra a falha e agenda a próxima tentativa para at.
+    MarkRetry(ctx context.Context, id string, status int, cause string, at time.Time) error
+    // MarkDead registra a falha definitiva (dead letter).
+    MarkDead(ctx context.Context, id string, status int, cause string) error
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// issuer identifica os tokens emitidos por este serviço.
+const issuer = "gopher-tasks"
+
+// JWTService implementa domain.TokenService com JWT assinado via HS256.
+type JWTService struct {
+    secret []byte
+    expiry time.Duration
+}
+
+// NewJWTService exige um segredo não vazio para assinar os tokens.
+func NewJWTService(secret string, expiry time.Duration) (*JWTService, error) {
+    if secret == "" {
+        return nil, errors.New("jwt secret must not be empty")
+    }
+    return &JWTService{secret: []byte(secret), expiry: expiry}, nil
+}
+
+// Issue emite um token para o usuário com validade configurada.
+func (s *JWTService) Issue(userID string) (string, time.Time, error) {
+    now := time.Now()
+    expiresAt := now.Add(s.expiry)
+    claims := jwt.RegisteredClaims{
+        Issuer:    issuer,
+        Subject:   userID,
+        IssuedAt:  jwt.NewNumericDate(now),
+        ExpiresAt: jwt.NewNumericDate(expiresAt),
+    }
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+    if err != nil {
+        return "", time.Time{}, err
+    }
+    return token, expiresAt, nil
+}
+
+// Parse valida assinatura, emissor e expiração e retorna o ID do usuário.
+func (s *JWTService) Parse(token string) (string, error) {
+    var claims jwt.RegisteredClaims
+    _, err := jwt.ParseWithClaims(token, &claims,
+        func(*jwt.Token) (interface{}, error) { return s.secret, nil },
+        jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+        jwt.WithIssuer(issuer),
+        jwt.WithExpirationRequired(),
+    )
+    if err != nil || claims.Subject == "" {
+        return "", domain.ErrInvalidCredentials
+    }
+    return claims.Subject, nil
+}
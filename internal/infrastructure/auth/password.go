@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher implementa domain.PasswordHasher com bcrypt.
+type BcryptHasher struct {
+    cost int
+}
+
+// NewBcryptHasher usa o custo padrão do bcrypt quando cost <= 0.
+func NewBcryptHasher(cost int) *BcryptHasher {
+    if cost <= 0 {
+        cost = bcrypt.DefaultCost
+    }
+    return &BcryptHasher{cost: cost}
+}
+
+// Hash gera o hash da senha.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+    hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+    if err != nil {
+        return "", err
+    }
+    return string(hash), nil
+}
+
+// Compare retorna domain.ErrInvalidCredentials se a senha não confere.
+func (h *BcryptHasher) Compare(hash, password string) error {
+    err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+    if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+        return domain.ErrInvalidCredentials
+    }
+    return err
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -11,21 +13,35 @@ import (
 
 // Config agrupa todas as configurações da aplicação.
 type Config struct {
-    Server   ServerConfig
-    Database DatabaseConfig
-    Auth     AuthConfig
-    Log      LogConfig
+    Server      ServerConfig
+    Database    DatabaseConfig
+    Auth        AuthConfig
+    Pagination  PaginationConfig
+    Log         LogConfig
+    Reminders   RemindersConfig
+    Notify      NotifyConfig
+    Webhooks    WebhooksConfig
+    Stream      StreamConfig
+    Concurrency ConcurrencyConfig
+    Idempotency IdempotencyConfig
+    Batch       BatchConfig
+    Outbox      OutboxConfig
 }
 
 type ServerConfig struct {
-    Port         int           `mapstructure:"port"`
-    ReadTimeout  time.Duration `mapstructure:"readtimeout"`
-    WriteTimeout time.Duration `mapstructure:"writetimeout"`
+    Port            int           `mapstructure:"port"`
+    ReadTimeout     time.Duration `mapstructure:"readtimeout"`
+    WriteTimeout    time.Duration `mapstructure:"writetimeout"`
+    ShutdownTimeout time.Duration `mapstructure:"shutdowntimeout"` // espera máxima por requisições em andamento
 }
 
 type DatabaseConfig struct {
-    Driver string `mapstructure:"driver"`
-    DSN    string `mapstructure:"dsn"`
+    Driver         string        `mapstructure:"driver"`
+    DSN            string        `mapstructure:"dsn"`
+    QueryTimeout   time.Duration `mapstructure:"querytimeout"`     // prazo máximo por consulta
+    MigrateOnStart bool          `mapstructure:"migrate_on_start"` // aplica migrations ao subir o servidor
+    TxIsolation    string        `mapstructure:"txisolation"`      // read_committed, repeatable_read ou serializable
+    TxMaxAttempts  int           `mapstructure:"txmaxattempts"`    // tentativas de uma transação após serialização ou deadlock
 }
 
 type AuthConfig struct {
@@ -33,6 +49,93 @@ type AuthConfig struct {
     TokenExpiryMinutes int    `mapstructure:"tokenexpiryminutes"`
 }
 
+// PaginationConfig limita o tamanho das páginas das listagens.
+type PaginationConfig struct {
+    DefaultLimit int `mapstructure:"defaultlimit"` // usado quando o cliente não informa limit
+    MaxLimit     int `mapstructure:"maxlimit"`     // teto imposto pelo servidor
+}
+
+// RemindersConfig controla o scheduler que entrega os lembretes vencidos.
+// Cada réplica roda o seu; as réplicas não entregam o mesmo lembrete.
+type RemindersConfig struct {
+    Enabled      bool          `mapstructure:"enabled"`
+    PollInterval time.Duration `mapstructure:"pollinterval"` // intervalo entre varreduras
+    BatchSize    int           `mapstructure:"batchsize"`    // lembretes reservados por consulta
+    MaxAttempts  int           `mapstructure:"maxattempts"`  // tentativas antes de desistir
+    Lease        time.Duration `mapstructure:"lease"`        // reserva de um lembrete durante a entrega
+}
+
+// WebhooksConfig controla o worker que entrega os eventos às assinaturas de
+// webhook dos usuários. Como no scheduler de lembretes, cada réplica roda o seu.
+type WebhooksConfig struct {
+    Enabled      bool          `mapstructure:"enabled"`
+    PollInterval time.Duration `mapstructure:"pollinterval"` // intervalo entre varreduras
+    BatchSize    int           `mapstructure:"batchsize"`    // entregas reservadas por consulta
+    MaxAttempts  int           `mapstructure:"maxattempts"`  // tentativas antes de marcar como dead
+    Lease        time.Duration `mapstructure:"lease"`        // reserva de uma entrega durante o envio
+    Timeout      time.Duration `mapstructure:"timeout"`      // prazo de cada POST
+}
+
+// StreamConfig controla o stream ao vivo de eventos (GET /tasks/stream).
+type StreamConfig struct {
+    Heartbeat time.Duration `mapstructure:"heartbeat"` // intervalo dos comentários de keep-alive
+    LogSize   int           `mapstructure:"logsize"`   // eventos guardados para retomar com Last-Event-ID
+    Buffer    int           `mapstructure:"buffer"`    // eventos pendentes por conexão antes de desligá-la
+    Postgres  bool          `mapstructure:"postgres"`  // compartilha os eventos entre réplicas via LISTEN/NOTIFY
+}
+
+// ConcurrencyConfig controla o controle otimista de concorrência das Tasks.
+type ConcurrencyConfig struct {
+    RequireIfMatch bool `mapstructure:"requireifmatch"` // PUT, PATCH e DELETE de tasks exigem If-Match (* é aceito)
+}
+
+// IdempotencyConfig controla as respostas guardadas por Idempotency-Key e o
+// worker que apaga as expiradas.
+type IdempotencyConfig struct {
+    TTL        time.Duration `mapstructure:"ttl"`        // por quanto tempo uma chave repete a resposta
+    Lease      time.Duration `mapstructure:"lease"`      // após esse tempo uma chave em andamento é considerada abandonada
+    GCInterval time.Duration `mapstructure:"gcinterval"` // intervalo entre as coletas
+    BatchSize  int           `mapstructure:"batchsize"`  // chaves apagadas por comando
+}
+
+// BatchConfig limita as operações em lote de Tasks (POST /tasks:batch e
+// POST /tasks:bulk-update).
+type BatchConfig struct {
+    MaxOperations int `mapstructure:"maxoperations"` // operações por lote e Tasks por bulk update
+}
+
+// OutboxConfig controla o relay que publica os eventos de Task gravados na
+// outbox e a coleta dos já publicados. Cada réplica roda o seu relay; os
+// eventos de uma mesma Task são publicados por um relay de cada vez, em ordem.
+type OutboxConfig struct {
+    PollInterval time.Duration `mapstructure:"pollinterval"` // intervalo entre varreduras
+    BatchSize    int           `mapstructure:"batchsize"`    // eventos reservados por consulta e apagados por comando
+    Lease        time.Duration `mapstructure:"lease"`        // reserva dos eventos durante a publicação
+    Retention    time.Duration `mapstructure:"retention"`    // por quanto tempo os eventos publicados são mantidos
+    GCInterval   time.Duration `mapstructure:"gcinterval"`   // intervalo entre as coletas
+    File         string        `mapstructure:"file"`         // arquivo NDJSON que também recebe os eventos; vazio desativa
+}
+
+// NotifyConfig escolhe os canais de entrega dos lembretes; o log está sempre
+// ativo e webhook/SMTP entram quando configurados.
+type NotifyConfig struct {
+    Webhook WebhookConfig `mapstructure:"webhook"`
+    SMTP    SMTPConfig    `mapstructure:"smtp"`
+}
+
+type WebhookConfig struct {
+    URL     string        `mapstructure:"url"` // vazio desativa
+    Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type SMTPConfig struct {
+    Host     string `mapstructure:"host"` // vazio desativa
+    Port     int    `mapstructure:"port"`
+    Username string `mapstructure:"username"` // vazio envia sem autenticação
+    Password string `mapstructure:"password"`
+    From     string `mapstructure:"from"`
+}
+
 type LogConfig struct {
     Level  string `mapstructure:"level"`
     Format string `mapstructure:"format"`
@@ -67,6 +170,47 @@ func Load(path string) (*Config, error) {
     // 3) Defaults
     v.SetDefault("server.readtimeout", 5*time.Second)
     v.SetDefault("server.writetimeout", 10*time.Second)
+    v.SetDefault("server.shutdowntimeout", 15*time.Second)
+    v.SetDefault("database.querytimeout", 5*time.Second)
+    v.SetDefault("database.migrate_on_start", false)
+    v.SetDefault("database.txisolation", "read_committed")
+    v.SetDefault("database.txmaxattempts", 3)
+    v.SetDefault("pagination.defaultlimit", 20)
+    v.SetDefault("pagination.maxlimit", 100)
+    v.SetDefault("reminders.enabled", true)
+    v.SetDefault("reminders.pollinterval", 30*time.Second)
+    v.SetDefault("reminders.batchsize", 50)
+    v.SetDefault("reminders.maxattempts", 5)
+    v.SetDefault("reminders.lease", 2*time.Minute)
+    v.SetDefault("notify.webhook.url", "")
+    v.SetDefault("notify.webhook.timeout", 10*time.Second)
+    v.SetDefault("notify.smtp.host", "")
+    v.SetDefault("notify.smtp.port", 1025)
+    v.SetDefault("notify.smtp.username", "")
+    v.SetDefault("notify.smtp.password", "")
+    v.SetDefault("notify.smtp.from", "gopher-tasks@localhost")
+    v.SetDefault("webhooks.enabled", true)
+    v.SetDefault("webhooks.pollinterval", 10*time.Second)
+    v.SetDefault("webhooks.batchsize", 50)
+    v.SetDefault("webhooks.maxattempts", 8)
+    v.SetDefault("webhooks.lease", 2*time.Minute)
+    v.SetDefault("webhooks.timeout", 10*time.Second)
+    v.SetDefault("stream.heartbeat", 15*time.Second)
+    v.SetDefault("stream.logsize", 1000)
+    v.SetDefault("stream.buffer", 64)
+    v.SetDefault("stream.postgres", true)
+    v.SetDefault("concurrency.requireifmatch", false)
+    v.SetDefault("idempotency.ttl", 24*time.Hour)
+    v.SetDefault("idempotency.lease", time.Minute)
+    v.SetDefault("idempotency.gcinterval", time.Hour)
+    v.SetDefault("idempotency.batchsize", 1000)
+    v.SetDefault("batch.maxoperations", 500)
+    v.SetDefault("outbox.pollinterval", time.Second)
+    v.SetDefault("outbox.batchsize", 100)
+    v.SetDefault("outbox.lease", 30*time.Second)
+    v.SetDefault("outbox.retention", 24*time.Hour)
+    v.SetDefault("outbox.gcinterval", time.Hour)
+    v.SetDefault("outbox.file", "")
 
     // 4) Unmarshal em struct
     var cfg Config
@@ -75,6 +219,33 @@ func Load(path string) (*Config, error) {
         return nil, err
     }
 
-    log.WithField("config", cfg).Info("Configuration loaded successfully")
+    log.WithField("config", cfg.Redacted()).Info("Configuration loaded successfully")
     return &cfg, nil
 }
+
+// redactedValue substitui os segredos nas cópias da configuração que vão para o log.
+const redactedValue = "xxxxx"
+
+// dsnPassword casa com a senha de um DSN no formato "chave=valor" do lib/pq.
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Redacted devolve uma cópia de c sem segredos (senha do banco, segredo do
+// JWT e senha do SMTP), própria para ir para o log.
+func (c Config) Redacted() Config {
+    c.Database.DSN = redactDSN(c.Database.DSN)
+    if c.Auth.JWTSecret != "" {
+        c.Auth.JWTSecret = redactedValue
+    }
+    if c.Notify.SMTP.Password != "" {
+        c.Notify.SMTP.Password = redactedValue
+    }
+    return c
+}
+
+// redactDSN esconde a senha de um DSN em formato URL ou "chave=valor".
+func redactDSN(dsn string) string {
+    if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+        return u.Redacted()
+    }
+    return dsnPassword.ReplaceAllString(dsn, "${1}"+redactedValue)
+}
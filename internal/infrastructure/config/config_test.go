@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedactDSN(t *testing.T) {
+    tests := []struct {
+        dsn  string
+        want string
+    }{
+        {"postgres://app:s3cret@db:5432/tasks?sslmode=disable", "postgres://app:xxxxx@db:5432/tasks?sslmode=disable"},
+        {"postgres://app@db/tasks", "postgres://app@db/tasks"},
+        {"host=db user=app password=s3cret dbname=tasks", "host=db user=app password=xxxxx dbname=tasks"},
+        {"host=db password = 's3 cr\\'et' dbname=tasks", "host=db password = xxxxx dbname=tasks"},
+        {"", ""},
+    }
+    for _, tt := range tests {
+        if got := redactDSN(tt.dsn); got != tt.want {
+            t.Errorf("redactDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+        }
+    }
+}
+
+func TestConfigRedacted(t *testing.T) {
+    var cfg Config
+    cfg.Database.DSN = "postgres://app:db-secret@db/tasks"
+    cfg.Auth.JWTSecret = "jwt-secret"
+    cfg.Notify.SMTP.Username = "mailer"
+    cfg.Notify.SMTP.Password = "smtp-secret"
+
+    logged := fmt.Sprintf("%+v", cfg.Redacted())
+    for _, secret := range []string{"db-secret", "jwt-secret", "smtp-secret"} {
+        if strings.Contains(logged, secret) {
+            t.Errorf("redacted config leaks %q: %s", secret, logged)
+        }
+    }
+    if !strings.Contains(logged, "mailer") {
+        t.Errorf("redacted config lost non-secret fields: %s", logged)
+    }
+    // O original não é alterado
+    if cfg.Auth.JWTSecret != "jwt-secret" || cfg.Notify.SMTP.Password != "smtp-secret" {
+        t.Errorf("Redacted modified the config: %+v", cfg)
+    }
+}
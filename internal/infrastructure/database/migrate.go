@@ -0,0 +1,278 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// migrationLockKey identifica o advisory lock que serializa execuções
+// concorrentes do migrator (ex.: várias réplicas subindo ao mesmo tempo).
+const migrationLockKey int64 = 0x676f7068_65727461 // "gopherta"
+
+// migrationFile casa com NNNN_descricao.up.sql / NNNN_descricao.down.sql.
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration é uma versão do schema com seus scripts de subida e descida.
+type Migration struct {
+    Version int
+    Name    string
+    Up      string
+    Down    string
+}
+
+// MigrationStatus informa se uma migration já foi aplicada.
+type MigrationStatus struct {
+    Version   int
+    Name      string
+    Applied   bool
+    AppliedAt *time.Time
+}
+
+// Migrator aplica as migrations embutidas e registra o progresso em schema_migrations.
+type Migrator struct {
+    db         *sql.DB
+    migrations []Migration
+    log        logger.Logger
+}
+
+// NewMigrator lê os arquivos .sql de fsys e valida a sequência de versões.
+func NewMigrator(db *sql.DB, fsys fs.FS, log logger.Logger) (*Migrator, error) {
+    entries, err := fs.ReadDir(fsys, ".")
+    if err != nil {
+        return nil, err
+    }
+
+    byVersion := map[int]*Migration{}
+    for _, e := range entries {
+        m := migrationFile.FindStringSubmatch(e.Name())
+        if e.IsDir() || m == nil {
+            continue
+        }
+        version, _ := strconv.Atoi(m[1])
+        body, err := fs.ReadFile(fsys, e.Name())
+        if err != nil {
+            return nil, err
+        }
+
+        mig, ok := byVersion[version]
+        if !ok {
+            mig = &Migration{Version: version, Name: m[2]}
+            byVersion[version] = mig
+        } else if mig.Name != m[2] {
+            return nil, fmt.Errorf("migration %04d has conflicting names %q and %q", version, mig.Name, m[2])
+        }
+        if m[3] == "up" {
+            mig.Up = string(body)
+        } else {
+            mig.Down = string(body)
+        }
+    }
+
+    migrations := make([]Migration, 0, len(byVersion))
+    for _, mig := range byVersion {
+        if mig.Up == "" {
+            return nil, fmt.Errorf("migration %04d_%s has no up script", mig.Version, mig.Name)
+        }
+        migrations = append(migrations, *mig)
+    }
+    sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+    return &Migrator{db: db, migrations: migrations, log: log}, nil
+}
+
+// Latest retorna a maior versão disponível (0 se não houver migrations).
+func (m *Migrator) Latest() int {
+    if len(m.migrations) == 0 {
+        return 0
+    }
+    return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up aplica todas as migrations pendentes.
+func (m *Migrator) Up(ctx context.Context) error {
+    return m.Goto(ctx, m.Latest())
+}
+
+// Down desfaz as últimas `steps` migrations aplicadas.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+    return m.withLock(ctx, func(conn *sql.Conn) error {
+        applied, err := m.applied(ctx, conn)
+        if err != nil {
+            return err
+        }
+        for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+            mig := m.migrations[i]
+            if _, ok := applied[mig.Version]; !ok {
+                continue
+            }
+            if err := m.rollback(ctx, conn, mig); err != nil {
+                return err
+            }
+            steps--
+        }
+        return nil
+    })
+}
+
+// Goto sobe ou desce o schema até exatamente a versão informada.
+func (m *Migrator) Goto(ctx context.Context, version int) error {
+    if version < 0 || (version > 0 && m.find(version) == nil) {
+        return fmt.Errorf("unknown migration version %d", version)
+    }
+
+    return m.withLock(ctx, func(conn *sql.Conn) error {
+        applied, err := m.applied(ctx, conn)
+        if err != nil {
+            return err
+        }
+
+        // Primeiro desce (da mais nova para a mais antiga)...
+        for i := len(m.migrations) - 1; i >= 0; i-- {
+            mig := m.migrations[i]
+            if _, ok := applied[mig.Version]; ok && mig.Version > version {
+                if err := m.rollback(ctx, conn, mig); err != nil {
+                    return err
+                }
+            }
+        }
+        // ...depois sobe (da mais antiga para a mais nova).
+        for _, mig := range m.migrations {
+            if _, ok := applied[mig.Version]; !ok && mig.Version <= version {
+                if err := m.apply(ctx, conn, mig); err != nil {
+                    return err
+                }
+            }
+        }
+        return nil
+    })
+}
+
+// Status lista todas as migrations conhecidas e se já foram aplicadas.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+    var out []MigrationStatus
+    err := m.withLock(ctx, func(conn *sql.Conn) error {
+        applied, err := m.applied(ctx, conn)
+        if err != nil {
+            return err
+        }
+        for _, mig := range m.migrations {
+            st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+            if at, ok := applied[mig.Version]; ok {
+                at := at
+                st.Applied = true
+                st.AppliedAt = &at
+            }
+            out = append(out, st)
+        }
+        return nil
+    })
+    return out, err
+}
+
+// withLock reserva uma conexão, garante a tabela de controle e segura o
+// advisory lock enquanto fn executa. Locks de sessão exigem a mesma conexão
+// do início ao fim, por isso não usamos o pool diretamente.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+    conn, err := m.db.Conn(ctx)
+    if err != nil {
+        return err
+    }
+    defer conn.Close()
+
+    if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+        return fmt.Errorf("acquire migration lock: %w", err)
+    }
+    defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+
+    if _, err := conn.ExecContext(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version    BIGINT PRIMARY KEY,
+            name       TEXT NOT NULL,
+            applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )
+    `); err != nil {
+        return fmt.Errorf("create schema_migrations: %w", err)
+    }
+
+    return fn(conn)
+}
+
+// applied retorna as versões registradas em schema_migrations.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+    rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    applied := map[int]time.Time{}
+    for rows.Next() {
+        var version int
+        var at time.Time
+        if err := rows.Scan(&version, &at); err != nil {
+            return nil, err
+        }
+        applied[version] = at
+    }
+    return applied, rows.Err()
+}
+
+// apply executa o script up e registra a versão na mesma transação.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+    m.log.Infof("Applying migration %04d_%s", mig.Version, mig.Name)
+    return inTx(ctx, conn, func(tx *sql.Tx) error {
+        if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+            return fmt.Errorf("migration %04d_%s up: %w", mig.Version, mig.Name, err)
+        }
+        _, err := tx.ExecContext(ctx,
+            `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+            mig.Version, mig.Name,
+        )
+        return err
+    })
+}
+
+// rollback executa o script down e remove o registro da versão.
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, mig Migration) error {
+    if mig.Down == "" {
+        return fmt.Errorf("migration %04d_%s has no down script", mig.Version, mig.Name)
+    }
+    m.log.Infof("Rolling back migration %04d_%s", mig.Version, mig.Name)
+    return inTx(ctx, conn, func(tx *sql.Tx) error {
+        if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+            return fmt.Errorf("migration %04d_%s down: %w", mig.Version, mig.Name, err)
+        }
+        _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+        return err
+    })
+}
+
+func (m *Migrator) find(version int) *Migration {
+    for i := range m.migrations {
+        if m.migrations[i].Version == version {
+            return &m.migrations[i]
+        }
+    }
+    return nil
+}
+
+// inTx executa fn em uma transação na conexão informada.
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+    tx, err := conn.BeginTx(ctx, nil)
+    if err != nil {
+        return err
+    }
+    if err := fn(tx); err != nil {
+        tx.Rollback()
+        return err
+    }
+    return tx.Commit()
+}
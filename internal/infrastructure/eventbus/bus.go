@@ -0,0 +1,128 @@
+// Package eventbus distribui os eventos de Task entre os streams ao vivo:
+// Bus faz a distribuição dentro do processo e PostgresBridge a estende às
+// outras réplicas com LISTEN/NOTIFY. FileSink grava os eventos em um arquivo
+// NDJSON para consumidores fora do servidor.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Bus é um pub/sub em memória com um log circular dos eventos mais
+// recentes, usado para retomar streams interrompidos (Last-Event-ID).
+type Bus struct {
+    mu     sync.Mutex
+    log    []*domain.Event // anel com os últimos eventos
+    next   int             // posição do próximo evento no anel
+    full   bool            // o anel já deu a volta
+    subs   map[*subscriber]struct{}
+    buffer int
+    closed bool
+}
+
+type subscriber struct {
+    ch chan *domain.Event
+}
+
+// New cria um Bus que guarda até logSize eventos e reserva buffer eventos
+// por assinante.
+func New(logSize, buffer int) *Bus {
+    if logSize < 1 {
+        logSize = 1
+    }
+    return &Bus{
+        log:    make([]*domain.Event, logSize),
+        subs:   make(map[*subscriber]struct{}),
+        buffer: buffer,
+    }
+}
+
+// Publish guarda os eventos no log e os repassa aos assinantes. Um assinante
+// com o buffer cheio é desligado em vez de segurar quem publica; ele volta
+// com o último ID recebido e recupera o resto pelo log.
+func (b *Bus) Publish(ctx context.Context, events ...*domain.Event) error {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if b.closed {
+        return nil
+    }
+    for _, e := range events {
+        b.log[b.next] = e
+        b.next = (b.next + 1) % len(b.log)
+        if b.next == 0 {
+            b.full = true
+        }
+        for sub := range b.subs {
+            select {
+            case sub.ch <- e:
+            default:
+                b.remove(sub)
+            }
+        }
+    }
+    return nil
+}
+
+// Subscribe registra um assinante até ctx terminar. Com lastEventID, os
+// eventos do log posteriores a ele vêm em Missed; se o ID não está mais no
+// log, Gap avisa que o assinante deve recarregar o estado.
+func (b *Bus) Subscribe(ctx context.Context, lastEventID string) (*domain.EventSubscription, error) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if b.closed {
+        return nil, errors.New("eventbus: closed")
+    }
+
+    sub := &subscriber{ch: make(chan *domain.Event, b.buffer)}
+    b.subs[sub] = struct{}{}
+    out := &domain.EventSubscription{Events: sub.ch}
+    if lastEventID != "" {
+        out.Missed, out.Gap = b.since(lastEventID)
+    }
+
+    go func() {
+        <-ctx.Done()
+        b.mu.Lock()
+        b.remove(sub)
+        b.mu.Unlock()
+    }()
+    return out, nil
+}
+
+// Close encerra todos os assinantes; usado no shutdown para liberar as
+// conexões de stream abertas.
+func (b *Bus) Close() {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.closed = true
+    for sub := range b.subs {
+        b.remove(sub)
+    }
+}
+
+// since devolve os eventos do log depois de id, do mais antigo ao mais novo.
+func (b *Bus) since(id string) ([]*domain.Event, bool) {
+    ordered := b.log[:b.next]
+    if b.full {
+        ordered = append(append([]*domain.Event{}, b.log[b.next:]...), b.log[:b.next]...)
+    }
+    for i, e := range ordered {
+        if e.ID == id {
+            return append([]*domain.Event{}, ordered[i+1:]...), false
+        }
+    }
+    return nil, true
+}
+
+// remove desliga o assinante; chamado com mu travado.
+func (b *Bus) remove(sub *subscriber) {
+    if _, ok := b.subs[sub]; !ok {
+        return
+    }
+    delete(b.subs, sub)
+    close(sub.ch)
+}
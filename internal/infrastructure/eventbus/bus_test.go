@@ -0,0 +1,173 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func events(ids ...string) []*domain.Event {
+    out := make([]*domain.Event, len(ids))
+    for i, id := range ids {
+        out[i] = &domain.Event{ID: id, Type: domain.EventTaskUpdated}
+    }
+    return out
+}
+
+func eventIDs(events []*domain.Event) []string {
+    ids := []string{}
+    for _, e := range events {
+        ids = append(ids, e.ID)
+    }
+    return ids
+}
+
+// receive lê n eventos do canal ou falha por tempo.
+func receive(t *testing.T, ch <-chan *domain.Event, n int) []*domain.Event {
+    t.Helper()
+    var out []*domain.Event
+    for len(out) < n {
+        select {
+        case e, ok := <-ch:
+            if !ok {
+                t.Fatalf("channel closed after %d events", len(out))
+            }
+            out = append(out, e)
+        case <-time.After(time.Second):
+            t.Fatalf("got %d events, want %d", len(out), n)
+        }
+    }
+    return out
+}
+
+// closed espera o canal ser fechado, descartando o que ainda houver nele.
+func closed(ch <-chan *domain.Event) bool {
+    timeout := time.After(time.Second)
+    for {
+        select {
+        case _, ok := <-ch:
+            if !ok {
+                return true
+            }
+        case <-timeout:
+            return false
+        }
+    }
+}
+
+func TestBusReplaySinceLastEventID(t *testing.T) {
+    tests := []struct {
+        name       string
+        published  []string
+        lastID     string
+        wantMissed []string
+        wantGap    bool
+    }{
+        {name: "from the middle", published: []string{"1", "2", "3"}, lastID: "1", wantMissed: []string{"2", "3"}},
+        {name: "up to date", published: []string{"1", "2", "3"}, lastID: "3", wantMissed: []string{}},
+        {name: "after the ring wrapped", published: []string{"1", "2", "3", "4", "5", "6"}, lastID: "3", wantMissed: []string{"4", "5", "6"}},
+        {name: "oldest kept event", published: []string{"1", "2", "3", "4", "5", "6"}, lastID: "2", wantMissed: []string{"3", "4", "5", "6"}},
+        {name: "evicted from the log", published: []string{"1", "2", "3", "4", "5", "6"}, lastID: "1", wantGap: true},
+        {name: "unknown id", published: []string{"1"}, lastID: "x", wantGap: true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            bus := New(5, 10)
+            ctx := context.Background()
+            bus.Publish(ctx, events(tt.published...)...)
+
+            sub, err := bus.Subscribe(ctx, tt.lastID)
+            if err != nil {
+                t.Fatalf("Subscribe: %v", err)
+            }
+            if sub.Gap != tt.wantGap {
+                t.Errorf("Gap = %v, want %v", sub.Gap, tt.wantGap)
+            }
+            if !tt.wantGap && fmt.Sprint(eventIDs(sub.Missed)) != fmt.Sprint(tt.wantMissed) {
+                t.Errorf("Missed = %v, want %v", eventIDs(sub.Missed), tt.wantMissed)
+            }
+
+            // Depois do replay vêm os eventos novos, sem repetir nem perder nenhum
+            bus.Publish(ctx, events("next")...)
+            if got := receive(t, sub.Events, 1); got[0].ID != "next" {
+                t.Errorf("live event = %s, want next", got[0].ID)
+            }
+        })
+    }
+}
+
+func TestBusWithoutLastEventIDHasNoReplay(t *testing.T) {
+    bus := New(5, 10)
+    bus.Publish(context.Background(), events("1", "2")...)
+
+    sub, err := bus.Subscribe(context.Background(), "")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if sub.Gap || len(sub.Missed) != 0 {
+        t.Errorf("Missed = %v, Gap = %v; want nothing", eventIDs(sub.Missed), sub.Gap)
+    }
+}
+
+func TestBusDropsSlowSubscribers(t *testing.T) {
+    bus := New(10, 2)
+    ctx := context.Background()
+    slow, _ := bus.Subscribe(ctx, "")
+    fast, _ := bus.Subscribe(ctx, "")
+
+    // fast lê cada evento; slow não lê nada e estoura o buffer no terceiro
+    for _, id := range []string{"1", "2", "3", "4"} {
+        if err := bus.Publish(ctx, events(id)...); err != nil {
+            t.Fatalf("Publish: %v", err)
+        }
+        receive(t, fast.Events, 1)
+    }
+
+    if got := eventIDs(receive(t, slow.Events, 2)); fmt.Sprint(got) != "[1 2]" {
+        t.Errorf("slow subscriber got %v before being dropped, want [1 2]", got)
+    }
+    if !closed(slow.Events) {
+        t.Fatal("slow subscriber was not dropped")
+    }
+
+    // Ele volta com o último ID recebido e recupera o resto pelo log
+    again, _ := bus.Subscribe(ctx, "2")
+    if again.Gap || fmt.Sprint(eventIDs(again.Missed)) != "[3 4]" {
+        t.Errorf("resubscribe: Missed = %v, Gap = %v", eventIDs(again.Missed), again.Gap)
+    }
+}
+
+func TestBusUnsubscribesOnContextDone(t *testing.T) {
+    bus := New(10, 2)
+    ctx, cancel := context.WithCancel(context.Background())
+    sub, _ := bus.Subscribe(ctx, "")
+    cancel()
+
+    if !closed(sub.Events) {
+        t.Fatal("subscription still open after its context ended")
+    }
+    // Publicar depois do cancelamento não entra em pânico com o canal fechado
+    if err := bus.Publish(context.Background(), events("1")...); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func TestBusClose(t *testing.T) {
+    bus := New(10, 2)
+    sub, _ := bus.Subscribe(context.Background(), "")
+    bus.Close()
+
+    if !closed(sub.Events) {
+        t.Error("Close did not end the subscription")
+    }
+    if _, err := bus.Subscribe(context.Background(), ""); err == nil {
+        t.Error("Subscribe after Close succeeded")
+    }
+    if err := bus.Publish(context.Background(), events("1")...); err != nil {
+        t.Errorf("Publish after Close = %v", err)
+    }
+}
@@ -0,0 +1,54 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// FileSink grava cada evento como uma linha JSON (NDJSON) no fim de um
+// arquivo, para ser consumido por ferramentas externas (coletores de log,
+// jq, importações).
+type FileSink struct {
+    mu   sync.Mutex
+    file *os.File
+}
+
+// NewFileSink abre o arquivo para acréscimo, criando-o se não existir.
+func NewFileSink(path string) (*FileSink, error) {
+    f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+    if err != nil {
+        return nil, err
+    }
+    return &FileSink{file: f}, nil
+}
+
+// Publish acrescenta as linhas em uma única escrita e só retorna depois do
+// fsync, para que um evento dado como publicado já esteja em disco.
+func (s *FileSink) Publish(ctx context.Context, events ...*domain.Event) error {
+    var buf bytes.Buffer
+    enc := json.NewEncoder(&buf)
+    for _, e := range events {
+        if err := enc.Encode(e); err != nil {
+            return err
+        }
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if _, err := s.file.Write(buf.Bytes()); err != nil {
+        return err
+    }
+    return s.file.Sync()
+}
+
+// Close fecha o arquivo; usado no shutdown, depois que o relay parou.
+func (s *FileSink) Close() error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.file.Close()
+}
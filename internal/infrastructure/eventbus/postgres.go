@@ -0,0 +1,133 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// notifyChannel é o canal do LISTEN/NOTIFY compartilhado pelas réplicas.
+const notifyChannel = "gopher_tasks_events"
+
+// notifyMaxPayload fica abaixo do limite de 8000 bytes do NOTIFY.
+const notifyMaxPayload = 7900
+
+var errPayloadTooLarge = errors.New("eventbus: event too large for NOTIFY")
+
+// notification é o payload do NOTIFY; Origin identifica a réplica que
+// publicou, que já entregou o evento aos seus assinantes.
+type notification struct {
+    Origin string        `json:"origin"`
+    Event  *domain.Event `json:"event"`
+}
+
+// PostgresBridge leva os eventos publicados nesta réplica às demais via
+// NOTIFY e entrega ao Bus local os eventos que elas publicam.
+type PostgresBridge struct {
+    db     *sql.DB
+    dsn    string
+    origin string
+    log    logger.Logger
+}
+
+// NewPostgresBridge recebe o pool usado para o NOTIFY e o DSN da conexão
+// dedicada ao LISTEN.
+func NewPostgresBridge(db *sql.DB, dsn string, log logger.Logger) *PostgresBridge {
+    return &PostgresBridge{db: db, dsn: dsn, origin: uuid.NewString(), log: log}
+}
+
+// Publish envia os eventos às outras réplicas. Falhas são apenas
+// registradas: a mudança já foi gravada e os streams são best effort
+// (um cliente que perder eventos recarrega o estado ao receber um reset).
+func (p *PostgresBridge) Publish(ctx context.Context, events ...*domain.Event) error {
+    for _, e := range events {
+        payload, err := notifyPayload(p.origin, e)
+        if err != nil {
+            p.log.WithField("event_id", e.ID).WithField("error", err).Warn("Event not shared with other replicas")
+            continue
+        }
+        if _, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, payload); err != nil {
+            p.log.WithField("event_id", e.ID).WithField("error", err).Error("Failed to notify event")
+        }
+    }
+    return nil
+}
+
+// Listen escuta o canal e publica em sink os eventos das outras réplicas
+// até ctx terminar; a conexão é refeita sozinha se cair.
+func (p *PostgresBridge) Listen(ctx context.Context, sink domain.EventPublisher) {
+    listener := pq.NewListener(p.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+        if err != nil {
+            p.log.WithField("error", err).Warn("Event listener connection problem")
+        }
+    })
+    defer listener.Close()
+    if err := listener.Listen(notifyChannel); err != nil {
+        p.log.WithField("error", err).Error("Failed to listen for events")
+        return
+    }
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case n := <-listener.Notify:
+            // nil após uma reconexão: notificações enviadas no intervalo se perderam
+            if n == nil {
+                p.log.Warn("Event listener reconnected; events from other replicas may have been missed")
+                continue
+            }
+            var msg notification
+            if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil || msg.Event == nil {
+                p.log.WithField("error", err).Warn("Ignoring malformed event notification")
+                continue
+            }
+            if msg.Origin == p.origin {
+                continue
+            }
+            if err := sink.Publish(ctx, msg.Event); err != nil {
+                p.log.WithField("error", err).Error("Failed to dispatch event from another replica")
+            }
+        case <-time.After(90 * time.Second):
+            go listener.Ping()
+        }
+    }
+}
+
+// notifyPayload serializa o evento; se passar do limite do NOTIFY, as
+// descrições são omitidas (buscas textuais nas outras réplicas passam a
+// considerar apenas o título).
+func notifyPayload(origin string, e *domain.Event) (string, error) {
+    data, err := json.Marshal(notification{Origin: origin, Event: e})
+    if err != nil || len(data) <= notifyMaxPayload {
+        return string(data), err
+    }
+
+    trimmed := *e
+    trimmed.Task = withoutDescription(e.Task)
+    trimmed.Previous = withoutDescription(e.Previous)
+    data, err = json.Marshal(notification{Origin: origin, Event: &trimmed})
+    if err != nil {
+        return "", err
+    }
+    if len(data) > notifyMaxPayload {
+        return "", errPayloadTooLarge
+    }
+    return string(data), nil
+}
+
+func withoutDescription(t *domain.Task) *domain.Task {
+    if t == nil {
+        return nil
+    }
+    stripped := *t
+    stripped.Description = ""
+    return &stripped
+}
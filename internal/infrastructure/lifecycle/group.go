@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// Group controla os workers de background ligados ao ciclo de vida do
+// servidor: todos recebem o mesmo contexto, cancelado em Stop.
+type Group struct {
+    ctx    context.Context
+    cancel context.CancelFunc
+    wg     sync.WaitGroup
+    log    logger.Logger
+}
+
+// NewGroup cria um grupo vazio de workers.
+func NewGroup(log logger.Logger) *Group {
+    ctx, cancel := context.WithCancel(context.Background())
+    return &Group{ctx: ctx, cancel: cancel, log: log}
+}
+
+// Go inicia fn em uma goroutine; fn deve retornar quando ctx for cancelado.
+func (g *Group) Go(name string, fn func(ctx context.Context)) {
+    g.wg.Add(1)
+    go func() {
+        defer g.wg.Done()
+        g.log.WithField("worker", name).Info("Worker started")
+        fn(g.ctx)
+        g.log.WithField("worker", name).Info("Worker stopped")
+    }()
+}
+
+// Stop cancela os workers e espera que terminem, até o prazo de ctx.
+func (g *Group) Stop(ctx context.Context) error {
+    g.cancel()
+
+    done := make(chan struct{})
+    go func() {
+        g.wg.Wait()
+        close(done)
+    }()
+
+    select {
+    case <-done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
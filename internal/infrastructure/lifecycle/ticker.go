@@ -0,0 +1,24 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+// Every transforma fn em um worker para Group.Go que a executa logo ao
+// iniciar e depois a cada interval, até ctx ser cancelado. Execuções não se
+// sobrepõem: se fn demorar mais que interval, a próxima começa ao fim dela.
+func Every(interval time.Duration, fn func(ctx context.Context)) func(ctx context.Context) {
+    return func(ctx context.Context) {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for {
+            fn(ctx)
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+            }
+        }
+    }
+}
@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+)
+
+// LogNotifier apenas registra o lembrete no log do servidor; útil em
+// desenvolvimento e como trilha de auditoria ao lado dos outros canais.
+type LogNotifier struct {
+    log logger.Logger
+}
+
+// NewLogNotifier injeta o logger em que os lembretes são registrados.
+func NewLogNotifier(log logger.Logger) *LogNotifier {
+    return &LogNotifier{log: log}
+}
+
+// Notify nunca falha.
+func (l *LogNotifier) Notify(_ context.Context, n *domain.ReminderNotification) error {
+    l.log.
+        WithField("reminder_id", n.ReminderID).
+        WithField("task_id", n.TaskID).
+        WithField("user_id", n.UserID).
+        WithField("overdue", n.Overdue).
+        Info(body(n))
+    return nil
+}
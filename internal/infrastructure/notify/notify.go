@@ -0,0 +1,57 @@
+// Package notify implementa os canais de entrega de lembretes (domain.Notifier).
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Multi entrega a notificação em todos os canais. Se algum falhar o erro é
+// devolvido e o lembrete é tentado de novo em todos, então um canal que já
+// entregou pode repetir a mensagem.
+type Multi []domain.Notifier
+
+// Notify chama todos os canais, mesmo que algum falhe.
+func (m Multi) Notify(ctx context.Context, n *domain.ReminderNotification) error {
+    var errs []error
+    for _, notifier := range m {
+        if err := notifier.Notify(ctx, n); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// subject é o título curto da notificação.
+func subject(n *domain.ReminderNotification) string {
+    if n.Overdue {
+        return "Overdue: " + n.TaskTitle
+    }
+    return "Reminder: " + n.TaskTitle
+}
+
+// body descreve o lembrete com o vencimento no fuso do usuário.
+func body(n *domain.ReminderNotification) string {
+    if n.DueDate == nil {
+        return fmt.Sprintf("Reminder for task %q.", n.TaskTitle)
+    }
+    due := localTime(*n.DueDate, n.Timezone)
+    if n.Overdue {
+        return fmt.Sprintf("Task %q was due %s and is still open.", n.TaskTitle, due)
+    }
+    return fmt.Sprintf("Task %q is due %s.", n.TaskTitle, due)
+}
+
+// localTime formata t no fuso do usuário, caindo para UTC se ele for inválido.
+func localTime(t time.Time, timezone string) string {
+    if loc, err := domain.LoadTimezone(timezone); err == nil {
+        t = t.In(loc)
+    } else {
+        t = t.UTC()
+    }
+    return t.Format("Mon, 02 Jan 2006 15:04 MST")
+}
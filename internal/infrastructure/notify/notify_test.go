@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+type stubNotifier struct {
+    err   error
+    calls int
+}
+
+func (s *stubNotifier) Notify(context.Context, *domain.ReminderNotification) error {
+    s.calls++
+    return s.err
+}
+
+func TestMultiCallsEveryChannel(t *testing.T) {
+    down := errors.New("down")
+    first, failing, last := &stubNotifier{}, &stubNotifier{err: down}, &stubNotifier{}
+
+    err := Multi{first, failing, last}.Notify(context.Background(), &domain.ReminderNotification{})
+    if !errors.Is(err, down) {
+        t.Errorf("Notify = %v, want the failing channel's error", err)
+    }
+    if first.calls != 1 || failing.calls != 1 || last.calls != 1 {
+        t.Errorf("calls = %d, %d, %d; want every channel once", first.calls, failing.calls, last.calls)
+    }
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// SMTPNotifier envia o lembrete por e-mail para o endereço do usuário. Sem
+// usuário e senha a conexão não é autenticada, o que basta para servidores
+// de teste locais como o Mailpit (veja docker-compose.yml).
+type SMTPNotifier struct {
+    addr string
+    from string
+    auth smtp.Auth
+}
+
+// NewSMTPNotifier recebe o servidor, o remetente e, opcionalmente, as credenciais.
+func NewSMTPNotifier(host string, port int, username, password, from string) *SMTPNotifier {
+    s := &SMTPNotifier{addr: net.JoinHostPort(host, strconv.Itoa(port)), from: from}
+    if username != "" {
+        s.auth = smtp.PlainAuth("", username, password, host)
+    }
+    return s
+}
+
+// Notify envia o e-mail. smtp.SendMail não aceita contexto; a chamada só é
+// feita se ctx ainda estiver ativo.
+func (s *SMTPNotifier) Notify(ctx context.Context, n *domain.ReminderNotification) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    if err := smtp.SendMail(s.addr, s.auth, s.from, []string{n.UserEmail}, s.message(n)); err != nil {
+        return fmt.Errorf("smtp: %w", err)
+    }
+    return nil
+}
+
+// message monta o e-mail em texto puro (RFC 5322).
+func (s *SMTPNotifier) message(n *domain.ReminderNotification) []byte {
+    var b bytes.Buffer
+    fmt.Fprintf(&b, "From: %s\r\n", s.from)
+    fmt.Fprintf(&b, "To: %s\r\n", n.UserEmail)
+    fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject(n)))
+    fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+    fmt.Fprintf(&b, "Message-ID: <reminder-%s-%d@gopher-tasks>\r\n", n.ReminderID, n.Attempt)
+    b.WriteString("MIME-Version: 1.0\r\n")
+    b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+    b.WriteString("\r\n")
+    if n.UserName != "" {
+        fmt.Fprintf(&b, "Hi %s,\r\n\r\n", n.UserName)
+    }
+    b.WriteString(body(n) + "\r\n")
+    return b.Bytes()
+}
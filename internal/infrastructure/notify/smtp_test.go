@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// smtpMessage é o envelope e o conteúdo de uma mensagem recebida.
+type smtpMessage struct {
+    from, to, data string
+}
+
+// smtpServer é um servidor SMTP mínimo que aceita uma mensagem e a devolve em msgs.
+func smtpServer(t *testing.T) (host string, port int, msgs <-chan smtpMessage) {
+    t.Helper()
+    ln, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { ln.Close() })
+    out := make(chan smtpMessage, 1)
+
+    go func() {
+        c, err := ln.Accept()
+        if err != nil {
+            return
+        }
+        defer c.Close()
+        r := bufio.NewReader(c)
+        reply := func(s string) { c.Write([]byte(s + "\r\n")) }
+
+        var msg smtpMessage
+        reply("220 test ESMTP")
+        for {
+            line, err := r.ReadString('\n')
+            if err != nil {
+                return
+            }
+            cmd := strings.TrimSpace(line)
+            switch upper := strings.ToUpper(cmd); {
+            case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+                reply("250 test")
+            case strings.HasPrefix(upper, "MAIL FROM:"):
+                msg.from = cmd[len("MAIL FROM:"):]
+                reply("250 ok")
+            case strings.HasPrefix(upper, "RCPT TO:"):
+                msg.to = cmd[len("RCPT TO:"):]
+                reply("250 ok")
+            case upper == "DATA":
+                reply("354 go ahead")
+                var b strings.Builder
+                for {
+                    l, err := r.ReadString('\n')
+                    if err != nil || l == ".\r\n" {
+                        break
+                    }
+                    b.WriteString(l)
+                }
+                msg.data = b.String()
+                reply("250 queued")
+            case upper == "QUIT":
+                reply("221 bye")
+                out <- msg
+                return
+            default:
+                reply("502 not implemented")
+            }
+        }
+    }()
+
+    addr := ln.Addr().(*net.TCPAddr)
+    return addr.IP.String(), addr.Port, out
+}
+
+func TestSMTPNotifier(t *testing.T) {
+    host, port, msgs := smtpServer(t)
+    due := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    n := &domain.ReminderNotification{
+        ReminderID: "r1",
+        TaskTitle:  "Pagar o aluguel",
+        DueDate:    &due,
+        UserEmail:  "ana@example.com",
+        UserName:   "Ana",
+        Timezone:   "UTC",
+        Attempt:    2,
+    }
+
+    if err := NewSMTPNotifier(host, port, "", "", "tasks@example.com").Notify(context.Background(), n); err != nil {
+        t.Fatalf("Notify: %v", err)
+    }
+
+    var msg smtpMessage
+    select {
+    case msg = <-msgs:
+    case <-time.After(time.Second):
+        t.Fatal("no message received")
+    }
+    if msg.from != "<tasks@example.com>" || msg.to != "<ana@example.com>" {
+        t.Errorf("envelope = %s -> %s", msg.from, msg.to)
+    }
+    for _, want := range []string{
+        "To: ana@example.com\r\n",
+        "Subject: Reminder: Pagar o aluguel\r\n",
+        "Message-ID: <reminder-r1-2@gopher-tasks>\r\n",
+        "Hi Ana,\r\n",
+        `Task "Pagar o aluguel" is due Mon, 02 Mar 2026 12:00 UTC.`,
+    } {
+        if !strings.Contains(msg.data, want) {
+            t.Errorf("message lacks %q:\n%s", want, msg.data)
+        }
+    }
+}
+
+func TestSMTPNotifierFailures(t *testing.T) {
+    // Nada escutando na porta: a entrega falha e o lembrete é tentado de novo
+    ln, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    port := ln.Addr().(*net.TCPAddr).Port
+    ln.Close()
+    n := &domain.ReminderNotification{ReminderID: "r1", UserEmail: "ana@example.com"}
+
+    if err := NewSMTPNotifier("127.0.0.1", port, "", "", "tasks@example.com").Notify(context.Background(), n); err == nil {
+        t.Error("Notify to a closed port succeeded")
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if err := NewSMTPNotifier("127.0.0.1", port, "", "", "tasks@example.com").Notify(ctx, n); err != context.Canceled {
+        t.Errorf("Notify with canceled context = %v", err)
+    }
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// webhookPayload é o corpo JSON enviado ao webhook.
+type webhookPayload struct {
+    Event   string `json:"event"` // reminder.due ou reminder.overdue
+    Subject string `json:"subject"`
+    Message string `json:"message"`
+    *domain.ReminderNotification
+}
+
+// WebhookNotifier envia cada lembrete como um POST JSON para uma URL fixa.
+// Qualquer resposta fora de 2xx conta como falha e o lembrete é tentado de novo.
+type WebhookNotifier struct {
+    url    string
+    client *http.Client
+}
+
+// NewWebhookNotifier recebe a URL de destino e o prazo de cada requisição.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+    return &WebhookNotifier{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Notify faz o POST e espera uma resposta 2xx.
+func (w *WebhookNotifier) Notify(ctx context.Context, n *domain.ReminderNotification) error {
+    event := "reminder.due"
+    if n.Overdue {
+        event = "reminder.overdue"
+    }
+    payload, err := json.Marshal(webhookPayload{
+        Event:                event,
+        Subject:              subject(n),
+        Message:              body(n),
+        ReminderNotification: n,
+    })
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("User-Agent", "gopher-tasks")
+
+    resp, err := w.client.Do(req)
+    if err != nil {
+        return fmt.Errorf("webhook: %w", err)
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return fmt.Errorf("webhook: unexpected status %s", resp.Status)
+    }
+    return nil
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+    due := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    var got map[string]interface{}
+    var contentType string
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        contentType = r.Header.Get("Content-Type")
+        if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+            t.Errorf("decode: %v", err)
+        }
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer srv.Close()
+
+    n := &domain.ReminderNotification{ReminderID: "r1", TaskID: "t1", TaskTitle: "Pay rent", DueDate: &due, Overdue: true, Timezone: "America/Sao_Paulo", Attempt: 1}
+    if err := NewWebhookNotifier(srv.URL, time.Second).Notify(context.Background(), n); err != nil {
+        t.Fatalf("Notify: %v", err)
+    }
+
+    if contentType != "application/json" {
+        t.Errorf("Content-Type = %q", contentType)
+    }
+    want := map[string]interface{}{
+        "event":       "reminder.overdue",
+        "subject":     "Overdue: Pay rent",
+        "message":     `Task "Pay rent" was due Mon, 02 Mar 2026 09:00 -03 and is still open.`,
+        "reminder_id": "r1",
+        "task_id":     "t1",
+    }
+    for k, v := range want {
+        if got[k] != v {
+            t.Errorf("payload[%s] = %v, want %v", k, got[k], v)
+        }
+    }
+}
+
+func TestWebhookNotifierFailures(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/slow" {
+            time.Sleep(200 * time.Millisecond)
+        }
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer srv.Close()
+    n := &domain.ReminderNotification{ReminderID: "r1", TaskTitle: "x"}
+
+    if err := NewWebhookNotifier(srv.URL, time.Second).Notify(context.Background(), n); err == nil {
+        t.Error("non-2xx response did not fail")
+    }
+    if err := NewWebhookNotifier(srv.URL+"/slow", 50*time.Millisecond).Notify(context.Background(), n); err == nil {
+        t.Error("timeout did not fail")
+    }
+}
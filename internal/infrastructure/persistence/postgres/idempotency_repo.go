@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// IdempotencyRepo usa sempre o pool, nunca a transação do contexto: a chave
+// precisa valer mesmo que a transação da requisição seja desfeita.
+type IdempotencyRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewIdempotencyRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewIdempotencyRepo(db *sql.DB, queryTimeout time.Duration) *IdempotencyRepo {
+    return &IdempotencyRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Reserve insere a chave em andamento. Uma chave expirada ainda não coletada,
+// ou abandonada em andamento há mais que lease, é reaproveitada; se a chave
+// está em uso, o registro atual é devolvido. Cada reserva recebe um Token
+// novo, que Complete e Release conferem.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, lease time.Duration) (*domain.IdempotencyRecord, bool, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    rec.CreatedAt = time.Now()
+    rec.Token = uuid.New().String()
+    query := `
+        INSERT INTO idempotency_keys (user_id, key, token, method, path, fingerprint, created_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (user_id, key) DO UPDATE
+        SET token = EXCLUDED.token, method = EXCLUDED.method, path = EXCLUDED.path, fingerprint = EXCLUDED.fingerprint,
+            status = NULL, headers = NULL, body = NULL,
+            created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+        WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+           OR (idempotency_keys.status IS NULL AND idempotency_keys.created_at <= $9)
+    `
+    res, err := r.db.ExecContext(ctx, query,
+        rec.UserID, rec.Key, rec.Token, rec.Method, rec.Path, rec.Fingerprint, rec.CreatedAt, rec.ExpiresAt, rec.CreatedAt.Add(-lease))
+    if err != nil {
+        return nil, false, ctxError(ctx, err)
+    }
+    if n, err := res.RowsAffected(); err != nil {
+        return nil, false, err
+    } else if n == 1 {
+        return rec, true, nil
+    }
+
+    existing, err := r.find(ctx, rec.UserID, rec.Key)
+    if err != nil {
+        return nil, false, ctxError(ctx, err)
+    }
+    return existing, false, nil
+}
+
+func (r *IdempotencyRepo) find(ctx context.Context, userID, key string) (*domain.IdempotencyRecord, error) {
+    query := `
+        SELECT user_id, key, token, method, path, fingerprint, status, headers, body, created_at, expires_at
+        FROM idempotency_keys
+        WHERE user_id = $1 AND key = $2
+    `
+    rec := &domain.IdempotencyRecord{}
+    var status sql.NullInt64
+    var header []byte
+    err := r.db.QueryRowContext(ctx, query, userID, key).Scan(
+        &rec.UserID,
+        &rec.Key,
+        &rec.Token,
+        &rec.Method,
+        &rec.Path,
+        &rec.Fingerprint,
+        &status,
+        &header,
+        &rec.Body,
+        &rec.CreatedAt,
+        &rec.ExpiresAt,
+    )
+    // A reserva pode ter sido liberada entre o INSERT e a leitura
+    if errors.Is(err, sql.ErrNoRows) {
+        return nil, errors.New("idempotency key released concurrently")
+    }
+    if err != nil {
+        return nil, err
+    }
+    rec.Status = int(status.Int64)
+    if len(header) > 0 {
+        rec.Header = map[string][]string{}
+        if err := json.Unmarshal(header, &rec.Header); err != nil {
+            return nil, err
+        }
+    }
+    return rec, nil
+}
+
+// Complete grava a resposta da reserva. O token impede que uma requisição
+// lenta, cuja reserva venceu e foi retomada, sobrescreva a da nova dona.
+func (r *IdempotencyRepo) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    header, err := json.Marshal(rec.Header)
+    if err != nil {
+        return err
+    }
+    query := `
+        UPDATE idempotency_keys
+        SET status = $4, headers = $5, body = $6
+        WHERE user_id = $1 AND key = $2 AND token = $3 AND status IS NULL
+    `
+    res, err := r.db.ExecContext(ctx, query, rec.UserID, rec.Key, rec.Token, rec.Status, header, rec.Body)
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    n, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if n == 0 {
+        return domain.ErrIdempotencyKeyLost
+    }
+    return nil
+}
+
+// Release apaga a reserva ainda em andamento, se ainda for de rec.
+func (r *IdempotencyRepo) Release(ctx context.Context, rec *domain.IdempotencyRecord) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND token = $3 AND status IS NULL`
+    _, err := r.db.ExecContext(ctx, query, rec.UserID, rec.Key, rec.Token)
+    return ctxError(ctx, err)
+}
+
+// DeleteExpired apaga um lote de chaves expiradas.
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time, limit int) (int, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        DELETE FROM idempotency_keys
+        WHERE ctid IN (
+            SELECT ctid FROM idempotency_keys WHERE expires_at <= $1 LIMIT $2
+        )
+    `
+    res, err := r.db.ExecContext(ctx, query, now, limit)
+    if err != nil {
+        return 0, ctxError(ctx, err)
+    }
+    n, err := res.RowsAffected()
+    return int(n), err
+}
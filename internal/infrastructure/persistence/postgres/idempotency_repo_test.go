@@ -0,0 +1,60 @@
+//go:build integration
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestIdempotencyRepoCompleteChecksTheReservation(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    userID, _ := domain.UserIDFromContext(ctx)
+    repo := NewIdempotencyRepo(db, 0)
+    record := func() *domain.IdempotencyRecord {
+        return &domain.IdempotencyRecord{
+            UserID: userID, Key: "k-" + uuid.NewString()[:8], Method: "POST", Path: "/tasks",
+            Fingerprint: "f", ExpiresAt: time.Now().Add(time.Hour),
+        }
+    }
+
+    slow := record()
+    if _, ok, err := repo.Reserve(ctx, slow, time.Hour); err != nil || !ok {
+        t.Fatalf("Reserve() = %v, %v", ok, err)
+    }
+    // O lease venceu: outra requisição retoma a chave com um token novo.
+    retry := *slow
+    if _, ok, err := repo.Reserve(ctx, &retry, 0); err != nil || !ok {
+        t.Fatalf("takeover Reserve() = %v, %v", ok, err)
+    }
+    if retry.Token == slow.Token {
+        t.Fatal("takeover kept the token")
+    }
+
+    retry.Status, retry.Body = 201, []byte("new")
+    if err := repo.Complete(ctx, &retry); err != nil {
+        t.Fatalf("Complete(new owner): %v", err)
+    }
+    // A requisição lenta termina depois e não sobrescreve nem apaga a resposta.
+    slow.Status, slow.Body = 200, []byte("old")
+    if err := repo.Complete(ctx, slow); !errors.Is(err, domain.ErrIdempotencyKeyLost) {
+        t.Fatalf("Complete(old owner) = %v, want ErrIdempotencyKeyLost", err)
+    }
+    if err := repo.Release(ctx, slow); err != nil {
+        t.Fatalf("Release(old owner): %v", err)
+    }
+
+    again := *slow
+    got, reserved, err := repo.Reserve(ctx, &again, time.Hour)
+    if err != nil || reserved {
+        t.Fatalf("Reserve() = %v, %v; want the stored response", reserved, err)
+    }
+    if got.Status != 201 || string(got.Body) != "new" || got.Token != retry.Token {
+        t.Errorf("stored = %d %q (token %s), want the new owner's response", got.Status, got.Body, got.Token)
+    }
+}
@@ -0,0 +1,68 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/database"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/logger"
+	"github.com/rubenfabio/gopher-tasks/scripts/migrations"
+)
+
+// testDSNEnv aponta para um Postgres descartável usado pelos testes de integração.
+const testDSNEnv = "APP_TEST_DATABASE_DSN"
+
+// testDB abre o banco de APP_TEST_DATABASE_DSN com o schema migrado; sem a
+// variável o teste é pulado.
+func testDB(t *testing.T) *sql.DB {
+    t.Helper()
+    dsn := os.Getenv(testDSNEnv)
+    if dsn == "" {
+        t.Skipf("%s not set", testDSNEnv)
+    }
+    db, err := database.Open("postgres", dsn, 10, 5, time.Minute)
+    if err != nil {
+        t.Fatalf("open: %v", err)
+    }
+    t.Cleanup(func() { db.Close() })
+
+    m, err := database.NewMigrator(db, migrations.FS, logger.New("error", "text", io.Discard))
+    if err != nil {
+        t.Fatalf("migrations: %v", err)
+    }
+    if err := m.Up(context.Background()); err != nil {
+        t.Fatalf("migrate: %v", err)
+    }
+    return db
+}
+
+// testUser cria um usuário novo (apagado ao fim do teste, com seus dados) e
+// devolve um contexto autenticado como ele.
+func testUser(t *testing.T, db *sql.DB) context.Context {
+    t.Helper()
+    u := &domain.User{Email: uuid.NewString() + "@example.com", Name: "test", PasswordHash: "x"}
+    if err := NewUserRepo(db, 0).Create(context.Background(), u); err != nil {
+        t.Fatalf("create user: %v", err)
+    }
+    t.Cleanup(func() { db.Exec(`DELETE FROM users WHERE id = $1`, u.ID) })
+    return domain.WithUserID(context.Background(), u.ID)
+}
+
+// testTask cria uma Task em todo do usuário do contexto.
+func testTask(t *testing.T, ctx context.Context, db *sql.DB, title string) *domain.Task {
+    t.Helper()
+    userID, _ := domain.UserIDFromContext(ctx)
+    task := &domain.Task{UserID: userID, Title: title, Status: domain.StatusTodo, Priority: domain.PriorityMedium}
+    if err := NewTaskRepo(db, 0).Create(ctx, task); err != nil {
+        t.Fatalf("create task: %v", err)
+    }
+    return task
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+type OutboxRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewOutboxRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewOutboxRepo(db *sql.DB, queryTimeout time.Duration) *OutboxRepo {
+    return &OutboxRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Publish grava os eventos na outbox. Dentro de TxManager.WithinTx, a
+// gravação entra na transação da mudança: ou ambas são confirmadas, ou nenhuma.
+func (r *OutboxRepo) Publish(ctx context.Context, events ...*domain.Event) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO outbox (task_id, event_id, event_type, payload, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+    for _, e := range events {
+        if e.Task == nil {
+            return fmt.Errorf("outbox: event %s has no task", e.ID)
+        }
+        payload, err := json.Marshal(e)
+        if err != nil {
+            return err
+        }
+        _, err = conn(ctx, r.db).ExecContext(ctx, query, e.Task.ID, e.ID, string(e.Type), string(payload), e.OccurredAt)
+        if err != nil {
+            return ctxError(ctx, err)
+        }
+    }
+    return nil
+}
+
+// ClaimPending reserva os eventos pendentes mais antigos das Tasks livres.
+// SKIP LOCKED não basta aqui: a réplica que reserva precisa enxergar as
+// reservas das outras para não pegar uma Task já em publicação, então as
+// reservas são serializadas por um advisory lock (a consulta é curta).
+func (r *OutboxRepo) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    // heads é o evento pendente mais antigo de cada Task; dos eventos das
+    // Tasks prontas saem os limit mais antigos, que formam um prefixo da fila
+    // de cada Task (limit eventos vêm de no máximo limit Tasks)
+    query := `
+        WITH heads AS (
+            SELECT DISTINCT ON (task_id) task_id, id, next_attempt_at, locked_until
+            FROM outbox
+            WHERE published_at IS NULL
+            ORDER BY task_id, id
+        ), ready AS (
+            SELECT task_id
+            FROM heads
+            WHERE next_attempt_at <= NOW() AND (locked_until IS NULL OR locked_until <= NOW())
+            ORDER BY id
+            LIMIT $1
+        ), picked AS (
+            SELECT o.id
+            FROM outbox o
+            JOIN ready ON ready.task_id = o.task_id
+            WHERE o.published_at IS NULL
+            ORDER BY o.id
+            LIMIT $1
+        ), claimed AS (
+            UPDATE outbox o
+            SET locked_until = NOW() + make_interval(secs => $2)
+            FROM picked
+            WHERE o.id = picked.id
+            RETURNING o.id, o.task_id, o.payload, o.attempts, o.delivered_sinks
+        )
+        SELECT id, task_id, payload, attempts, delivered_sinks FROM claimed ORDER BY id
+    `
+    var out []*domain.OutboxEntry
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('outbox'))`); err != nil {
+            return err
+        }
+        rows, err := tx.QueryContext(ctx, query, limit, lease.Seconds())
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+
+        for rows.Next() {
+            var e domain.OutboxEntry
+            var payload []byte
+            if err := rows.Scan(&e.ID, &e.TaskID, &payload, &e.Attempts, pq.Array(&e.Delivered)); err != nil {
+                return err
+            }
+            if err := json.Unmarshal(payload, &e.Event); err != nil {
+                return fmt.Errorf("outbox: decoding event %d: %w", e.ID, err)
+            }
+            out = append(out, &e)
+        }
+        return rows.Err()
+    })
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    return out, nil
+}
+
+// MarkPublished registra a publicação e solta a reserva.
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []int64) error {
+    if len(ids) == 0 {
+        return nil
+    }
+    return r.exec(ctx, `
+        UPDATE outbox
+        SET published_at = NOW(), locked_until = NULL, last_error = ''
+        WHERE id = ANY($1)
+    `, pq.Array(ids))
+}
+
+// MarkFailed registra a falha e os sinks já entregues e agenda a próxima tentativa.
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, delivered []string, cause string, at time.Time) error {
+    if delivered == nil {
+        delivered = []string{}
+    }
+    return r.exec(ctx, `
+        UPDATE outbox
+        SET attempts = attempts + 1, delivered_sinks = $2, last_error = $3, next_attempt_at = $4, locked_until = NULL
+        WHERE id = $1
+    `, id, pq.Array(delivered), cause, at)
+}
+
+// Release solta a reserva dos eventos sem alterar tentativas nem agenda.
+func (r *OutboxRepo) Release(ctx context.Context, ids []int64) error {
+    if len(ids) == 0 {
+        return nil
+    }
+    return r.exec(ctx, `UPDATE outbox SET locked_until = NULL WHERE id = ANY($1)`, pq.Array(ids))
+}
+
+// DeletePublished apaga em lote os eventos publicados antes de before.
+func (r *OutboxRepo) DeletePublished(ctx context.Context, before time.Time, limit int) (int, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        DELETE FROM outbox
+        WHERE id IN (
+            SELECT id FROM outbox WHERE published_at < $1 LIMIT $2
+        )
+    `
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, before, limit)
+    if err != nil {
+        return 0, ctxError(ctx, err)
+    }
+    n, err := res.RowsAffected()
+    return int(n), err
+}
+
+func (r *OutboxRepo) exec(ctx context.Context, query string, args ...interface{}) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+    return ctxError(ctx, err)
+}
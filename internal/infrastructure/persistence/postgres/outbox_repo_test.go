@@ -0,0 +1,192 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// testOutbox esvazia a outbox do banco de teste e grava, em ordem, um evento
+// para cada Task de tasks; devolve os IDs dos eventos, na mesma ordem.
+func testOutbox(t *testing.T, ctx context.Context, db *sql.DB, repo *OutboxRepo, tasks ...*domain.Task) []string {
+    t.Helper()
+    if _, err := db.Exec(`DELETE FROM outbox`); err != nil {
+        t.Fatalf("clear outbox: %v", err)
+    }
+    var ids []string
+    for _, task := range tasks {
+        e := &domain.Event{ID: uuid.NewString(), Type: domain.EventTaskUpdated, OccurredAt: time.Now(), UserID: task.UserID, Task: task}
+        if err := repo.Publish(ctx, e); err != nil {
+            t.Fatalf("publish: %v", err)
+        }
+        ids = append(ids, e.ID)
+    }
+    return ids
+}
+
+func claimedEvents(entries []*domain.OutboxEntry) string {
+    var ids []string
+    for _, e := range entries {
+        ids = append(ids, e.Event.ID)
+    }
+    return fmt.Sprint(ids)
+}
+
+func TestOutboxRepoClaimOrderAcrossRelays(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewOutboxRepo(db, 0)
+    a, b := testTask(t, ctx, db, "a"), testTask(t, ctx, db, "b")
+    ev := testOutbox(t, ctx, db, repo, a, a, b, a)
+
+    // O relay 1 reserva a Task a (limit 1: um evento dela).
+    first, err := repo.ClaimPending(ctx, 1, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got, want := claimedEvents(first), fmt.Sprint(ev[:1]); got != want {
+        t.Fatalf("relay 1 claimed %s, want %s", got, want)
+    }
+    // O relay 2 não pega os eventos seguintes de a enquanto a está reservada.
+    second, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got, want := claimedEvents(second), fmt.Sprint(ev[2:3]); got != want {
+        t.Fatalf("relay 2 claimed %s, want %s", got, want)
+    }
+    if more, _ := repo.ClaimPending(ctx, 10, time.Minute); len(more) != 0 {
+        t.Fatalf("claimed %s while every task is reserved", claimedEvents(more))
+    }
+
+    // Publicado o primeiro, os demais de a saem em ordem para quem reservar.
+    if err := repo.MarkPublished(ctx, []int64{first[0].ID}); err != nil {
+        t.Fatalf("MarkPublished: %v", err)
+    }
+    rest, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got, want := claimedEvents(rest), fmt.Sprint([]string{ev[1], ev[3]}); got != want {
+        t.Errorf("next claim = %s, want %s", got, want)
+    }
+}
+
+func TestOutboxRepoClaimLimitsEvents(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewOutboxRepo(db, 0)
+    a, b := testTask(t, ctx, db, "a"), testTask(t, ctx, db, "b")
+    ev := testOutbox(t, ctx, db, repo, a, b, a, a, b)
+
+    // limit conta eventos de todas as Tasks, não eventos por Task.
+    claimed, err := repo.ClaimPending(ctx, 3, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got, want := claimedEvents(claimed), fmt.Sprint(ev[:3]); got != want {
+        t.Fatalf("claimed %s, want %s", got, want)
+    }
+
+    // Soltos sem publicar, os eventos voltam na mesma ordem e sem tentativa.
+    ids := []int64{claimed[0].ID, claimed[1].ID, claimed[2].ID}
+    if err := repo.Release(ctx, ids); err != nil {
+        t.Fatalf("Release: %v", err)
+    }
+    again, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got, want := claimedEvents(again), fmt.Sprint(ev); got != want {
+        t.Fatalf("claimed %s after release, want %s", got, want)
+    }
+    if again[0].Attempts != 0 {
+        t.Errorf("released entry = %+v, want no attempt", again[0])
+    }
+}
+
+func TestOutboxRepoRetryKeepsDeliveredSinks(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewOutboxRepo(db, 0)
+    a := testTask(t, ctx, db, "a")
+    ev := testOutbox(t, ctx, db, repo, a, a)
+
+    claimed, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil || len(claimed) != 2 {
+        t.Fatalf("ClaimPending() = %s, %v", claimedEvents(claimed), err)
+    }
+    if len(claimed[0].Delivered) != 0 || claimed[0].Attempts != 0 {
+        t.Fatalf("new entry = %+v", claimed[0])
+    }
+
+    // Falha para depois: nem o evento nem o seguinte da Task estão na hora.
+    if err := repo.MarkFailed(ctx, claimed[0].ID, []string{"webhooks"}, "stream: down", time.Now().Add(time.Hour)); err != nil {
+        t.Fatalf("MarkFailed: %v", err)
+    }
+    if err := repo.Release(ctx, []int64{claimed[1].ID}); err != nil { // o adiado volta à fila
+        t.Fatalf("Release: %v", err)
+    }
+    if early, _ := repo.ClaimPending(ctx, 10, time.Minute); len(early) != 0 {
+        t.Fatalf("claimed %s before the retry time", claimedEvents(early))
+    }
+
+    // Na hora da nova tentativa, o evento volta com os sinks já entregues.
+    db.Exec(`UPDATE outbox SET next_attempt_at = NOW() WHERE id = $1`, claimed[0].ID)
+    retry, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil {
+        t.Fatalf("ClaimPending: %v", err)
+    }
+    if got := claimedEvents(retry); got != fmt.Sprint(ev) {
+        t.Fatalf("retry claimed %s, want %v", got, ev)
+    }
+    if retry[0].Attempts != 1 || fmt.Sprint(retry[0].Delivered) != "[webhooks]" {
+        t.Errorf("retried entry = %+v, want one attempt delivered to webhooks", retry[0])
+    }
+}
+
+func TestOutboxRepoDeletePublished(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewOutboxRepo(db, 0)
+    a, b, c := testTask(t, ctx, db, "a"), testTask(t, ctx, db, "b"), testTask(t, ctx, db, "c")
+    ev := testOutbox(t, ctx, db, repo, a, b, c)
+
+    claimed, err := repo.ClaimPending(ctx, 10, time.Minute)
+    if err != nil || len(claimed) != 3 {
+        t.Fatalf("ClaimPending() = %s, %v", claimedEvents(claimed), err)
+    }
+    // a publicado há dois dias, b agora, c pendente.
+    if err := repo.MarkPublished(ctx, []int64{claimed[0].ID, claimed[1].ID}); err != nil {
+        t.Fatalf("MarkPublished: %v", err)
+    }
+    db.Exec(`UPDATE outbox SET published_at = NOW() - INTERVAL '2 days' WHERE id = $1`, claimed[0].ID)
+    // Um pendente antigo nunca é coletado.
+    db.Exec(`UPDATE outbox SET created_at = NOW() - INTERVAL '3 days' WHERE id = $1`, claimed[2].ID)
+
+    n, err := repo.DeletePublished(ctx, time.Now().Add(-24*time.Hour), 10)
+    if err != nil || n != 1 {
+        t.Fatalf("DeletePublished() = %d, %v; want 1", n, err)
+    }
+    var left []string
+    rows, err := db.Query(`SELECT event_id FROM outbox ORDER BY id`)
+    if err != nil {
+        t.Fatalf("query: %v", err)
+    }
+    defer rows.Close()
+    for rows.Next() {
+        var id string
+        rows.Scan(&id)
+        left = append(left, id)
+    }
+    if got, want := fmt.Sprint(left), fmt.Sprint(ev[1:]); got != want {
+        t.Errorf("left %s, want %s", got, want)
+    }
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// errInvalidCursor é retornado quando o cursor não pode ser decodificado ou
+// foi gerado para outra ordenação.
+var errInvalidCursor = domain.NewBadRequestError("invalid query parameter", domain.FieldError{Field: "cursor", Message: "is invalid or expired"})
+
+// sortKey é uma coluna da ordenação de uma listagem paginada por keyset.
+type sortKey struct {
+    column   string // expressão SQL da coluna
+    cast     string // tipo usado para comparar o valor vindo do cursor
+    desc     bool
+    nullable bool // NULLs ficam por último, em qualquer direção
+}
+
+// cursor é o conteúdo (opaco para o cliente) de NextCursor/PrevCursor: os
+// valores das chaves de ordenação da linha de referência e o ID de desempate.
+type cursor struct {
+    Sort   string        `json:"s"`           // assinatura da ordenação
+    Values []interface{} `json:"v"`           // um valor (string ou null) por sortKey
+    ID     string        `json:"id"`          // desempate final
+    Prev   bool          `json:"p,omitempty"` // página anterior à linha de referência
+}
+
+// sortSignature identifica a ordenação para rejeitar cursores de outra listagem.
+func sortSignature(keys []sortKey) string {
+    parts := make([]string, len(keys))
+    for i, k := range keys {
+        parts[i] = k.column
+        if k.desc {
+            parts[i] = "-" + parts[i]
+        }
+    }
+    return strings.Join(parts, ",")
+}
+
+func encodeCursor(c cursor) string {
+    data, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string, keys []sortKey) (cursor, error) {
+    var c cursor
+    data, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return c, errInvalidCursor
+    }
+    if err := json.Unmarshal(data, &c); err != nil {
+        return c, errInvalidCursor
+    }
+    if c.Sort != sortSignature(keys) || len(c.Values) != len(keys) || !isUUID(c.ID) {
+        return c, errInvalidCursor
+    }
+    for i, v := range c.Values {
+        switch v.(type) {
+        case string:
+        case nil:
+            if !keys[i].nullable {
+                return c, errInvalidCursor
+            }
+        default:
+            return c, errInvalidCursor
+        }
+    }
+    return c, nil
+}
+
+// orderBy monta o ORDER BY das chaves seguidas do ID; reverse inverte todas
+// as direções (usado para buscar a página anterior).
+func orderBy(keys []sortKey, reverse bool) string {
+    parts := make([]string, 0, len(keys)+1)
+    idDesc := false
+    for _, k := range keys {
+        part := k.column + direction(k.desc != reverse)
+        if k.nullable {
+            // Invertida, a ordem também inverte a posição dos NULLs.
+            if reverse {
+                part += " NULLS FIRST"
+            } else {
+                part += " NULLS LAST"
+            }
+        }
+        parts = append(parts, part)
+        idDesc = k.desc
+    }
+    // O ID segue a direção da última chave para manter a ordem total estável.
+    parts = append(parts, "id"+direction(idDesc != reverse))
+    return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// keysetCondition monta o predicado "linha vem depois do cursor" na ordem
+// dada por keys (invertida quando reverse):
+//
+//	(k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND id > idv)
+//
+// Para chaves anuláveis "depois" e "igual" consideram que NULL vem por último.
+func keysetCondition(keys []sortKey, c cursor, reverse bool, arg func(interface{}) string) string {
+    var ors []string
+    var equals []string
+    idDesc := false
+    for i, k := range keys {
+        after, equal := k.compare(c.Values[i], reverse, arg)
+        if after != "" {
+            ors = append(ors, group(append(append([]string{}, equals...), after)))
+        }
+        equals = append(equals, equal)
+        idDesc = k.desc
+    }
+    op := ">"
+    if idDesc != reverse {
+        op = "<"
+    }
+    ors = append(ors, group(append(equals, "id "+op+" "+arg(c.ID)+"::uuid")))
+    return "(" + strings.Join(ors, " OR ") + ")"
+}
+
+// compare devolve as condições "coluna vem depois de v" e "coluna igual a
+// v". after vazio significa que nenhuma linha vem estritamente depois.
+func (k sortKey) compare(v interface{}, reverse bool, arg func(interface{}) string) (after, equal string) {
+    if v == nil {
+        // NULL é o último valor: depois dele só há NULLs (que empatam);
+        // na ordem invertida, todos os não nulos vêm depois.
+        if reverse {
+            return k.column + " IS NOT NULL", k.column + " IS NULL"
+        }
+        return "", k.column + " IS NULL"
+    }
+
+    p := arg(v) + "::" + k.cast
+    op := ">"
+    if k.desc != reverse {
+        op = "<"
+    }
+    after = k.column + " " + op + " " + p
+    if k.nullable && !reverse {
+        after = "(" + after + " OR " + k.column + " IS NULL)"
+    }
+    return after, k.column + " = " + p
+}
+
+func group(conds []string) string {
+    return "(" + strings.Join(conds, " AND ") + ")"
+}
+
+func direction(desc bool) string {
+    if desc {
+        return " DESC"
+    }
+    return " ASC"
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+const cursorID = "6f1c2a9e-8a6b-4f0e-9d2a-3b5c7e1f0a42"
+
+// placeholders imita o arg de TaskRepo.List, guardando os valores registrados.
+func placeholders() (func(interface{}) string, *[]interface{}) {
+    var args []interface{}
+    return func(v interface{}) string {
+        args = append(args, v)
+        return fmt.Sprintf("$%d", len(args))
+    }, &args
+}
+
+func TestKeysetCondition(t *testing.T) {
+    priorityDesc := sortKey{column: "priority", cast: "smallint", desc: true}
+    createdAsc := sortKey{column: "created_at", cast: "timestamptz"}
+    dueAsc := sortKey{column: "due_date", cast: "timestamptz", nullable: true}
+    dueDesc := sortKey{column: "due_date", cast: "timestamptz", nullable: true, desc: true}
+    due := "2026-01-01T00:00:00Z"
+
+    tests := []struct {
+        name    string
+        keys    []sortKey
+        values  []interface{}
+        reverse bool
+        want    string
+    }{
+        {
+            name:   "ascending",
+            keys:   []sortKey{createdAsc},
+            values: []interface{}{due},
+            want:   "((created_at > $1::timestamptz) OR (created_at = $1::timestamptz AND id > $2::uuid))",
+        },
+        {
+            name:    "ascending reversed",
+            keys:    []sortKey{createdAsc},
+            values:  []interface{}{due},
+            reverse: true,
+            want:    "((created_at < $1::timestamptz) OR (created_at = $1::timestamptz AND id < $2::uuid))",
+        },
+        {
+            name:   "descending",
+            keys:   []sortKey{priorityDesc},
+            values: []interface{}{"3"},
+            want:   "((priority < $1::smallint) OR (priority = $1::smallint AND id < $2::uuid))",
+        },
+        {
+            name:    "descending reversed",
+            keys:    []sortKey{priorityDesc},
+            values:  []interface{}{"3"},
+            reverse: true,
+            want:    "((priority > $1::smallint) OR (priority = $1::smallint AND id > $2::uuid))",
+        },
+        {
+            name:   "nullable ascending includes the NULLs after the value",
+            keys:   []sortKey{dueAsc},
+            values: []interface{}{due},
+            want:   "(((due_date > $1::timestamptz OR due_date IS NULL)) OR (due_date = $1::timestamptz AND id > $2::uuid))",
+        },
+        {
+            name:    "nullable ascending reversed",
+            keys:    []sortKey{dueAsc},
+            values:  []interface{}{due},
+            reverse: true,
+            want:    "((due_date < $1::timestamptz) OR (due_date = $1::timestamptz AND id < $2::uuid))",
+        },
+        {
+            name:   "nullable descending includes the NULLs after the value",
+            keys:   []sortKey{dueDesc},
+            values: []interface{}{due},
+            want:   "(((due_date < $1::timestamptz OR due_date IS NULL)) OR (due_date = $1::timestamptz AND id < $2::uuid))",
+        },
+        {
+            name:   "null value only ties on id",
+            keys:   []sortKey{dueAsc},
+            values: []interface{}{nil},
+            want:   "((due_date IS NULL AND id > $1::uuid))",
+        },
+        {
+            name:    "null value reversed reaches every non-null row",
+            keys:    []sortKey{dueAsc},
+            values:  []interface{}{nil},
+            reverse: true,
+            want:    "((due_date IS NOT NULL) OR (due_date IS NULL AND id < $1::uuid))",
+        },
+        {
+            name:   "two keys, id follows the last direction",
+            keys:   []sortKey{priorityDesc, createdAsc},
+            values: []interface{}{"2", due},
+            want: "((priority < $1::smallint) OR (priority = $1::smallint AND created_at > $2::timestamptz)" +
+                " OR (priority = $1::smallint AND created_at = $2::timestamptz AND id > $3::uuid))",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            arg, args := placeholders()
+            got := keysetCondition(tt.keys, cursor{Values: tt.values, ID: cursorID}, tt.reverse, arg)
+            if got != tt.want {
+                t.Errorf("keysetCondition() =\n  %s\nwant\n  %s", got, tt.want)
+            }
+            // Cada valor do cursor vira um único placeholder, seguido do id.
+            var wantArgs []interface{}
+            for _, v := range tt.values {
+                if v != nil {
+                    wantArgs = append(wantArgs, v)
+                }
+            }
+            wantArgs = append(wantArgs, cursorID)
+            if fmt.Sprint(*args) != fmt.Sprint(wantArgs) {
+                t.Errorf("args = %v, want %v", *args, wantArgs)
+            }
+        })
+    }
+}
+
+func TestOrderBy(t *testing.T) {
+    tests := []struct {
+        name    string
+        keys    []sortKey
+        reverse bool
+        want    string
+    }{
+        {
+            name: "descending key",
+            keys: []sortKey{{column: "priority", desc: true}},
+            want: " ORDER BY priority DESC, id DESC",
+        },
+        {
+            name:    "descending key reversed",
+            keys:    []sortKey{{column: "priority", desc: true}},
+            reverse: true,
+            want:    " ORDER BY priority ASC, id ASC",
+        },
+        {
+            name: "nullable key keeps NULLs last",
+            keys: []sortKey{{column: "due_date", nullable: true}, {column: "created_at", desc: true}},
+            want: " ORDER BY due_date ASC NULLS LAST, created_at DESC, id DESC",
+        },
+        {
+            name:    "nullable key reversed puts NULLs first",
+            keys:    []sortKey{{column: "due_date", nullable: true}, {column: "created_at", desc: true}},
+            reverse: true,
+            want:    " ORDER BY due_date DESC NULLS FIRST, created_at ASC, id ASC",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := orderBy(tt.keys, tt.reverse); got != tt.want {
+                t.Errorf("orderBy() = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestTaskSortKeys(t *testing.T) {
+    keys := taskSortKeys([]domain.TaskSort{
+        {Field: domain.SortByDueDate},
+        {Field: domain.SortByPriority, Desc: true},
+    })
+    if got, want := sortSignature(keys), "due_date,-priority"; got != want {
+        t.Errorf("sortSignature() = %q, want %q", got, want)
+    }
+    if !keys[0].nullable || keys[1].nullable {
+        t.Errorf("only due_date should be nullable: %+v", keys)
+    }
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+    keys := []sortKey{
+        {column: "due_date", cast: "timestamptz", nullable: true},
+        {column: "priority", cast: "smallint", desc: true},
+    }
+    tests := []cursor{
+        {Sort: sortSignature(keys), Values: []interface{}{"2026-01-01T00:00:00Z", "3"}, ID: cursorID},
+        {Sort: sortSignature(keys), Values: []interface{}{nil, "1"}, ID: cursorID, Prev: true},
+    }
+
+    for _, want := range tests {
+        got, err := decodeCursor(encodeCursor(want), keys)
+        if err != nil {
+            t.Fatalf("decodeCursor(encodeCursor(%+v)): %v", want, err)
+        }
+        if fmt.Sprintf("%+v", got) != fmt.Sprintf("%+v", want) {
+            t.Errorf("round trip = %+v, want %+v", got, want)
+        }
+    }
+}
+
+func TestDecodeCursorRejectsInvalid(t *testing.T) {
+    keys := []sortKey{
+        {column: "due_date", cast: "timestamptz", nullable: true},
+        {column: "priority", cast: "smallint", desc: true},
+    }
+    sig := sortSignature(keys)
+    raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+    tests := []struct {
+        name   string
+        cursor string
+    }{
+        {"garbage", "not a cursor!"},
+        {"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"x"}`))},
+        {"not json", raw("hello")},
+        {"other sort", encodeCursor(cursor{Sort: "created_at", Values: []interface{}{"x"}, ID: cursorID})},
+        {"too few values", encodeCursor(cursor{Sort: sig, Values: []interface{}{"x"}, ID: cursorID})},
+        {"id is not a uuid", encodeCursor(cursor{Sort: sig, Values: []interface{}{"x", "1"}, ID: "1 OR 1=1"})},
+        {"null in non-nullable key", encodeCursor(cursor{Sort: sig, Values: []interface{}{"x", nil}, ID: cursorID})},
+        {"number value", raw(`{"s":"` + sig + `","v":["x",1],"id":"` + cursorID + `"}`)},
+        {"object value", raw(`{"s":"` + sig + `","v":[{"a":1},"1"],"id":"` + cursorID + `"}`)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := decodeCursor(tt.cursor, keys)
+            de, ok := domain.AsError(err)
+            if !ok {
+                t.Fatalf("decodeCursor() error = %v, want a domain error", err)
+            }
+            // Um cursor adulterado é erro do cliente (400), nunca 500.
+            if de.Kind != domain.KindBadRequest {
+                t.Errorf("kind = %q, want %q", de.Kind, domain.KindBadRequest)
+            }
+            if len(de.Fields) != 1 || de.Fields[0].Field != "cursor" {
+                t.Errorf("fields = %+v, want the cursor field", de.Fields)
+            }
+        })
+    }
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// withTimeout aplica o prazo máximo de consulta configurado; d <= 0 mantém
+// apenas o prazo (se houver) do contexto recebido.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+    if d <= 0 {
+        return context.WithCancel(ctx)
+    }
+    return context.WithTimeout(ctx, d)
+}
+
+// ctxError prefere o erro do contexto ao erro do driver: quando a consulta é
+// cancelada o pq devolve "canceling statement due to user request", o que
+// esconde se foi prazo estourado ou cliente desconectado.
+func ctxError(ctx context.Context, err error) error {
+    if err == nil {
+        return nil
+    }
+    if cerr := ctx.Err(); cerr != nil {
+        return fmt.Errorf("%w: %v", cerr, err)
+    }
+    return err
+}
+
+// isUniqueViolation indica violação de UNIQUE (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+    var pqErr *pq.Error
+    return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isUniqueViolationOf indica violação do índice UNIQUE constraint, e não de
+// outro índice da mesma tabela.
+func isUniqueViolationOf(err error, constraint string) bool {
+    var pqErr *pq.Error
+    return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// ownerArg retorna o usuário autenticado do contexto para filtrar consultas,
+// ou nil quando não há usuário (ex.: workers internos), o que desativa o filtro
+// nas cláusulas "($n::uuid IS NULL OR user_id = $n)".
+func ownerArg(ctx context.Context) interface{} {
+    if id, ok := domain.UserIDFromContext(ctx); ok {
+        return id
+    }
+    return nil
+}
+
+// isRetryable indica falha de serialização (40001) ou deadlock (40P01): o
+// banco desfez a transação e ela pode ser repetida do início.
+func isRetryable(err error) bool {
+    var pqErr *pq.Error
+    return errors.As(err, &pqErr) && (pqErr.Code == "40001" || pqErr.Code == "40P01")
+}
+
+// nullString converte "" em NULL.
+func nullString(s string) sql.NullString {
+    return sql.NullString{String: s, Valid: s != ""}
+}
+
+// querier é satisfeito por *sql.DB e *sql.Tx, para helpers que rodam dentro
+// ou fora de uma transação.
+type querier interface {
+    ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+    QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+    QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn devolve a transação aberta por TxManager.WithinTx, se o contexto
+// estiver dentro de uma, ou o pool.
+func conn(ctx context.Context, db *sql.DB) querier {
+    if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+        return tx
+    }
+    return db
+}
+
+// inTx executa fn em uma transação, desfeita se fn retornar erro. Dentro de
+// TxManager.WithinTx, fn roda em um savepoint da transação já aberta, que
+// decide o commit.
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+    if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+        return inSavepoint(ctx, tx, fn)
+    }
+    return runTx(ctx, db, nil, fn)
+}
+
+// runTx abre uma transação com opts, executa fn e faz commit, ou rollback se
+// fn retornar erro.
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+    tx, err := db.BeginTx(ctx, opts)
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    if err := fn(tx); err != nil {
+        tx.Rollback()
+        return err
+    }
+    return ctxError(ctx, tx.Commit())
+}
+
+// inSavepoint executa fn em um savepoint da transação aberta: um erro desfaz
+// só o que fn gravou e a transação continua utilizável (ex.: a violação de
+// UNIQUE que Create traduz em ErrOccurrenceExists não aborta o lote todo).
+func inSavepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+    if _, err := tx.ExecContext(ctx, "SAVEPOINT repo"); err != nil {
+        return ctxError(ctx, err)
+    }
+    if err := fn(tx); err != nil {
+        tx.ExecContext(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT repo")
+        return err
+    }
+    _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT repo")
+    return ctxError(ctx, err)
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestIsUniqueViolationOf(t *testing.T) {
+    tests := []struct {
+        name string
+        err  error
+        want bool
+    }{
+        {"occurrence index", &pq.Error{Code: "23505", Constraint: occurrenceIndex}, true},
+        {"wrapped", fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Constraint: occurrenceIndex}), true},
+        {"other unique index", &pq.Error{Code: "23505", Constraint: "tasks_pkey"}, false},
+        {"other error on the same index", &pq.Error{Code: "23514", Constraint: occurrenceIndex}, false},
+        {"not a pq error", errors.New("boom"), false},
+        {"nil", nil, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := isUniqueViolationOf(tt.err, occurrenceIndex); got != tt.want {
+                t.Errorf("isUniqueViolationOf(%v) = %v, want %v", tt.err, got, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// projectSelect lê as colunas de Project e a quantidade de Tasks.
+const projectSelect = `
+    SELECT p.id, p.user_id, p.name, p.description, p.color, p.archived, p.archived_at,
+           p.created_at, p.updated_at,
+           (SELECT COUNT(*) FROM tasks t WHERE t.project_id = p.id)
+    FROM projects p
+`
+
+type ProjectRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewProjectRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewProjectRepo(db *sql.DB, queryTimeout time.Duration) *ProjectRepo {
+    return &ProjectRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Create insere um novo Project; nome repetido vira domain.ErrProjectNameTaken.
+func (r *ProjectRepo) Create(ctx context.Context, p *domain.Project) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO projects (id, user_id, name, description, color, archived, archived_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+    now := time.Now()
+    p.ID = uuid.NewString()
+    p.CreatedAt = now
+    p.UpdatedAt = now
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query,
+        p.ID,
+        p.UserID,
+        p.Name,
+        p.Description,
+        p.Color,
+        p.Archived,
+        p.ArchivedAt,
+        p.CreatedAt,
+        p.UpdatedAt,
+    )
+    if isUniqueViolation(err) {
+        return domain.ErrProjectNameTaken
+    }
+    return ctxError(ctx, err)
+}
+
+// FindByID busca um Project pelo ID, restrito ao usuário do contexto.
+func (r *ProjectRepo) FindByID(ctx context.Context, id string) (*domain.Project, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrProjectNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := projectSelect + `WHERE p.id = $1 AND ($2::uuid IS NULL OR p.user_id = $2)`
+    p, err := scanProject(conn(ctx, r.db).QueryRowContext(ctx, query, id, ownerArg(ctx)))
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, domain.ErrProjectNotFound
+        }
+        return nil, ctxError(ctx, err)
+    }
+    return p, nil
+}
+
+// List retorna os Projects do usuário do contexto em ordem alfabética.
+func (r *ProjectRepo) List(ctx context.Context, includeArchived bool) ([]*domain.Project, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := projectSelect + `
+        WHERE ($1::uuid IS NULL OR p.user_id = $1) AND ($2 OR NOT p.archived)
+        ORDER BY lower(p.name)
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, ownerArg(ctx), includeArchived)
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var projects []*domain.Project
+    for rows.Next() {
+        p, err := scanProject(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        projects = append(projects, p)
+    }
+    return projects, ctxError(ctx, rows.Err())
+}
+
+// Update altera os campos de um Project do usuário do contexto.
+func (r *ProjectRepo) Update(ctx context.Context, p *domain.Project) error {
+    if !isUUID(p.ID) {
+        return domain.ErrProjectNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        UPDATE projects
+        SET name = $1, description = $2, color = $3, archived = $4, archived_at = $5, updated_at = $6
+        WHERE id = $7 AND ($8::uuid IS NULL OR user_id = $8)
+    `
+    p.UpdatedAt = time.Now()
+    res, err := conn(ctx, r.db).ExecContext(ctx, query,
+        p.Name,
+        p.Description,
+        p.Color,
+        p.Archived,
+        p.ArchivedAt,
+        p.UpdatedAt,
+        p.ID,
+        ownerArg(ctx),
+    )
+    if isUniqueViolation(err) {
+        return domain.ErrProjectNameTaken
+    }
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrProjectNotFound
+    }
+    return nil
+}
+
+// Delete remove um Project do usuário do contexto. Com cascade as Tasks do
+// projeto são removidas na mesma transação; sem cascade um projeto com
+// Tasks retorna domain.ErrProjectNotEmpty.
+func (r *ProjectRepo) Delete(ctx context.Context, id string, cascade bool) error {
+    if !isUUID(id) {
+        return domain.ErrProjectNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        // Trava o projeto para que nenhuma Task seja incluída durante a remoção
+        var exists bool
+        err := tx.QueryRowContext(ctx,
+            `SELECT TRUE FROM projects WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2) FOR UPDATE`,
+            id, ownerArg(ctx),
+        ).Scan(&exists)
+        if errors.Is(err, sql.ErrNoRows) {
+            return domain.ErrProjectNotFound
+        }
+        if err != nil {
+            return err
+        }
+
+        if cascade {
+            if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE project_id = $1`, id); err != nil {
+                return err
+            }
+        } else {
+            var hasTasks bool
+            if err := tx.QueryRowContext(ctx,
+                `SELECT EXISTS (SELECT 1 FROM tasks WHERE project_id = $1)`, id,
+            ).Scan(&hasTasks); err != nil {
+                return err
+            }
+            if hasTasks {
+                return domain.ErrProjectNotEmpty
+            }
+        }
+
+        _, err = tx.ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, id)
+        return err
+    })
+    return ctxError(ctx, err)
+}
+
+// scanProject lê uma linha no formato de projectSelect.
+func scanProject(row rowScanner) (*domain.Project, error) {
+    var p domain.Project
+    if err := row.Scan(
+        &p.ID,
+        &p.UserID,
+        &p.Name,
+        &p.Description,
+        &p.Color,
+        &p.Archived,
+        &p.ArchivedAt,
+        &p.CreatedAt,
+        &p.UpdatedAt,
+        &p.TaskCount,
+    ); err != nil {
+        return nil, err
+    }
+    return &p, nil
+}
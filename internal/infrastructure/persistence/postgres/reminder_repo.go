@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// reminderFireAt calcula o disparo de um lembrete (r) a partir da sua Task
+// (t); lembretes relativos de Tasks sem vencimento dão NULL e nunca disparam.
+const reminderFireAt = `COALESCE(r.remind_at, t.due_date - make_interval(mins => r.before_minutes))`
+
+// reminderColumns lista as colunas lidas por scanReminder.
+const reminderColumns = `r.id, r.task_id, r.remind_at, r.before_minutes, ` + reminderFireAt + `,
+    r.attempts, r.last_error, r.sent_at, r.failed_at, r.created_at`
+
+type ReminderRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewReminderRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewReminderRepo(db *sql.DB, queryTimeout time.Duration) *ReminderRepo {
+    return &ReminderRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Create insere um lembrete e preenche o horário de disparo calculado.
+func (r *ReminderRepo) Create(ctx context.Context, rem *domain.Reminder) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        WITH r AS (
+            INSERT INTO reminders (id, task_id, remind_at, before_minutes, created_at)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING task_id, remind_at, before_minutes
+        )
+        SELECT ` + reminderFireAt + ` FROM r JOIN tasks t ON t.id = r.task_id
+    `
+    rem.ID = uuid.NewString()
+    rem.CreatedAt = time.Now()
+
+    var before sql.NullInt64
+    if rem.BeforeMinutes != nil {
+        before = sql.NullInt64{Int64: int64(*rem.BeforeMinutes), Valid: true}
+    }
+    err := conn(ctx, r.db).QueryRowContext(ctx, query, rem.ID, rem.TaskID, rem.At, before, rem.CreatedAt).Scan(&rem.FireAt)
+    return ctxError(ctx, err)
+}
+
+// ListByTask retorna os lembretes da Task em ordem de disparo, restritos ao
+// usuário do contexto.
+func (r *ReminderRepo) ListByTask(ctx context.Context, taskID string) ([]*domain.Reminder, error) {
+    if !isUUID(taskID) {
+        return nil, nil
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        SELECT ` + reminderColumns + `
+        FROM reminders r
+        JOIN tasks t ON t.id = r.task_id
+        WHERE r.task_id = $1 AND ($2::uuid IS NULL OR t.user_id = $2)
+        ORDER BY 5 NULLS LAST, r.created_at
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, taskID, ownerArg(ctx))
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    reminders := []*domain.Reminder{}
+    for rows.Next() {
+        rem, err := scanReminder(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        reminders = append(reminders, rem)
+    }
+    return reminders, ctxError(ctx, rows.Err())
+}
+
+// Delete remove um lembrete da Task, restrito ao usuário do contexto.
+func (r *ReminderRepo) Delete(ctx context.Context, taskID, id string) error {
+    if !isUUID(taskID) || !isUUID(id) {
+        return domain.ErrReminderNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        DELETE FROM reminders r
+        USING tasks t
+        WHERE r.id = $1 AND r.task_id = $2
+          AND t.id = r.task_id AND ($3::uuid IS NULL OR t.user_id = $3)
+    `
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, id, taskID, ownerArg(ctx))
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrReminderNotFound
+    }
+    return nil
+}
+
+// ClaimDue reserva lembretes vencidos com FOR UPDATE SKIP LOCKED: réplicas
+// que varrem ao mesmo tempo pegam lotes disjuntos, e locked_until impede que
+// outra réplica pegue o lembrete enquanto ele é entregue. Se a réplica cair
+// no meio da entrega, o lembrete volta a ficar disponível quando a lease expira.
+func (r *ReminderRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.ReminderNotification, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        WITH due AS (
+            SELECT r.id
+            FROM reminders r
+            JOIN tasks t ON t.id = r.task_id
+            WHERE r.sent_at IS NULL AND r.failed_at IS NULL
+              AND (r.locked_until IS NULL OR r.locked_until <= NOW())
+              AND t.status NOT IN ('done', 'cancelled')
+              AND ` + reminderFireAt + ` <= NOW()
+            ORDER BY ` + reminderFireAt + `
+            LIMIT $1
+            FOR UPDATE OF r SKIP LOCKED
+        )
+        UPDATE reminders r
+        SET locked_until = NOW() + make_interval(secs => $2), attempts = r.attempts + 1
+        FROM due, tasks t, users u
+        WHERE r.id = due.id AND t.id = r.task_id AND u.id = t.user_id
+        RETURNING r.id, r.task_id, t.title, t.due_date, ` + reminderFireAt + `,
+                  r.attempts, u.id, u.email, u.name, u.timezone
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Seconds())
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var out []*domain.ReminderNotification
+    for rows.Next() {
+        var n domain.ReminderNotification
+        err := rows.Scan(
+            &n.ReminderID,
+            &n.TaskID,
+            &n.TaskTitle,
+            &n.DueDate,
+            &n.FireAt,
+            &n.Attempt,
+            &n.UserID,
+            &n.UserEmail,
+            &n.UserName,
+            &n.Timezone,
+        )
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        out = append(out, &n)
+    }
+    return out, ctxError(ctx, rows.Err())
+}
+
+// MarkSent registra a entrega do lembrete.
+func (r *ReminderRepo) MarkSent(ctx context.Context, id string) error {
+    return r.exec(ctx, `
+        UPDATE reminders SET sent_at = NOW(), locked_until = NULL, last_error = ''
+        WHERE id = $1
+    `, id)
+}
+
+// MarkRetry registra a falha e adia a próxima tentativa para at.
+func (r *ReminderRepo) MarkRetry(ctx context.Context, id, cause string, at time.Time) error {
+    return r.exec(ctx, `
+        UPDATE reminders SET last_error = $2, locked_until = $3
+        WHERE id = $1
+    `, id, cause, at)
+}
+
+// MarkFailed registra a falha definitiva do lembrete.
+func (r *ReminderRepo) MarkFailed(ctx context.Context, id, cause string) error {
+    return r.exec(ctx, `
+        UPDATE reminders SET last_error = $2, failed_at = NOW(), locked_until = NULL
+        WHERE id = $1
+    `, id, cause)
+}
+
+func (r *ReminderRepo) exec(ctx context.Context, query string, args ...interface{}) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+    return ctxError(ctx, err)
+}
+
+// scanReminder lê uma linha com as colunas de reminderColumns.
+func scanReminder(row rowScanner) (*domain.Reminder, error) {
+    var rem domain.Reminder
+    var before sql.NullInt64
+    if err := row.Scan(
+        &rem.ID,
+        &rem.TaskID,
+        &rem.At,
+        &before,
+        &rem.FireAt,
+        &rem.Attempts,
+        &rem.LastError,
+        &rem.SentAt,
+        &rem.FailedAt,
+        &rem.CreatedAt,
+    ); err != nil {
+        return nil, err
+    }
+    if before.Valid {
+        minutes := int(before.Int64)
+        rem.BeforeMinutes = &minutes
+    }
+    return &rem, nil
+}
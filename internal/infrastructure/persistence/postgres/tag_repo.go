@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// tagSelect lê as colunas de Tag e a quantidade de Tasks marcadas.
+const tagSelect = `
+    SELECT g.id, g.user_id, g.name, g.color, g.created_at, g.updated_at,
+           (SELECT COUNT(*) FROM task_tags tt WHERE tt.tag_id = g.id)
+    FROM tags g
+`
+
+type TagRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewTagRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewTagRepo(db *sql.DB, queryTimeout time.Duration) *TagRepo {
+    return &TagRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Create insere uma nova Tag; nome repetido vira domain.ErrTagNameTaken.
+func (r *TagRepo) Create(ctx context.Context, t *domain.Tag) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO tags (id, user_id, name, color, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+    now := time.Now()
+    t.ID = uuid.NewString()
+    t.CreatedAt = now
+    t.UpdatedAt = now
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, t.ID, t.UserID, t.Name, t.Color, t.CreatedAt, t.UpdatedAt)
+    if isUniqueViolation(err) {
+        return domain.ErrTagNameTaken
+    }
+    return ctxError(ctx, err)
+}
+
+// FindByID busca uma Tag pelo ID, restrita ao usuário do contexto.
+func (r *TagRepo) FindByID(ctx context.Context, id string) (*domain.Tag, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrTagNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := tagSelect + `WHERE g.id = $1 AND ($2::uuid IS NULL OR g.user_id = $2)`
+    t, err := scanTag(conn(ctx, r.db).QueryRowContext(ctx, query, id, ownerArg(ctx)))
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, domain.ErrTagNotFound
+        }
+        return nil, ctxError(ctx, err)
+    }
+    return t, nil
+}
+
+// List retorna as Tags do usuário do contexto em ordem alfabética.
+func (r *TagRepo) List(ctx context.Context) ([]*domain.Tag, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := tagSelect + `WHERE ($1::uuid IS NULL OR g.user_id = $1) ORDER BY lower(g.name)`
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, ownerArg(ctx))
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var tags []*domain.Tag
+    for rows.Next() {
+        t, err := scanTag(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        tags = append(tags, t)
+    }
+    return tags, ctxError(ctx, rows.Err())
+}
+
+// Update altera nome e cor de uma Tag do usuário do contexto.
+func (r *TagRepo) Update(ctx context.Context, t *domain.Tag) error {
+    if !isUUID(t.ID) {
+        return domain.ErrTagNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        UPDATE tags SET name = $1, color = $2, updated_at = $3
+        WHERE id = $4 AND ($5::uuid IS NULL OR user_id = $5)
+    `
+    t.UpdatedAt = time.Now()
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, t.Name, t.Color, t.UpdatedAt, t.ID, ownerArg(ctx))
+    if isUniqueViolation(err) {
+        return domain.ErrTagNameTaken
+    }
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrTagNotFound
+    }
+    return nil
+}
+
+// Delete remove uma Tag e a desassocia de todas as Tasks.
+func (r *TagRepo) Delete(ctx context.Context, id string) error {
+    if !isUUID(id) {
+        return domain.ErrTagNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `DELETE FROM tags WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)`
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, id, ownerArg(ctx))
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrTagNotFound
+    }
+    return nil
+}
+
+// scanTag lê uma linha no formato de tagSelect.
+func scanTag(row rowScanner) (*domain.Tag, error) {
+    var t domain.Tag
+    if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt, &t.UpdatedAt, &t.TaskCount); err != nil {
+        return nil, err
+    }
+    return &t, nil
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// openPrerequisite é a condição "a Task t tem algum pré-requisito aberto".
+const openPrerequisite = `
+    EXISTS (
+        SELECT 1 FROM task_dependencies d
+        JOIN tasks p ON p.id = d.depends_on_id
+        WHERE d.task_id = t.id AND p.status NOT IN ('done', 'cancelled')
+    )
+`
+
+type TaskDependencyRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewTaskDependencyRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewTaskDependencyRepo(db *sql.DB, queryTimeout time.Duration) *TaskDependencyRepo {
+    return &TaskDependencyRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Add cria a aresta taskID -> dependsOnID. As alterações no grafo de um mesmo
+// usuário são serializadas por um advisory lock, senão duas arestas criadas ao
+// mesmo tempo poderiam fechar um ciclo sem que nenhuma das duas o visse.
+func (r *TaskDependencyRepo) Add(ctx context.Context, taskID, dependsOnID string) error {
+    if !isUUID(taskID) || !isUUID(dependsOnID) {
+        return domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        var userID string
+        err := tx.QueryRowContext(ctx,
+            `SELECT user_id FROM tasks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)`,
+            taskID, ownerArg(ctx),
+        ).Scan(&userID)
+        if errors.Is(err, sql.ErrNoRows) {
+            return domain.ErrTaskNotFound
+        }
+        if err != nil {
+            return err
+        }
+        if _, err := tx.ExecContext(ctx,
+            `SELECT pg_advisory_xact_lock(hashtext('task_dependencies'), hashtext($1))`, userID,
+        ); err != nil {
+            return err
+        }
+
+        // Há ciclo se taskID já é alcançável a partir de dependsOnID
+        var cycle bool
+        err = tx.QueryRowContext(ctx, `
+            WITH RECURSIVE reach(id) AS (
+                SELECT depends_on_id FROM task_dependencies WHERE task_id = $1
+                UNION
+                SELECT d.depends_on_id FROM task_dependencies d JOIN reach ON d.task_id = reach.id
+            )
+            SELECT EXISTS (SELECT 1 FROM reach WHERE id = $2)
+        `, dependsOnID, taskID).Scan(&cycle)
+        if err != nil {
+            return err
+        }
+        if cycle {
+            return domain.ErrDependencyCycle
+        }
+
+        _, err = tx.ExecContext(ctx, `
+            INSERT INTO task_dependencies (task_id, depends_on_id) VALUES ($1, $2)
+            ON CONFLICT DO NOTHING
+        `, taskID, dependsOnID)
+        return err
+    })
+    return ctxError(ctx, err)
+}
+
+// Remove apaga a aresta taskID -> dependsOnID.
+func (r *TaskDependencyRepo) Remove(ctx context.Context, taskID, dependsOnID string) error {
+    if !isUUID(taskID) || !isUUID(dependsOnID) {
+        return domain.ErrDependencyNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        DELETE FROM task_dependencies d
+        USING tasks t
+        WHERE d.task_id = $1 AND d.depends_on_id = $2
+          AND t.id = d.task_id AND ($3::uuid IS NULL OR t.user_id = $3)
+    `
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, taskID, dependsOnID, ownerArg(ctx))
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrDependencyNotFound
+    }
+    return nil
+}
+
+// BlockedBy retorna os pré-requisitos diretos da Task.
+func (r *TaskDependencyRepo) BlockedBy(ctx context.Context, taskID string) ([]*domain.Task, error) {
+    return r.neighbours(ctx, `
+        SELECT `+taskColumns+` FROM tasks
+        WHERE id IN (SELECT depends_on_id FROM task_dependencies WHERE task_id = $1)
+          AND ($2::uuid IS NULL OR user_id = $2)
+        ORDER BY created_at, id
+    `, taskID)
+}
+
+// Blocks retorna as Tasks que dependem diretamente da Task.
+func (r *TaskDependencyRepo) Blocks(ctx context.Context, taskID string) ([]*domain.Task, error) {
+    return r.neighbours(ctx, `
+        SELECT `+taskColumns+` FROM tasks
+        WHERE id IN (SELECT task_id FROM task_dependencies WHERE depends_on_id = $1)
+          AND ($2::uuid IS NULL OR user_id = $2)
+        ORDER BY created_at, id
+    `, taskID)
+}
+
+func (r *TaskDependencyRepo) neighbours(ctx context.Context, query, taskID string) ([]*domain.Task, error) {
+    if !isUUID(taskID) {
+        return nil, domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    tasks, err := queryTasks(ctx, conn(ctx, r.db), query, taskID, ownerArg(ctx))
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    return tasks, nil
+}
+
+// SyncBlocked aplica o bloqueio automático às Tasks informadas: todo com
+// pré-requisito aberto vai para blocked e blocked sem nenhum volta para todo.
+func (r *TaskDependencyRepo) SyncBlocked(ctx context.Context, taskIDs []string) error {
+    if len(taskIDs) == 0 {
+        return nil
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        UPDATE tasks t
+        SET status = CASE WHEN t.status = 'todo' THEN 'blocked' ELSE 'todo' END,
+            updated_at = NOW(), version = t.version + 1
+        WHERE t.id = ANY($1::uuid[]) AND ($2::uuid IS NULL OR t.user_id = $2)
+          AND ((t.status = 'todo' AND ` + openPrerequisite + `)
+            OR (t.status = 'blocked' AND NOT ` + openPrerequisite + `))
+    `
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, pq.Array(taskIDs), ownerArg(ctx))
+    return ctxError(ctx, err)
+}
+
+// ProjectGraph retorna as Tasks abertas do projeto e as dependências entre elas.
+func (r *TaskDependencyRepo) ProjectGraph(ctx context.Context, projectID string) ([]*domain.Task, []domain.TaskDependency, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    tasks, err := queryTasks(ctx, conn(ctx, r.db), `
+        SELECT `+taskColumns+` FROM tasks
+        WHERE project_id = $1 AND ($2::uuid IS NULL OR user_id = $2)
+          AND status NOT IN ('done', 'cancelled')
+    `, projectID, ownerArg(ctx))
+    if err != nil {
+        return nil, nil, ctxError(ctx, err)
+    }
+
+    rows, err := conn(ctx, r.db).QueryContext(ctx, `
+        SELECT d.task_id, d.depends_on_id
+        FROM task_dependencies d
+        JOIN tasks t ON t.id = d.task_id
+        JOIN tasks p ON p.id = d.depends_on_id
+        WHERE t.project_id = $1 AND p.project_id = $1
+          AND ($2::uuid IS NULL OR t.user_id = $2)
+    `, projectID, ownerArg(ctx))
+    if err != nil {
+        return nil, nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var edges []domain.TaskDependency
+    for rows.Next() {
+        var e domain.TaskDependency
+        if err := rows.Scan(&e.TaskID, &e.DependsOnID); err != nil {
+            return nil, nil, ctxError(ctx, err)
+        }
+        edges = append(edges, e)
+    }
+    return tasks, edges, ctxError(ctx, rows.Err())
+}
+
+// queryTasks executa uma consulta que seleciona taskColumns e carrega as tags.
+func queryTasks(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Task, error) {
+    rows, err := q.QueryContext(ctx, query, args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    tasks := []*domain.Task{}
+    for rows.Next() {
+        t, err := scanTask(rows)
+        if err != nil {
+            return nil, err
+        }
+        tasks = append(tasks, t)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    if err := loadTaskTags(ctx, q, tasks); err != nil {
+        return nil, err
+    }
+    return tasks, nil
+}
@@ -0,0 +1,68 @@
+//go:build integration
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestTaskDependencyRepoAddRejectsCycles(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewTaskDependencyRepo(db, 0)
+    a, b, c := testTask(t, ctx, db, "a"), testTask(t, ctx, db, "b"), testTask(t, ctx, db, "c")
+
+    // c -> b -> a
+    if err := repo.Add(ctx, b.ID, a.ID); err != nil {
+        t.Fatalf("add b -> a: %v", err)
+    }
+    if err := repo.Add(ctx, c.ID, b.ID); err != nil {
+        t.Fatalf("add c -> b: %v", err)
+    }
+    // Repetir a aresta é no-op
+    if err := repo.Add(ctx, c.ID, b.ID); err != nil {
+        t.Fatalf("add c -> b again: %v", err)
+    }
+
+    tests := []struct {
+        name            string
+        task, dependsOn *domain.Task
+    }{
+        {"direct", a, b},
+        {"transitive", a, c},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := repo.Add(ctx, tt.task.ID, tt.dependsOn.ID)
+            if !errors.Is(err, domain.ErrDependencyCycle) {
+                t.Fatalf("Add(%s -> %s) = %v, want ErrDependencyCycle", tt.task.Title, tt.dependsOn.Title, err)
+            }
+        })
+    }
+
+    // Uma aresta que não fecha ciclo continua aceita
+    if err := repo.Add(ctx, c.ID, a.ID); err != nil {
+        t.Fatalf("add c -> a: %v", err)
+    }
+    blockedBy, err := repo.BlockedBy(ctx, a.ID)
+    if err != nil {
+        t.Fatalf("blocked by: %v", err)
+    }
+    if len(blockedBy) != 0 {
+        t.Errorf("a is blocked by %d tasks, want 0", len(blockedBy))
+    }
+}
+
+func TestTaskDependencyRepoAddRejectsSelf(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    a := testTask(t, ctx, db, "a")
+
+    // O use case barra antes; o CHECK da tabela é a última linha de defesa.
+    if err := NewTaskDependencyRepo(db, 0).Add(ctx, a.ID, a.ID); err == nil {
+        t.Fatal("Add(a -> a) succeeded")
+    }
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Ancestors retorna os IDs dos ancestrais da Task, do pai até a raiz. A
+// recursão é limitada para não girar para sempre se houver dados inconsistentes.
+func (r *TaskRepo) Ancestors(ctx context.Context, id string) ([]string, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        WITH RECURSIVE up(id, parent_id, depth) AS (
+            SELECT id, parent_id, 0 FROM tasks
+            WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)
+            UNION ALL
+            SELECT t.id, t.parent_id, up.depth + 1
+            FROM tasks t JOIN up ON t.id = up.parent_id
+            WHERE up.depth < $3
+        )
+        SELECT id FROM up WHERE depth > 0 ORDER BY depth
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, ownerArg(ctx), domain.TaskMaxDepth*2)
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var ids []string
+    for rows.Next() {
+        var ancestor string
+        if err := rows.Scan(&ancestor); err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        ids = append(ids, ancestor)
+    }
+    return ids, ctxError(ctx, rows.Err())
+}
+
+// Descendants retorna todas as subtarefas da Task, em qualquer nível, com as
+// tags carregadas.
+func (r *TaskRepo) Descendants(ctx context.Context, id string) ([]*domain.Task, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        WITH RECURSIVE down(id, depth) AS (
+            SELECT id, 1 FROM tasks
+            WHERE parent_id = $1 AND ($2::uuid IS NULL OR user_id = $2)
+            UNION ALL
+            SELECT t.id, down.depth + 1
+            FROM tasks t JOIN down ON t.parent_id = down.id
+            WHERE down.depth < $3
+        )
+        SELECT ` + taskColumns + `
+        FROM tasks WHERE id IN (SELECT id FROM down)
+        ORDER BY created_at, id
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, ownerArg(ctx), domain.TaskMaxDepth*2)
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var tasks []*domain.Task
+    for rows.Next() {
+        t, err := scanTask(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        tasks = append(tasks, t)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    if err := loadTaskTags(ctx, conn(ctx, r.db), tasks); err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    return tasks, nil
+}
+
+// loadTaskDetails completa as tasks lidas com os dados de outras tabelas
+// (tags e progresso das subtarefas), em consultas únicas para o lote.
+func loadTaskDetails(ctx context.Context, q querier, tasks []*domain.Task) error {
+    if err := loadTaskTags(ctx, q, tasks); err != nil {
+        return err
+    }
+    return loadTaskProgress(ctx, q, tasks)
+}
+
+// loadTaskProgress preenche Progress das tasks que têm subtarefas.
+func loadTaskProgress(ctx context.Context, q querier, tasks []*domain.Task) error {
+    if len(tasks) == 0 {
+        return nil
+    }
+    byID := make(map[string]*domain.Task, len(tasks))
+    ids := make([]string, len(tasks))
+    for i, t := range tasks {
+        byID[t.ID] = t
+        ids[i] = t.ID
+    }
+
+    rows, err := q.QueryContext(ctx, `
+        WITH RECURSIVE tree(root_id, id, status, depth) AS (
+            SELECT parent_id, id, status, 1 FROM tasks WHERE parent_id = ANY($1::uuid[])
+            UNION ALL
+            SELECT tree.root_id, t.id, t.status, tree.depth + 1
+            FROM tasks t JOIN tree ON t.parent_id = tree.id
+            WHERE tree.depth < $2
+        )
+        SELECT root_id,
+               COUNT(*) FILTER (WHERE status <> 'cancelled'),
+               COUNT(*) FILTER (WHERE status = 'done')
+        FROM tree GROUP BY root_id
+    `, pq.Array(ids), domain.TaskMaxDepth*2)
+    if err != nil {
+        return err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var rootID string
+        var total, completed int
+        if err := rows.Scan(&rootID, &total, &completed); err != nil {
+            return err
+        }
+        if t, ok := byID[rootID]; ok {
+            t.Progress = domain.NewTaskProgress(total, completed)
+        }
+    }
+    return rows.Err()
+}
@@ -1,153 +1,591 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/rubenfabio/gopher-tasks/internal/domain"
 )
 
+// taskColumns é a lista de colunas lida por scanTask, na mesma ordem.
+const taskColumns = `id, user_id, project_id, parent_id, title, description, status, priority, due_date, completed, created_at, updated_at,
+    recurrence_rule, recurrence_timezone, recurrence_series_id, recurrence_start, recurrence_index, version`
+
+// occurrenceIndex impede duas Tasks com a mesma ocorrência de uma série
+// (migração 0008); só a violação dele vira ErrOccurrenceExists.
+const occurrenceIndex = "idx_tasks_recurrence_occurrence"
+
 type TaskRepo struct {
-    db *sql.DB
+    db           *sql.DB
+    queryTimeout time.Duration
 }
 
-func NewTaskRepo(db *sql.DB) *TaskRepo {
-    return &TaskRepo{db: db}
+// NewTaskRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewTaskRepo(db *sql.DB, queryTimeout time.Duration) *TaskRepo {
+    return &TaskRepo{db: db, queryTimeout: queryTimeout}
 }
 
-// Create insere uma nova Task no banco.
-func (r *TaskRepo) Create(t *domain.Task) error {
+// Create insere uma nova Task no banco, junto com suas tags.
+func (r *TaskRepo) Create(ctx context.Context, t *domain.Task) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
     query := `
-        INSERT INTO tasks (id, title, description, due_date, completed, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO tasks (id, user_id, project_id, parent_id, title, description, status, priority, due_date, completed, created_at, updated_at,
+                           recurrence_rule, recurrence_timezone, recurrence_series_id, recurrence_start, recurrence_index)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
     `
     now := time.Now()
     t.ID = uuid.NewString()
     t.CreatedAt = now
     t.UpdatedAt = now
+    t.Version = 1
+    // A primeira ocorrência dá o ID à série
+    if t.Recurrence != nil && t.Recurrence.SeriesID == "" {
+        t.Recurrence.SeriesID = t.ID
+    }
 
-    _, err := r.db.Exec(query,
-        t.ID,
-        t.Title,
-        t.Description,
-        t.DueDate,
-        t.Completed,
-        t.CreatedAt,
-        t.UpdatedAt,
-    )
-    return err
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        args := []interface{}{
+            t.ID,
+            nullString(t.UserID),
+            nullString(t.ProjectID),
+            nullString(t.ParentID),
+            t.Title,
+            t.Description,
+            t.Status,
+            t.Priority,
+            t.DueDate,
+            t.Completed,
+            t.CreatedAt,
+            t.UpdatedAt,
+        }
+        if _, err := tx.ExecContext(ctx, query, append(args, recurrenceArgs(t.Recurrence)...)...); err != nil {
+            return err
+        }
+        if t.Tags == nil {
+            t.Tags = []string{}
+            return nil
+        }
+        return setTaskTags(ctx, tx, t)
+    })
+    if isUniqueViolationOf(err, occurrenceIndex) {
+        return domain.ErrOccurrenceExists
+    }
+    return ctxError(ctx, err)
 }
 
-// FindByID busca uma Task pelo ID.
-func (r *TaskRepo) FindByID(id string) (*domain.Task, error) {
+// FindByID busca uma Task pelo ID, restrita ao usuário do contexto.
+func (r *TaskRepo) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
     query := `
-        SELECT id, title, description, due_date, completed, created_at, updated_at
-        FROM tasks WHERE id = $1
+        SELECT ` + taskColumns + `
+        FROM tasks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)
     `
-    row := r.db.QueryRow(query, id)
-    var t domain.Task
-    if err := row.Scan(
-        &t.ID,
-        &t.Title,
-        &t.Description,
-        &t.DueDate,
-        &t.Completed,
-        &t.CreatedAt,
-        &t.UpdatedAt,
-    ); err != nil {
+    t, err := scanTask(conn(ctx, r.db).QueryRowContext(ctx, query, id, ownerArg(ctx)))
+    if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
-            return nil, nil
+            return nil, domain.ErrTaskNotFound
         }
-        return nil, err
+        return nil, ctxError(ctx, err)
     }
-    return &t, nil
+    if err := loadTaskDetails(ctx, conn(ctx, r.db), []*domain.Task{t}); err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    return t, nil
 }
 
-// Update altera os campos de uma Task existente.
-func (r *TaskRepo) Update(t *domain.Task) error {
+// Update altera os campos de uma Task existente do usuário do contexto. As
+// tags são substituídas por t.Tags; nil mantém as atuais.
+func (r *TaskRepo) Update(ctx context.Context, t *domain.Task) error {
+    if !isUUID(t.ID) {
+        return domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    // A versão só avança se ninguém gravou a Task desde que ela foi lida
     query := `
         UPDATE tasks
-        SET title = $1, description = $2, due_date = $3, completed = $4, updated_at = $5
-        WHERE id = $6
+        SET project_id = $1, parent_id = $2, title = $3, description = $4, status = $5,
+            priority = $6, due_date = $7, completed = $8, updated_at = $9,
+            recurrence_rule = $12, recurrence_timezone = $13, recurrence_series_id = $14,
+            recurrence_start = $15, recurrence_index = $16, version = version + 1
+        WHERE id = $10 AND ($11::uuid IS NULL OR user_id = $11) AND version = $17
+        RETURNING version
     `
-    t.UpdatedAt = time.Now()
-    _, err := r.db.Exec(query,
-        t.Title,
-        t.Description,
-        t.DueDate,
-        t.Completed,
-        t.UpdatedAt,
-        t.ID,
-    )
-    return err
-}
-
-// Delete remove uma Task pelo ID.
-func (r *TaskRepo) Delete(id string) error {
-    query := `DELETE FROM tasks WHERE id = $1`  
-    res, err := r.db.Exec(query, id)
-    if err != nil {
-        return err
+    updatedAt := time.Now()
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        args := []interface{}{
+            nullString(t.ProjectID),
+            nullString(t.ParentID),
+            t.Title,
+            t.Description,
+            t.Status,
+            t.Priority,
+            t.DueDate,
+            t.Completed,
+            updatedAt,
+            t.ID,
+            ownerArg(ctx),
+        }
+        args = append(args, recurrenceArgs(t.Recurrence)...)
+        var version int
+        err := tx.QueryRowContext(ctx, query, append(args, t.Version)...).Scan(&version)
+        if errors.Is(err, sql.ErrNoRows) {
+            return taskMissingOrChanged(ctx, tx, t.ID)
+        }
+        if err != nil {
+            return err
+        }
+        t.Version = version
+        t.UpdatedAt = updatedAt
+        if t.Tags == nil {
+            return nil
+        }
+        return setTaskTags(ctx, tx, t)
+    })
+    return ctxError(ctx, err)
+}
+
+// UpdateFields grava apenas as colunas dos campos informados, para que uma
+// atualização parcial não reescreva o que não mudou. A versão é conferida e
+// avançada como em Update.
+func (r *TaskRepo) UpdateFields(ctx context.Context, t *domain.Task, fields []domain.TaskField) error {
+    if !isUUID(t.ID) {
+        return domain.ErrTaskNotFound
     }
-    count, err := res.RowsAffected()
-    if err != nil {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    var args []interface{}
+    // arg registra o valor e devolve o placeholder correspondente
+    arg := func(v interface{}) string {
+        args = append(args, v)
+        return fmt.Sprintf("$%d", len(args))
+    }
+    var set []string
+    tags := false
+    for _, f := range fields {
+        switch f {
+        case domain.TaskFieldProject:
+            set = append(set, "project_id = "+arg(nullString(t.ProjectID)))
+        case domain.TaskFieldParent:
+            set = append(set, "parent_id = "+arg(nullString(t.ParentID)))
+        case domain.TaskFieldTitle:
+            set = append(set, "title = "+arg(t.Title))
+        case domain.TaskFieldDescription:
+            set = append(set, "description = "+arg(t.Description))
+        case domain.TaskFieldStatus:
+            set = append(set, "status = "+arg(t.Status))
+        case domain.TaskFieldPriority:
+            set = append(set, "priority = "+arg(t.Priority))
+        case domain.TaskFieldDueDate:
+            set = append(set, "due_date = "+arg(t.DueDate))
+        case domain.TaskFieldCompleted:
+            set = append(set, "completed = "+arg(t.Completed))
+        case domain.TaskFieldRecurrence:
+            values := recurrenceArgs(t.Recurrence)
+            for i, col := range []string{"recurrence_rule", "recurrence_timezone", "recurrence_series_id", "recurrence_start", "recurrence_index"} {
+                set = append(set, col+" = "+arg(values[i]))
+            }
+        case domain.TaskFieldTags:
+            tags = true
+        default:
+            return fmt.Errorf("unknown task field %q", f)
+        }
+    }
+    updatedAt := time.Now()
+    set = append(set, "updated_at = "+arg(updatedAt), "version = version + 1")
+    owner := arg(ownerArg(ctx))
+    query := `
+        UPDATE tasks
+        SET ` + strings.Join(set, ", ") + `
+        WHERE id = ` + arg(t.ID) + ` AND (` + owner + `::uuid IS NULL OR user_id = ` + owner + `)
+          AND version = ` + arg(t.Version) + `
+        RETURNING version
+    `
+
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        var version int
+        err := tx.QueryRowContext(ctx, query, args...).Scan(&version)
+        if errors.Is(err, sql.ErrNoRows) {
+            return taskMissingOrChanged(ctx, tx, t.ID)
+        }
+        if err != nil {
+            return err
+        }
+        t.Version = version
+        t.UpdatedAt = updatedAt
+        if !tags {
+            return nil
+        }
+        return setTaskTags(ctx, tx, t)
+    })
+    return ctxError(ctx, err)
+}
+
+// taskMissingOrChanged explica um UPDATE que não achou a Task na versão
+// esperada: ela não existe (para o usuário) ou foi alterada por outro.
+func taskMissingOrChanged(ctx context.Context, q querier, id string) error {
+    var exists bool
+    query := `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2))`
+    if err := q.QueryRowContext(ctx, query, id, ownerArg(ctx)).Scan(&exists); err != nil {
         return err
     }
-    if count == 0 {
-        return errors.New("task not found")
+    if !exists {
+        return domain.ErrTaskNotFound
     }
-    return nil
+    return domain.ErrTaskVersionConflict
 }
 
-// List retorna uma lista de Tasks segundo o filtro.
-func (r *TaskRepo) List(filter domain.TaskFilter) ([]*domain.Task, error) {
-    query := `
-        SELECT id, title, description, due_date, completed, created_at, updated_at
-        FROM tasks
-    `
+// Delete remove uma Task pelo ID, restrita ao usuário do contexto, se ela
+// ainda estiver em version, como em Update.
+func (r *TaskRepo) Delete(ctx context.Context, id string, version int) error {
+    if !isUUID(id) {
+        return domain.ErrTaskNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `DELETE FROM tasks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2) AND version = $3`
+    err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+        res, err := tx.ExecContext(ctx, query, id, ownerArg(ctx), version)
+        if err != nil {
+            return err
+        }
+        count, err := res.RowsAffected()
+        if err != nil {
+            return err
+        }
+        if count == 0 {
+            return taskMissingOrChanged(ctx, tx, id)
+        }
+        return nil
+    })
+    return ctxError(ctx, err)
+}
+
+// taskSortColumns mapeia os campos de domain.TaskSortFields para as colunas.
+var taskSortColumns = map[domain.TaskSortField]sortKey{
+    domain.SortByCreatedAt: {column: "created_at", cast: "timestamptz"},
+    domain.SortByUpdatedAt: {column: "updated_at", cast: "timestamptz"},
+    domain.SortByDueDate:   {column: "due_date", cast: "timestamptz", nullable: true},
+    domain.SortByPriority:  {column: "priority", cast: "smallint"},
+    domain.SortByTitle:     {column: "title", cast: "text"},
+    domain.SortByStatus:    {column: "status", cast: "text"},
+}
+
+// taskSortKeys converte a ordenação do filtro (ou a padrão) em sortKeys.
+func taskSortKeys(sorts []domain.TaskSort) []sortKey {
+    if len(sorts) == 0 {
+        sorts = domain.DefaultTaskSort
+    }
+    keys := make([]sortKey, 0, len(sorts))
+    for _, s := range sorts {
+        k, ok := taskSortColumns[s.Field]
+        if !ok {
+            continue
+        }
+        k.desc = s.Desc
+        keys = append(keys, k)
+    }
+    return keys
+}
+
+// List retorna uma página de Tasks do usuário do contexto segundo o filtro,
+// paginada por keyset sobre as chaves de ordenação seguidas do id.
+func (r *TaskRepo) List(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
     var args []interface{}
-    var conditions []string
+    // arg registra o valor e devolve o placeholder correspondente
+    arg := func(v interface{}) string {
+        args = append(args, v)
+        return fmt.Sprintf("$%d", len(args))
+    }
+    conditions := taskConditions(ctx, filter, arg)
 
-    if filter.Completed != nil {
-        conditions = append(conditions, "completed = $1")
-        args = append(args, *filter.Completed)
+    page := &domain.TaskPage{}
+    if filter.IncludeTotal {
+        total, err := r.count(ctx, conditions, args)
+        if err != nil {
+            return nil, err
+        }
+        page.Total = &total
     }
-    if len(conditions) > 0 {
-        query += " WHERE " + strings.Join(conditions, " AND ")
+
+    keys := taskSortKeys(filter.Sort)
+    var cur *cursor
+    if filter.Cursor != "" {
+        c, err := decodeCursor(filter.Cursor, keys)
+        if err != nil {
+            return nil, err
+        }
+        cur = &c
+        conditions = append(conditions, keysetCondition(keys, c, c.Prev, arg))
     }
-    query += " ORDER BY created_at DESC"
+    reverse := cur != nil && cur.Prev
+
+    query := `
+        SELECT ` + taskColumns + `
+        FROM tasks
+    `
+    query += where(conditions) + orderBy(keys, reverse)
     if filter.Limit > 0 {
-        query += fmt.Sprintf(" LIMIT %d", filter.Limit)
-    }
-    if filter.Offset > 0 {
-        query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+        // Uma linha a mais indica se existe outra página
+        query += " LIMIT " + arg(filter.Limit+1)
     }
 
-    rows, err := r.db.Query(query, args...)
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
     if err != nil {
-        return nil, err
+        return nil, ctxError(ctx, err)
     }
     defer rows.Close()
 
     var tasks []*domain.Task
     for rows.Next() {
-        var t domain.Task
-        if err := rows.Scan(
-            &t.ID,
-            &t.Title,
-            &t.Description,
-            &t.DueDate,
-            &t.Completed,
-            &t.CreatedAt,
-            &t.UpdatedAt,
-        ); err != nil {
-            return nil, err
+        t, err := scanTask(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
         }
-        tasks = append(tasks, &t)
+        tasks = append(tasks, t)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, ctxError(ctx, err)
+    }
+
+    tasks, page.NextCursor, page.PrevCursor = pageTasks(tasks, keys, filter.Limit, cur)
+    if err := loadTaskDetails(ctx, conn(ctx, r.db), tasks); err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    page.Tasks = tasks
+    return page, nil
+}
+
+// count conta as Tasks que satisfazem as condições (sem paginação).
+func (r *TaskRepo) count(ctx context.Context, conditions []string, args []interface{}) (int, error) {
+    var total int
+    query := `SELECT COUNT(*) FROM tasks` + where(conditions)
+    if err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+        return 0, ctxError(ctx, err)
+    }
+    return total, nil
+}
+
+// taskConditions traduz o filtro (e o dono do contexto) em condições SQL,
+// registrando os valores via arg.
+func taskConditions(ctx context.Context, filter domain.TaskFilter, arg func(interface{}) string) []string {
+    var conditions []string
+
+    if owner := ownerArg(ctx); owner != nil {
+        conditions = append(conditions, "user_id = "+arg(owner))
+    }
+    if filter.ProjectID != "" {
+        conditions = append(conditions, "project_id = "+arg(filter.ProjectID))
+    }
+    if filter.ParentID != "" {
+        conditions = append(conditions, "parent_id = "+arg(filter.ParentID))
+    }
+    if filter.Completed != nil {
+        conditions = append(conditions, "completed = "+arg(*filter.Completed))
+    }
+    if len(filter.Statuses) > 0 {
+        statuses := make([]string, len(filter.Statuses))
+        for i, st := range filter.Statuses {
+            statuses[i] = string(st)
+        }
+        conditions = append(conditions, "status = ANY("+arg(pq.Array(statuses))+")")
+    }
+    if len(filter.Priorities) > 0 {
+        priorities := make([]int64, len(filter.Priorities))
+        for i, p := range filter.Priorities {
+            priorities[i] = int64(p)
+        }
+        conditions = append(conditions, "priority = ANY("+arg(pq.Array(priorities))+")")
+    }
+    if filter.DueAfter != nil {
+        conditions = append(conditions, "due_date >= "+arg(*filter.DueAfter))
+    }
+    if filter.DueBefore != nil {
+        conditions = append(conditions, "due_date < "+arg(*filter.DueBefore))
+    }
+    if filter.Overdue {
+        conditions = append(conditions, "due_date < NOW() AND status NOT IN ('done', 'cancelled')")
+    }
+    if filter.CreatedAfter != nil {
+        conditions = append(conditions, "created_at >= "+arg(*filter.CreatedAfter))
+    }
+    if filter.CreatedBefore != nil {
+        conditions = append(conditions, "created_at < "+arg(*filter.CreatedBefore))
+    }
+    if filter.UpdatedAfter != nil {
+        conditions = append(conditions, "updated_at >= "+arg(*filter.UpdatedAfter))
+    }
+    if filter.UpdatedBefore != nil {
+        conditions = append(conditions, "updated_at < "+arg(*filter.UpdatedBefore))
+    }
+    if filter.Search != "" {
+        // Mesma expressão do índice GIN idx_tasks_search
+        conditions = append(conditions,
+            "to_tsvector('simple', title || ' ' || coalesce(description, '')) @@ plainto_tsquery('simple', "+arg(filter.Search)+")")
+    }
+    conditions = append(conditions, tagConditions(filter, arg)...)
+    return conditions
+}
+
+// pageTasks monta a página a partir das linhas lidas (até limit+1, na ordem
+// invertida quando cur aponta para a página anterior): descarta a linha
+// extra, devolve as Tasks na ordem pedida e gera os cursores vizinhos.
+func pageTasks(tasks []*domain.Task, keys []sortKey, limit int, cur *cursor) (page []*domain.Task, next, prev string) {
+    reverse := cur != nil && cur.Prev
+    hasMore := limit > 0 && len(tasks) > limit
+    if hasMore {
+        tasks = tasks[:limit]
+    }
+    if reverse {
+        for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+            tasks[i], tasks[j] = tasks[j], tasks[i]
+        }
+    }
+    if len(tasks) == 0 {
+        return tasks, "", ""
     }
-    return tasks, nil
+
+    first, last := tasks[0], tasks[len(tasks)-1]
+    switch {
+    case reverse:
+        // Viemos de uma página posterior: sempre há "próxima".
+        next = taskCursor(keys, last, false)
+        if hasMore {
+            prev = taskCursor(keys, first, true)
+        }
+    default:
+        if hasMore {
+            next = taskCursor(keys, last, false)
+        }
+        if cur != nil {
+            prev = taskCursor(keys, first, true)
+        }
+    }
+    return tasks, next, prev
+}
+
+// taskCursor gera o cursor que aponta para t na ordenação keys.
+func taskCursor(keys []sortKey, t *domain.Task, prev bool) string {
+    values := make([]interface{}, len(keys))
+    for i, k := range keys {
+        values[i] = taskSortValue(t, k.column)
+    }
+    return encodeCursor(cursor{Sort: sortSignature(keys), Values: values, ID: t.ID, Prev: prev})
+}
+
+// taskSortValue extrai de t o valor da coluna de ordenação, como string
+// (ou nil para due_date ausente).
+func taskSortValue(t *domain.Task, column string) interface{} {
+    switch column {
+    case "created_at":
+        return t.CreatedAt.Format(time.RFC3339Nano)
+    case "updated_at":
+        return t.UpdatedAt.Format(time.RFC3339Nano)
+    case "due_date":
+        if t.DueDate == nil {
+            return nil
+        }
+        return t.DueDate.Format(time.RFC3339Nano)
+    case "priority":
+        return strconv.Itoa(int(t.Priority))
+    case "title":
+        return t.Title
+    case "status":
+        return string(t.Status)
+    default:
+        return nil
+    }
+}
+
+func where(conditions []string) string {
+    if len(conditions) == 0 {
+        return ""
+    }
+    return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// rowScanner é satisfeito por *sql.Row e *sql.Rows.
+type rowScanner interface {
+    Scan(dest ...interface{}) error
+}
+
+// scanTask lê uma linha com as colunas de taskColumns.
+func scanTask(row rowScanner) (*domain.Task, error) {
+    var t domain.Task
+    var userID, projectID, parentID, description sql.NullString
+    var rule, timezone, seriesID sql.NullString
+    var start sql.NullTime
+    var index sql.NullInt64
+    if err := row.Scan(
+        &t.ID,
+        &userID,
+        &projectID,
+        &parentID,
+        &t.Title,
+        &description,
+        &t.Status,
+        &t.Priority,
+        &t.DueDate,
+        &t.Completed,
+        &t.CreatedAt,
+        &t.UpdatedAt,
+        &rule,
+        &timezone,
+        &seriesID,
+        &start,
+        &index,
+        &t.Version,
+    ); err != nil {
+        return nil, err
+    }
+    t.UserID = userID.String
+    t.ProjectID = projectID.String
+    t.ParentID = parentID.String
+    t.Description = description.String
+    if rule.Valid {
+        t.Recurrence = &domain.TaskRecurrence{
+            Rule:       rule.String,
+            Timezone:   timezone.String,
+            SeriesID:   seriesID.String,
+            Start:      start.Time,
+            Occurrence: int(index.Int64),
+        }
+    }
+    return &t, nil
+}
+
+// recurrenceArgs devolve os valores das colunas recurrence_*, na ordem de
+// taskColumns; Task sem recorrência grava NULL em todas.
+func recurrenceArgs(r *domain.TaskRecurrence) []interface{} {
+    if r == nil {
+        return []interface{}{nil, nil, nil, nil, nil}
+    }
+    return []interface{}{r.Rule, r.Timezone, r.SeriesID, r.Start, r.Occurrence}
+}
+
+// isUUID evita enviar ao Postgres IDs que nunca casariam com a coluna UUID
+// (o driver retornaria erro de sintaxe em vez de "não encontrado").
+func isUUID(id string) bool {
+    _, err := uuid.Parse(id)
+    return err == nil
 }
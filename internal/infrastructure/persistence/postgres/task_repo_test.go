@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// pagedTable simula a consulta de TaskRepo.List sobre linhas em memória,
+// ordenadas por priority DESC, id DESC (com empates de prioridade).
+type pagedTable struct {
+    keys []sortKey
+    rows []*domain.Task
+}
+
+func newPagedTable(n int) *pagedTable {
+    tb := &pagedTable{keys: taskSortKeys([]domain.TaskSort{{Field: domain.SortByPriority, Desc: true}})}
+    for i := 0; i < n; i++ {
+        tb.rows = append(tb.rows, &domain.Task{
+            ID:       fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+            Priority: domain.Priority(1 + i%3),
+        })
+    }
+    return tb
+}
+
+// less diz se a vem antes de b na ordem da listagem.
+func (tb *pagedTable) less(a, b *domain.Task) bool {
+    if a.Priority != b.Priority {
+        return a.Priority > b.Priority
+    }
+    return a.ID > b.ID
+}
+
+// fetch devolve o que o SELECT devolveria: as linhas depois do cursor, na
+// ordem invertida quando ele aponta para a página anterior, até limit+1.
+func (tb *pagedTable) fetch(t *testing.T, token string, limit int) ([]*domain.Task, *cursor) {
+    var cur *cursor
+    rows := append([]*domain.Task{}, tb.rows...)
+    sort.Slice(rows, func(i, j int) bool { return tb.less(rows[i], rows[j]) })
+    if token != "" {
+        c, err := decodeCursor(token, tb.keys)
+        if err != nil {
+            t.Fatalf("decodeCursor: %v", err)
+        }
+        cur = &c
+        p, _ := strconv.Atoi(c.Values[0].(string))
+        ref := &domain.Task{ID: c.ID, Priority: domain.Priority(p)}
+
+        var after []*domain.Task
+        for _, r := range rows {
+            if (!c.Prev && tb.less(ref, r)) || (c.Prev && tb.less(r, ref)) {
+                after = append(after, r)
+            }
+        }
+        rows = after
+        if c.Prev {
+            for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+                rows[i], rows[j] = rows[j], rows[i]
+            }
+        }
+    }
+    if len(rows) > limit+1 {
+        rows = rows[:limit+1]
+    }
+    return rows, cur
+}
+
+func (tb *pagedTable) page(t *testing.T, token string, limit int) (ids []string, next, prev string) {
+    rows, cur := tb.fetch(t, token, limit)
+    tasks, next, prev := pageTasks(rows, tb.keys, limit, cur)
+    for _, task := range tasks {
+        ids = append(ids, task.ID)
+    }
+    return ids, next, prev
+}
+
+func TestPageTasksForwardThenBack(t *testing.T) {
+    tb := newPagedTable(10)
+    const limit = 3
+
+    // Avança até o fim guardando cada página.
+    var pages [][]string
+    var prevs []string
+    next := ""
+    for {
+        ids, n, p := tb.page(t, next, limit)
+        pages = append(pages, ids)
+        prevs = append(prevs, p)
+        if n == "" {
+            break
+        }
+        next = n
+    }
+    if len(pages) != 4 {
+        t.Fatalf("got %d pages, want 4", len(pages))
+    }
+    if prevs[0] != "" {
+        t.Errorf("first page has a prev cursor")
+    }
+
+    // Volta de cada página para a anterior: mesmas linhas, mesma ordem.
+    for i := len(pages) - 1; i > 0; i-- {
+        ids, n, p := tb.page(t, prevs[i], limit)
+        if fmt.Sprint(ids) != fmt.Sprint(pages[i-1]) {
+            t.Errorf("back from page %d = %v, want %v", i, ids, pages[i-1])
+        }
+        if n == "" {
+            t.Errorf("back from page %d: missing next cursor", i)
+        }
+        if (p == "") != (i-1 == 0) {
+            t.Errorf("back from page %d: prev cursor = %q", i, p)
+        }
+        // O next da página alcançada voltando leva de novo à página i.
+        again, _, _ := tb.page(t, n, limit)
+        if fmt.Sprint(again) != fmt.Sprint(pages[i]) {
+            t.Errorf("forward again to page %d = %v, want %v", i, again, pages[i])
+        }
+    }
+}
+
+func TestPageTasksEmpty(t *testing.T) {
+    tasks, next, prev := pageTasks(nil, taskSortKeys(nil), 10, nil)
+    if len(tasks) != 0 || next != "" || prev != "" {
+        t.Errorf("pageTasks(nil) = %v, %q, %q", tasks, next, prev)
+    }
+}
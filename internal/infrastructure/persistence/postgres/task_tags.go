@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// setTaskTags faz de t.Tags o conjunto de tags da Task, criando para o dono
+// da Task as tags que ainda não existem. Ao final t.Tags recebe a grafia
+// cadastrada de cada tag.
+func setTaskTags(ctx context.Context, tx *sql.Tx, t *domain.Task) error {
+    if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE task_id = $1`, t.ID); err != nil {
+        return err
+    }
+    if len(t.Tags) > 0 {
+        // ON CONFLICT cobre a tag já existente (idx_tags_user_name)
+        if _, err := tx.ExecContext(ctx, `
+            INSERT INTO tags (user_id, name)
+            SELECT t.user_id, n
+            FROM tasks t, unnest($2::text[]) AS n
+            WHERE t.id = $1 AND t.user_id IS NOT NULL
+            ON CONFLICT DO NOTHING
+        `, t.ID, pq.Array(t.Tags)); err != nil {
+            return err
+        }
+        if _, err := tx.ExecContext(ctx, `
+            INSERT INTO task_tags (task_id, tag_id)
+            SELECT t.id, g.id
+            FROM tasks t
+            JOIN tags g ON g.user_id = t.user_id AND lower(g.name) = ANY($2)
+            WHERE t.id = $1
+        `, t.ID, pq.Array(lowerAll(t.Tags))); err != nil {
+            return err
+        }
+    }
+    return loadTaskTags(ctx, tx, []*domain.Task{t})
+}
+
+// loadTaskTags preenche Tags de todas as tasks com uma única consulta.
+func loadTaskTags(ctx context.Context, q querier, tasks []*domain.Task) error {
+    if len(tasks) == 0 {
+        return nil
+    }
+    byID := make(map[string]*domain.Task, len(tasks))
+    ids := make([]string, len(tasks))
+    for i, t := range tasks {
+        t.Tags = []string{}
+        byID[t.ID] = t
+        ids[i] = t.ID
+    }
+
+    rows, err := q.QueryContext(ctx, `
+        SELECT tt.task_id, g.name
+        FROM task_tags tt
+        JOIN tags g ON g.id = tt.tag_id
+        WHERE tt.task_id = ANY($1::uuid[])
+        ORDER BY lower(g.name)
+    `, pq.Array(ids))
+    if err != nil {
+        return err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var taskID, name string
+        if err := rows.Scan(&taskID, &name); err != nil {
+            return err
+        }
+        if t, ok := byID[taskID]; ok {
+            t.Tags = append(t.Tags, name)
+        }
+    }
+    return rows.Err()
+}
+
+// tagConditions traduz os filtros de tag de TaskFilter em condições sobre tasks.
+func tagConditions(filter domain.TaskFilter, arg func(interface{}) string) []string {
+    var conditions []string
+    // Tags da task corrente que estão na lista informada
+    matching := func(names []string) string {
+        return `FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+            WHERE tt.task_id = tasks.id AND lower(g.name) = ANY(` + arg(pq.Array(names)) + `)`
+    }
+
+    if names := lowerAll(filter.TagsAny); len(names) > 0 {
+        conditions = append(conditions, "EXISTS (SELECT 1 "+matching(names)+")")
+    }
+    if names := lowerAll(filter.TagsAll); len(names) > 0 {
+        conditions = append(conditions,
+            "(SELECT COUNT(DISTINCT lower(g.name)) "+matching(names)+") = "+arg(len(names)))
+    }
+    if names := lowerAll(filter.TagsNone); len(names) > 0 {
+        conditions = append(conditions, "NOT EXISTS (SELECT 1 "+matching(names)+")")
+    }
+    return conditions
+}
+
+// lowerAll devolve os nomes em minúsculas e sem repetições.
+func lowerAll(names []string) []string {
+    var out []string
+    seen := map[string]bool{}
+    for _, n := range names {
+        n = strings.ToLower(strings.TrimSpace(n))
+        if n == "" || seen[n] {
+            continue
+        }
+        seen[n] = true
+        out = append(out, n)
+    }
+    return out
+}
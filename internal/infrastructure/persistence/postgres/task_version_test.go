@@ -0,0 +1,45 @@
+//go:build integration
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestTaskRepoWritesCheckVersion(t *testing.T) {
+    db := testDB(t)
+    ctx := testUser(t, db)
+    repo := NewTaskRepo(db, 0)
+    task := testTask(t, ctx, db, "a")
+
+    // Uma gravação feita depois da leitura muda a versão.
+    stale := *task
+    task.Title = "renamed"
+    if err := repo.UpdateFields(ctx, task, []domain.TaskField{domain.TaskFieldTitle}); err != nil {
+        t.Fatalf("UpdateFields: %v", err)
+    }
+    if task.Version != stale.Version+1 {
+        t.Fatalf("version = %d, want %d", task.Version, stale.Version+1)
+    }
+
+    stale.Title = "lost update"
+    if err := repo.Update(ctx, &stale); !errors.Is(err, domain.ErrTaskVersionConflict) {
+        t.Errorf("Update(stale) = %v, want ErrTaskVersionConflict", err)
+    }
+    if err := repo.Delete(ctx, stale.ID, stale.Version); !errors.Is(err, domain.ErrTaskVersionConflict) {
+        t.Errorf("Delete(stale) = %v, want ErrTaskVersionConflict", err)
+    }
+    if _, err := repo.FindByID(ctx, task.ID); err != nil {
+        t.Fatalf("task removed by a stale delete: %v", err)
+    }
+
+    if err := repo.Delete(ctx, task.ID, task.Version); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    if err := repo.Delete(ctx, task.ID, task.Version); !errors.Is(err, domain.ErrTaskNotFound) {
+        t.Errorf("Delete(missing) = %v, want ErrTaskNotFound", err)
+    }
+}
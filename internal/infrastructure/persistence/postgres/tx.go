@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+    // retryBaseDelay é a espera da primeira nova tentativa e o tamanho do jitter.
+    retryBaseDelay = 10 * time.Millisecond
+    // maxRetryDelay limita a espera entre tentativas, jitter incluído.
+    maxRetryDelay = 500 * time.Millisecond
+)
+
+// txKey guarda no contexto a transação aberta por TxManager.
+type txKey struct{}
+
+// isolationLevels são os níveis aceitos em database.txisolation.
+var isolationLevels = map[string]sql.IsolationLevel{
+    "read_committed":  sql.LevelReadCommitted,
+    "repeatable_read": sql.LevelRepeatableRead,
+    "serializable":    sql.LevelSerializable,
+}
+
+// TxManager implementa domain.TxManager sobre o pool: os repositórios que
+// consultam via conn e inTx enxergam a transação carregada pelo contexto.
+// Falhas de serialização e deadlocks desfazem a transação e fn roda de novo,
+// até maxAttempts vezes.
+type TxManager struct {
+    db          *sql.DB
+    opts        *sql.TxOptions
+    maxAttempts int
+}
+
+// NewTxManager recebe o pool, o nível de isolamento (read_committed,
+// repeatable_read ou serializable; vazio usa o padrão do banco) e quantas
+// vezes uma transação é tentada (menos de 1 vale 1).
+func NewTxManager(db *sql.DB, isolation string, maxAttempts int) (*TxManager, error) {
+    m := &TxManager{db: db, maxAttempts: max(maxAttempts, 1)}
+    if isolation != "" {
+        level, ok := isolationLevels[isolation]
+        if !ok {
+            return nil, fmt.Errorf("unknown transaction isolation level %q", isolation)
+        }
+        m.opts = &sql.TxOptions{Isolation: level}
+    }
+    return m, nil
+}
+
+// WithinTx roda fn em uma transação. Chamadas aninhadas reaproveitam a
+// transação de fora, que é a única a fazer commit, rollback e novas
+// tentativas; por isso fn deve poder ser repetida do início.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+    if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+        return fn(ctx)
+    }
+    for attempt := 1; ; attempt++ {
+        err := runTx(ctx, m.db, m.opts, func(tx *sql.Tx) error {
+            return fn(context.WithValue(ctx, txKey{}, tx))
+        })
+        if err == nil || attempt >= m.maxAttempts || !isRetryable(err) {
+            return err
+        }
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(retryDelay(attempt)):
+        }
+    }
+}
+
+// retryDelay é a espera depois da tentativa attempt: curta e crescente
+// (attempt² × retryBaseDelay), com jitter para as transações que colidiram
+// não colidirem de novo, e sempre abaixo de maxRetryDelay.
+func retryDelay(attempt int) time.Duration {
+    // attempt limitado evita overflow; a espera já passa do teto bem antes
+    attempt = min(attempt, 100)
+    delay := min(time.Duration(attempt*attempt)*retryBaseDelay, maxRetryDelay-retryBaseDelay)
+    return delay + rand.N(retryBaseDelay)
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var errBoom = errors.New("boom")
+
+// txLog registra, em ordem, o que as conexões falsas recebem.
+type txLog struct {
+    mu    sync.Mutex
+    steps []string
+}
+
+func (l *txLog) add(step string) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.steps = append(l.steps, step)
+}
+
+func (l *txLog) String() string {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    return strings.Join(l.steps, ", ")
+}
+
+// fakeConnector abre conexões que só registram BEGIN, COMMIT, ROLLBACK e os
+// comandos executados, sem banco por trás.
+type fakeConnector struct {
+    log *txLog
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (c fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+    log *txLog
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+    return nil, errors.New("fakeConn: prepare not supported")
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+    return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+    step := "BEGIN"
+    if sql.IsolationLevel(opts.Isolation) == sql.LevelSerializable {
+        step += " SERIALIZABLE"
+    }
+    c.log.add(step)
+    return fakeDriverTx(c), nil
+}
+
+func (c fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+    c.log.add(query)
+    return driver.RowsAffected(0), nil
+}
+
+type fakeDriverTx struct {
+    log *txLog
+}
+
+func (t fakeDriverTx) Commit() error   { t.log.add("COMMIT"); return nil }
+func (t fakeDriverTx) Rollback() error { t.log.add("ROLLBACK"); return nil }
+
+func fakeTxManager(t *testing.T, maxAttempts int) (*TxManager, *sql.DB, *txLog) {
+    t.Helper()
+    log := &txLog{}
+    db := sql.OpenDB(fakeConnector{log: log})
+    t.Cleanup(func() { db.Close() })
+    m, err := NewTxManager(db, "serializable", maxAttempts)
+    if err != nil {
+        t.Fatalf("NewTxManager: %v", err)
+    }
+    return m, db, log
+}
+
+func TestWithinTxRetriesConflicts(t *testing.T) {
+    serialization := &pq.Error{Code: "40001"}
+    deadlock := &pq.Error{Code: "40P01"}
+    other := &pq.Error{Code: "23505"}
+
+    tests := []struct {
+        name        string
+        maxAttempts int
+        failures    []error // erro de cada execução de fn; depois delas, nil
+        wantCalls   int
+        wantErr     error
+    }{
+        {"success", 3, nil, 1, nil},
+        {"serialization failure then success", 3, []error{serialization, serialization}, 3, nil},
+        {"deadlock then success", 3, []error{deadlock}, 2, nil},
+        {"gives up after maxAttempts", 2, []error{serialization, deadlock, serialization}, 2, deadlock},
+        {"other errors are not retried", 3, []error{other}, 1, other},
+        {"plain errors are not retried", 3, []error{errBoom}, 1, errBoom},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m, _, log := fakeTxManager(t, tt.maxAttempts)
+            var txs []*sql.Tx
+            err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+                tx, _ := ctx.Value(txKey{}).(*sql.Tx)
+                txs = append(txs, tx)
+                if n := len(txs); n <= len(tt.failures) {
+                    return tt.failures[n-1]
+                }
+                return nil
+            })
+            if err != tt.wantErr {
+                t.Fatalf("WithinTx() = %v, want %v", err, tt.wantErr)
+            }
+            if len(txs) != tt.wantCalls {
+                t.Fatalf("fn ran %d times, want %d", len(txs), tt.wantCalls)
+            }
+            // Cada execução roda em uma transação nova.
+            for i, tx := range txs {
+                if tx == nil {
+                    t.Fatalf("call %d ran outside a transaction", i)
+                }
+                for _, prev := range txs[:i] {
+                    if tx == prev {
+                        t.Errorf("call %d reused a transaction", i)
+                    }
+                }
+            }
+
+            var want []string
+            for i := 0; i < tt.wantCalls; i++ {
+                end := "COMMIT"
+                if i < tt.wantCalls-1 || tt.wantErr != nil {
+                    end = "ROLLBACK"
+                }
+                want = append(want, "BEGIN SERIALIZABLE", end)
+            }
+            if got := log.String(); got != strings.Join(want, ", ") {
+                t.Errorf("steps = %s, want %s", got, strings.Join(want, ", "))
+            }
+        })
+    }
+}
+
+func TestWithinTxCancelledWhileWaiting(t *testing.T) {
+    m, _, _ := fakeTxManager(t, 5)
+    ctx, cancel := context.WithCancel(context.Background())
+    calls := 0
+    err := m.WithinTx(ctx, func(context.Context) error {
+        calls++
+        cancel()
+        return &pq.Error{Code: "40001"}
+    })
+    if calls != 1 || err == nil {
+        t.Errorf("WithinTx() = %v after %d calls, want the conflict after 1", err, calls)
+    }
+}
+
+func TestWithinTxNesting(t *testing.T) {
+    m, db, log := fakeTxManager(t, 3)
+
+    err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+        outer := ctx.Value(txKey{})
+        // WithinTx aninhado reaproveita a transação, sem BEGIN nem COMMIT.
+        if err := m.WithinTx(ctx, func(ctx context.Context) error {
+            if ctx.Value(txKey{}) != outer {
+                t.Error("nested WithinTx opened another transaction")
+            }
+            return nil
+        }); err != nil {
+            return err
+        }
+        // inTx dentro da transação usa savepoints: o erro desfaz só o seu.
+        if err := inTx(ctx, db, func(tx *sql.Tx) error {
+            _, err := tx.ExecContext(ctx, "INSERT ok")
+            return err
+        }); err != nil {
+            return err
+        }
+        if err := inTx(ctx, db, func(tx *sql.Tx) error {
+            tx.ExecContext(ctx, "INSERT duplicate")
+            return errBoom
+        }); err != errBoom {
+            t.Errorf("inTx() = %v, want errBoom", err)
+        }
+        _, err := conn(ctx, db).ExecContext(ctx, "UPDATE after")
+        return err
+    })
+    if err != nil {
+        t.Fatalf("WithinTx: %v", err)
+    }
+
+    want := "BEGIN SERIALIZABLE, SAVEPOINT repo, INSERT ok, RELEASE SAVEPOINT repo, " +
+        "SAVEPOINT repo, INSERT duplicate, ROLLBACK TO SAVEPOINT repo, UPDATE after, COMMIT"
+    if got := log.String(); got != want {
+        t.Errorf("steps =\n  %s\nwant\n  %s", got, want)
+    }
+}
+
+func TestInTxOutsideWithinTx(t *testing.T) {
+    _, db, log := fakeTxManager(t, 1)
+    ctx := context.Background()
+
+    inTx(ctx, db, func(tx *sql.Tx) error {
+        _, err := tx.ExecContext(ctx, "INSERT ok")
+        return err
+    })
+    inTx(ctx, db, func(tx *sql.Tx) error { return errBoom })
+
+    if got, want := log.String(), "BEGIN, INSERT ok, COMMIT, BEGIN, ROLLBACK"; got != want {
+        t.Errorf("steps = %s, want %s", got, want)
+    }
+}
+
+func TestRetryDelay(t *testing.T) {
+    for _, attempt := range []int{1, 2, 3, 5, 7, 8, 50, 1 << 20} {
+        floor := maxRetryDelay - retryBaseDelay
+        if attempt < 10 {
+            floor = min(time.Duration(attempt*attempt)*retryBaseDelay, floor)
+        }
+        for i := 0; i < 200; i++ {
+            d := retryDelay(attempt)
+            if d < floor || d >= floor+retryBaseDelay {
+                t.Fatalf("retryDelay(%d) = %v, want [%v, %v)", attempt, d, floor, floor+retryBaseDelay)
+            }
+            if d >= maxRetryDelay {
+                t.Fatalf("retryDelay(%d) = %v, above the %v cap", attempt, d, maxRetryDelay)
+            }
+        }
+    }
+}
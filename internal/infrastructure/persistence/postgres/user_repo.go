@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+type UserRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewUserRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewUserRepo(db *sql.DB, queryTimeout time.Duration) *UserRepo {
+    return &UserRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Create insere um novo User; e-mail duplicado vira domain.ErrEmailTaken.
+func (r *UserRepo) Create(ctx context.Context, u *domain.User) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO users (id, email, name, password_hash, timezone, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+    now := time.Now()
+    u.ID = uuid.NewString()
+    u.CreatedAt = now
+    u.UpdatedAt = now
+    if u.Timezone == "" {
+        u.Timezone = domain.DefaultTimezone
+    }
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query,
+        u.ID,
+        u.Email,
+        u.Name,
+        u.PasswordHash,
+        u.Timezone,
+        u.CreatedAt,
+        u.UpdatedAt,
+    )
+    if isUniqueViolation(err) {
+        return domain.ErrEmailTaken
+    }
+    return ctxError(ctx, err)
+}
+
+// FindByID busca um User pelo ID.
+func (r *UserRepo) FindByID(ctx context.Context, id string) (*domain.User, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrUserNotFound
+    }
+    return r.findOne(ctx, `WHERE id = $1`, id)
+}
+
+// FindByEmail busca um User pelo e-mail (já normalizado pelo use case).
+func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+    return r.findOne(ctx, `WHERE email = $1`, email)
+}
+
+// Update grava nome e fuso de um User existente.
+func (r *UserRepo) Update(ctx context.Context, u *domain.User) error {
+    if !isUUID(u.ID) {
+        return domain.ErrUserNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    u.UpdatedAt = time.Now()
+    query := `UPDATE users SET name = $1, timezone = $2, updated_at = $3 WHERE id = $4`
+    res, err := conn(ctx, r.db).ExecContext(ctx, query, u.Name, u.Timezone, u.UpdatedAt, u.ID)
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrUserNotFound
+    }
+    return nil
+}
+
+func (r *UserRepo) findOne(ctx context.Context, where string, arg interface{}) (*domain.User, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        SELECT id, email, name, password_hash, timezone, created_at, updated_at
+        FROM users ` + where
+    var u domain.User
+    if err := conn(ctx, r.db).QueryRowContext(ctx, query, arg).Scan(
+        &u.ID,
+        &u.Email,
+        &u.Name,
+        &u.PasswordHash,
+        &u.Timezone,
+        &u.CreatedAt,
+        &u.UpdatedAt,
+    ); err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, domain.ErrUserNotFound
+        }
+        return nil, ctxError(ctx, err)
+    }
+    return &u, nil
+}
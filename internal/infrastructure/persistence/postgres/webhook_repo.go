@@ -0,0 +1,390 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// webhookColumns lista as colunas lidas por scanWebhook.
+const webhookColumns = `id, user_id, url, events, secret, active, created_at, updated_at`
+
+// deliveryColumns lista as colunas lidas por scanDelivery.
+const deliveryColumns = `id, webhook_id, event_id, event_type, status, attempts, next_attempt_at,
+    last_status_code, last_error, delivered_at, payload, created_at, updated_at`
+
+type WebhookRepo struct {
+    db           *sql.DB
+    queryTimeout time.Duration
+}
+
+// NewWebhookRepo recebe o pool e o prazo máximo de cada consulta (0 = sem limite próprio).
+func NewWebhookRepo(db *sql.DB, queryTimeout time.Duration) *WebhookRepo {
+    return &WebhookRepo{db: db, queryTimeout: queryTimeout}
+}
+
+// Create insere uma nova assinatura.
+func (r *WebhookRepo) Create(ctx context.Context, w *domain.Webhook) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO webhooks (id, user_id, url, events, secret, active, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+    now := time.Now()
+    w.ID = uuid.NewString()
+    w.CreatedAt = now
+    w.UpdatedAt = now
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query,
+        w.ID,
+        w.UserID,
+        w.URL,
+        pq.Array(eventNames(w.Events)),
+        w.Secret,
+        w.Active,
+        w.CreatedAt,
+        w.UpdatedAt,
+    )
+    return ctxError(ctx, err)
+}
+
+// FindByID busca uma assinatura pelo ID, restrita ao usuário do contexto.
+func (r *WebhookRepo) FindByID(ctx context.Context, id string) (*domain.Webhook, error) {
+    if !isUUID(id) {
+        return nil, domain.ErrWebhookNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)`
+    w, err := scanWebhook(conn(ctx, r.db).QueryRowContext(ctx, query, id, ownerArg(ctx)))
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, domain.ErrWebhookNotFound
+        }
+        return nil, ctxError(ctx, err)
+    }
+    return w, nil
+}
+
+// List retorna as assinaturas do usuário do contexto, das mais antigas para as mais novas.
+func (r *WebhookRepo) List(ctx context.Context) ([]*domain.Webhook, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE ($1::uuid IS NULL OR user_id = $1) ORDER BY created_at, id`
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, ownerArg(ctx))
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    webhooks := []*domain.Webhook{}
+    for rows.Next() {
+        w, err := scanWebhook(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        webhooks = append(webhooks, w)
+    }
+    return webhooks, ctxError(ctx, rows.Err())
+}
+
+// Update altera URL, eventos, segredo e situação de uma assinatura.
+func (r *WebhookRepo) Update(ctx context.Context, w *domain.Webhook) error {
+    if !isUUID(w.ID) {
+        return domain.ErrWebhookNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        UPDATE webhooks SET url = $1, events = $2, secret = $3, active = $4, updated_at = $5
+        WHERE id = $6 AND ($7::uuid IS NULL OR user_id = $7)
+    `
+    w.UpdatedAt = time.Now()
+    res, err := conn(ctx, r.db).ExecContext(ctx, query,
+        w.URL,
+        pq.Array(eventNames(w.Events)),
+        w.Secret,
+        w.Active,
+        w.UpdatedAt,
+        w.ID,
+        ownerArg(ctx),
+    )
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    return webhookAffected(res)
+}
+
+// Delete remove a assinatura e o seu histórico de entregas.
+func (r *WebhookRepo) Delete(ctx context.Context, id string) error {
+    if !isUUID(id) {
+        return domain.ErrWebhookNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1 AND ($2::uuid IS NULL OR user_id = $2)`, id, ownerArg(ctx))
+    if err != nil {
+        return ctxError(ctx, err)
+    }
+    return webhookAffected(res)
+}
+
+// Enqueue cria, em um único INSERT, uma entrega para cada assinatura ativa
+// do dono do evento que assina o seu tipo (ou "*").
+func (r *WebhookRepo) Enqueue(ctx context.Context, event *domain.Event, payload []byte) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+        SELECT id, $1, $2, $3
+        FROM webhooks
+        WHERE user_id = $4 AND active AND ($2 = ANY(events) OR '*' = ANY(events))
+        ON CONFLICT (webhook_id, event_id) DO NOTHING
+    `
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, event.ID, string(event.Type), string(payload), event.UserID)
+    return ctxError(ctx, err)
+}
+
+// Deliveries retorna o histórico de entregas da assinatura, restrito ao
+// usuário do contexto.
+func (r *WebhookRepo) Deliveries(ctx context.Context, webhookID string, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+    if !isUUID(webhookID) {
+        return nil, domain.ErrWebhookNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        SELECT ` + deliveryColumns + `
+        FROM webhook_deliveries d
+        WHERE webhook_id = $1
+          AND EXISTS (SELECT 1 FROM webhooks w WHERE w.id = d.webhook_id AND ($2::uuid IS NULL OR w.user_id = $2))
+          AND ($3 = '' OR status = $3)
+        ORDER BY created_at DESC, id DESC
+        LIMIT $4
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, webhookID, ownerArg(ctx), string(filter.Status), filter.Limit)
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    deliveries := []*domain.WebhookDelivery{}
+    for rows.Next() {
+        d, err := scanDelivery(rows)
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        deliveries = append(deliveries, d)
+    }
+    return deliveries, ctxError(ctx, rows.Err())
+}
+
+// Redeliver devolve a entrega (inclusive uma dead letter) para a fila, com
+// envio imediato; as tentativas anteriores continuam contadas.
+func (r *WebhookRepo) Redeliver(ctx context.Context, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+    if !isUUID(webhookID) || !isUUID(deliveryID) {
+        return nil, domain.ErrWebhookDeliveryNotFound
+    }
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        UPDATE webhook_deliveries d
+        SET status = 'pending', next_attempt_at = NOW(), locked_until = NULL, delivered_at = NULL, updated_at = NOW()
+        FROM webhooks w
+        WHERE d.id = $1 AND d.webhook_id = $2
+          AND w.id = d.webhook_id AND ($3::uuid IS NULL OR w.user_id = $3)
+        RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.status, d.attempts, d.next_attempt_at,
+                  d.last_status_code, d.last_error, d.delivered_at, d.payload, d.created_at, d.updated_at
+    `
+    d, err := scanDelivery(conn(ctx, r.db).QueryRowContext(ctx, query, deliveryID, webhookID, ownerArg(ctx)))
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, domain.ErrWebhookDeliveryNotFound
+        }
+        return nil, ctxError(ctx, err)
+    }
+    return d, nil
+}
+
+// ClaimDue reserva entregas com FOR UPDATE SKIP LOCKED, como os lembretes:
+// réplicas pegam lotes disjuntos e locked_until evita que outra réplica
+// reenvie enquanto o POST está em andamento.
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookRequest, error) {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    query := `
+        WITH due AS (
+            SELECT d.id
+            FROM webhook_deliveries d
+            JOIN webhooks w ON w.id = d.webhook_id
+            WHERE d.status = 'pending' AND w.active
+              AND d.next_attempt_at <= NOW()
+              AND (d.locked_until IS NULL OR d.locked_until <= NOW())
+            ORDER BY d.next_attempt_at
+            LIMIT $1
+            FOR UPDATE OF d SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET locked_until = NOW() + make_interval(secs => $2), attempts = d.attempts + 1, updated_at = NOW()
+        FROM due, webhooks w
+        WHERE d.id = due.id AND w.id = d.webhook_id
+        RETURNING d.id, d.event_id, d.event_type, w.url, w.secret, d.payload, d.attempts
+    `
+    rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Seconds())
+    if err != nil {
+        return nil, ctxError(ctx, err)
+    }
+    defer rows.Close()
+
+    var out []*domain.WebhookRequest
+    for rows.Next() {
+        var req domain.WebhookRequest
+        var payload string
+        err := rows.Scan(
+            &req.DeliveryID,
+            &req.EventID,
+            &req.EventType,
+            &req.URL,
+            &req.Secret,
+            &payload,
+            &req.Attempt,
+        )
+        if err != nil {
+            return nil, ctxError(ctx, err)
+        }
+        req.Payload = []byte(payload)
+        out = append(out, &req)
+    }
+    return out, ctxError(ctx, rows.Err())
+}
+
+// MarkDelivered registra a confirmação do destino.
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id string, status int) error {
+    return r.exec(ctx, `
+        UPDATE webhook_deliveries
+        SET status = 'delivered', last_status_code = $2, last_error = '', delivered_at = NOW(),
+            locked_until = NULL, updated_at = NOW()
+        WHERE id = $1
+    `, id, nullStatus(status))
+}
+
+// MarkRetry registra a falha e agenda a próxima tentativa.
+func (r *WebhookRepo) MarkRetry(ctx context.Context, id string, status int, cause string, at time.Time) error {
+    return r.exec(ctx, `
+        UPDATE webhook_deliveries
+        SET last_status_code = $2, last_error = $3, next_attempt_at = $4, locked_until = NULL, updated_at = NOW()
+        WHERE id = $1
+    `, id, nullStatus(status), cause, at)
+}
+
+// MarkDead move a entrega para a dead letter.
+func (r *WebhookRepo) MarkDead(ctx context.Context, id string, status int, cause string) error {
+    return r.exec(ctx, `
+        UPDATE webhook_deliveries
+        SET status = 'dead', last_status_code = $2, last_error = $3, locked_until = NULL, updated_at = NOW()
+        WHERE id = $1
+    `, id, nullStatus(status), cause)
+}
+
+func (r *WebhookRepo) exec(ctx context.Context, query string, args ...interface{}) error {
+    ctx, cancel := withTimeout(ctx, r.queryTimeout)
+    defer cancel()
+
+    _, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+    return ctxError(ctx, err)
+}
+
+// webhookAffected traduz "nenhuma linha alterada" em domain.ErrWebhookNotFound.
+func webhookAffected(res sql.Result) error {
+    count, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if count == 0 {
+        return domain.ErrWebhookNotFound
+    }
+    return nil
+}
+
+// nullStatus grava NULL quando não houve resposta HTTP.
+func nullStatus(status int) sql.NullInt64 {
+    return sql.NullInt64{Int64: int64(status), Valid: status != 0}
+}
+
+func eventNames(events []domain.EventType) []string {
+    names := make([]string, len(events))
+    for i, e := range events {
+        names[i] = string(e)
+    }
+    return names
+}
+
+// scanWebhook lê uma linha com as colunas de webhookColumns.
+func scanWebhook(row rowScanner) (*domain.Webhook, error) {
+    var w domain.Webhook
+    var events []string
+    if err := row.Scan(
+        &w.ID,
+        &w.UserID,
+        &w.URL,
+        pq.Array(&events),
+        &w.Secret,
+        &w.Active,
+        &w.CreatedAt,
+        &w.UpdatedAt,
+    ); err != nil {
+        return nil, err
+    }
+    w.Events = make([]domain.EventType, len(events))
+    for i, e := range events {
+        w.Events[i] = domain.EventType(e)
+    }
+    return &w, nil
+}
+
+// scanDelivery lê uma linha com as colunas de deliveryColumns.
+func scanDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+    var d domain.WebhookDelivery
+    var next *time.Time
+    var code sql.NullInt64
+    if err := row.Scan(
+        &d.ID,
+        &d.WebhookID,
+        &d.EventID,
+        &d.EventType,
+        &d.Status,
+        &d.Attempts,
+        &next,
+        &code,
+        &d.LastError,
+        &d.DeliveredAt,
+        &d.Payload,
+        &d.CreatedAt,
+        &d.UpdatedAt,
+    ); err != nil {
+        return nil, err
+    }
+    if d.Status == domain.DeliveryPending {
+        d.NextAttemptAt = next
+    }
+    if code.Valid {
+        status := int(code.Int64)
+        d.LastStatusCode = &status
+    }
+    return &d, nil
+}
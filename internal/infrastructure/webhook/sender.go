@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Sender faz o POST das entregas de webhook (domain.WebhookSender).
+type Sender struct {
+    client *http.Client
+    now    func() time.Time
+}
+
+// NewSender recebe o prazo de cada requisição. Redirecionamentos não são
+// seguidos: um 3xx conta como falha, para o corpo assinado não ir parar em
+// outro destino.
+func NewSender(timeout time.Duration) *Sender {
+    return &Sender{
+        client: &http.Client{
+            Timeout: timeout,
+            CheckRedirect: func(*http.Request, []*http.Request) error {
+                return http.ErrUseLastResponse
+            },
+        },
+        now: time.Now,
+    }
+}
+
+// Send assina e envia a entrega; qualquer status fora de 2xx é erro.
+func (s *Sender) Send(ctx context.Context, req *domain.WebhookRequest) (int, error) {
+    httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+    if err != nil {
+        return 0, err
+    }
+    httpReq.Header.Set("Content-Type", "application/json")
+    httpReq.Header.Set("User-Agent", "gopher-tasks-webhooks")
+    httpReq.Header.Set(HeaderEvent, string(req.EventType))
+    httpReq.Header.Set(HeaderEventID, req.EventID)
+    httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+    httpReq.Header.Set(HeaderSignature, Sign(req.Secret, s.now(), req.Payload))
+
+    resp, err := s.client.Do(httpReq)
+    if err != nil {
+        return 0, err
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+    }
+    return resp.StatusCode, nil
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestSenderSignsTheDelivery(t *testing.T) {
+    req := &domain.WebhookRequest{
+        DeliveryID: "d1",
+        EventID:    "e1",
+        EventType:  domain.EventTaskCreated,
+        Secret:     "s3cret",
+        Payload:    []byte(`{"id":"e1"}`),
+        Attempt:    1,
+    }
+    var verifyErr error
+    headers := http.Header{}
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, _ := io.ReadAll(r.Body)
+        headers = r.Header.Clone()
+        verifyErr = Verify("s3cret", r.Header.Get(HeaderSignature), body, time.Now(), DefaultTolerance)
+        w.WriteHeader(http.StatusAccepted)
+    }))
+    defer srv.Close()
+    req.URL = srv.URL
+
+    status, err := NewSender(time.Second).Send(context.Background(), req)
+    if err != nil || status != http.StatusAccepted {
+        t.Fatalf("Send() = %d, %v", status, err)
+    }
+    if verifyErr != nil {
+        t.Errorf("receiver could not verify the signature: %v", verifyErr)
+    }
+    want := map[string]string{
+        "Content-Type": "application/json",
+        HeaderEvent:    string(domain.EventTaskCreated),
+        HeaderEventID:  "e1",
+        HeaderDelivery: "d1",
+    }
+    for k, v := range want {
+        if got := headers.Get(k); got != v {
+            t.Errorf("header %s = %q, want %q", k, got, v)
+        }
+    }
+}
+
+func TestSenderFailures(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusInternalServerError)
+    })
+    mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+        http.Redirect(w, r, "/ok", http.StatusFound)
+    })
+    mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+        t.Error("redirect was followed")
+    })
+    mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+        time.Sleep(200 * time.Millisecond)
+    })
+    srv := httptest.NewServer(mux)
+    defer srv.Close()
+
+    tests := []struct {
+        path   string
+        status int
+    }{
+        {"/error", http.StatusInternalServerError},
+        {"/redirect", http.StatusFound},
+        {"/slow", 0},
+    }
+    sender := NewSender(50 * time.Millisecond)
+    for _, tt := range tests {
+        req := &domain.WebhookRequest{URL: srv.URL + tt.path, Secret: "s", Payload: []byte("{}")}
+        status, err := sender.Send(context.Background(), req)
+        if err == nil || status != tt.status {
+            t.Errorf("Send(%s) = %d, %v; want %d and an error", tt.path, status, err, tt.status)
+        }
+    }
+}
@@ -0,0 +1,78 @@
+// Package webhook envia as entregas de webhook assinadas e oferece a
+// verificação da assinatura para quem as recebe.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cabeçalhos enviados em cada entrega.
+const (
+    HeaderEvent     = "X-Gopher-Tasks-Event"     // tipo do evento, ex.: task.created
+    HeaderEventID   = "X-Gopher-Tasks-Event-Id"  // igual em todas as tentativas; use para deduplicar
+    HeaderDelivery  = "X-Gopher-Tasks-Delivery"  // ID da entrega
+    HeaderSignature = "X-Gopher-Tasks-Signature" // t=<unix>,v1=<hex>
+)
+
+// DefaultTolerance é a diferença máxima aceita por Verify entre o horário da
+// assinatura e o do receptor, para recusar reenvios capturados.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+    ErrMissingSignature = errors.New("webhook: missing or malformed signature")
+    ErrInvalidSignature = errors.New("webhook: signature mismatch")
+    ErrExpiredSignature = errors.New("webhook: signature timestamp outside tolerance")
+)
+
+// Sign devolve o valor de HeaderSignature para body: HMAC-SHA256 com secret
+// sobre "<t>.<body>", onde t é o horário Unix do envio.
+func Sign(secret string, t time.Time, body []byte) string {
+    ts := strconv.FormatInt(t.Unix(), 10)
+    return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify confere header (o valor de HeaderSignature) contra body e secret,
+// recusando assinaturas mais distantes de now do que tolerance.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+    var ts string
+    var sigs [][]byte
+    for _, part := range strings.Split(header, ",") {
+        key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+        switch key {
+        case "t":
+            ts = value
+        case "v1":
+            if sig, err := hex.DecodeString(value); err == nil {
+                sigs = append(sigs, sig)
+            }
+        }
+    }
+    unix, err := strconv.ParseInt(ts, 10, 64)
+    if err != nil || len(sigs) == 0 {
+        return ErrMissingSignature
+    }
+    if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+        return ErrExpiredSignature
+    }
+    expected := mac(secret, ts, body)
+    for _, sig := range sigs {
+        if hmac.Equal(sig, expected) {
+            return nil
+        }
+    }
+    return ErrInvalidSignature
+}
+
+func mac(secret, ts string, body []byte) []byte {
+    h := hmac.New(sha256.New, []byte(secret))
+    h.Write([]byte(ts))
+    h.Write([]byte("."))
+    h.Write(body)
+    return h.Sum(nil)
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+    now := time.Unix(1767225600, 0)
+    body := []byte(`{"type":"task.created"}`)
+    valid := Sign("s3cret", now, body)
+
+    tests := []struct {
+        name   string
+        secret string
+        header string
+        body   []byte
+        now    time.Time
+        want   error
+    }{
+        {name: "valid", secret: "s3cret", header: valid, body: body, now: now},
+        {name: "within tolerance", secret: "s3cret", header: valid, body: body, now: now.Add(DefaultTolerance)},
+        {name: "rotated secret among several", secret: "s3cret", header: Sign("old", now, body) + "," + strings.Split(valid, ",")[1], body: body, now: now},
+        {name: "spaces after commas", secret: "s3cret", header: strings.ReplaceAll(valid, ",", ", "), body: body, now: now},
+        {name: "wrong secret", secret: "other", header: valid, body: body, now: now, want: ErrInvalidSignature},
+        {name: "tampered body", secret: "s3cret", header: valid, body: []byte(`{"type":"task.deleted"}`), now: now, want: ErrInvalidSignature},
+        {name: "tampered timestamp", secret: "s3cret", header: strings.Replace(valid, "t=1767225600", "t=1767225601", 1), body: body, now: now, want: ErrInvalidSignature},
+        {name: "too old", secret: "s3cret", header: valid, body: body, now: now.Add(DefaultTolerance + time.Second), want: ErrExpiredSignature},
+        {name: "from the future", secret: "s3cret", header: valid, body: body, now: now.Add(-DefaultTolerance - time.Second), want: ErrExpiredSignature},
+        {name: "empty", secret: "s3cret", header: "", body: body, now: now, want: ErrMissingSignature},
+        {name: "no v1", secret: "s3cret", header: "t=1767225600", body: body, now: now, want: ErrMissingSignature},
+        {name: "v1 not hex", secret: "s3cret", header: "t=1767225600,v1=zz", body: body, now: now, want: ErrMissingSignature},
+        {name: "no timestamp", secret: "s3cret", header: strings.Split(valid, ",")[1], body: body, now: now, want: ErrMissingSignature},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := Verify(tt.secret, tt.header, tt.body, tt.now, DefaultTolerance)
+            if !errors.Is(err, tt.want) {
+                t.Errorf("Verify() = %v, want %v", err, tt.want)
+            }
+        })
+    }
+}
+
+func TestSignKnownVector(t *testing.T) {
+    // HMAC-SHA256("key", "1700000000.{}"), conferido com openssl dgst -hmac
+    got := Sign("key", time.Unix(1700000000, 0), []byte("{}"))
+    want := "t=1700000000,v1=9d713ed406bb7076d4123f0dc2c39d2df5c654ed4b0cd56b52c8b4c940bd63ae"
+    if got != want {
+        t.Errorf("Sign() = %q, want %q", got, want)
+    }
+}
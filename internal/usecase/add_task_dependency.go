@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// AddTaskDependencyUseCase encapsula a lógica de declarar que uma Task depende de outra.
+type AddTaskDependencyUseCase struct {
+    Repo domain.TaskRepository
+    Deps domain.TaskDependencyRepository
+    Tx   domain.TxManager
+}
+
+// NewAddTaskDependencyUseCase injeta os repositórios de tarefas e de
+// dependências e o gerenciador de transações.
+func NewAddTaskDependencyUseCase(repo domain.TaskRepository, deps domain.TaskDependencyRepository, tx domain.TxManager) *AddTaskDependencyUseCase {
+    return &AddTaskDependencyUseCase{Repo: repo, Deps: deps, Tx: tx}
+}
+
+// Execute faz taskID depender de dependsOnID, bloqueando-a se ela ainda não
+// começou e o pré-requisito está aberto. Retorna a vizinhança atualizada, ou
+// domain.ErrDependencyCycle se a aresta fechar um ciclo.
+func (uc *AddTaskDependencyUseCase) Execute(ctx context.Context, taskID, dependsOnID string) (*domain.TaskDependencies, error) {
+    if _, err := uc.Repo.FindByID(ctx, taskID); err != nil {
+        return nil, err
+    }
+    if dependsOnID == taskID {
+        return nil, domain.NewValidationError(domain.FieldError{Field: "depends_on_id", Message: "must not be the task itself"})
+    }
+    _, err := uc.Repo.FindByID(ctx, dependsOnID)
+    if errors.Is(err, domain.ErrTaskNotFound) {
+        return nil, domain.NewValidationError(domain.FieldError{Field: "depends_on_id", Message: "does not exist"})
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    // A aresta e o bloqueio que ela causa são gravados juntos
+    err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        if err := uc.Deps.Add(ctx, taskID, dependsOnID); err != nil {
+            return err
+        }
+        return uc.Deps.SyncBlocked(ctx, []string{taskID})
+    })
+    if err != nil {
+        return nil, err
+    }
+    return loadTaskDependencies(ctx, uc.Deps, taskID)
+}
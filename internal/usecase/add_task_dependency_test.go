@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestAddTaskDependencyRejectsSelf(t *testing.T) {
+    deps := &fakeDependencyRepo{}
+    uc := NewAddTaskDependencyUseCase(newFakeTaskRepo(&domain.Task{ID: "a"}), deps, nil)
+
+    _, err := uc.Execute(context.Background(), "a", "a")
+    de, ok := domain.AsError(err)
+    if !ok || de.Kind != domain.KindValidation {
+        t.Fatalf("Execute(a, a) = %v, want a validation error", err)
+    }
+    if len(de.Fields) != 1 || de.Fields[0].Field != "depends_on_id" {
+        t.Errorf("fields = %+v, want depends_on_id", de.Fields)
+    }
+    if len(deps.added) != 0 {
+        t.Errorf("edges added: %v", deps.added)
+    }
+}
+
+func TestAddTaskDependencyUnknownPrerequisite(t *testing.T) {
+    deps := &fakeDependencyRepo{}
+    uc := NewAddTaskDependencyUseCase(newFakeTaskRepo(&domain.Task{ID: "a"}), deps, nil)
+
+    _, err := uc.Execute(context.Background(), "a", "b")
+    if de, ok := domain.AsError(err); !ok || de.Kind != domain.KindValidation {
+        t.Fatalf("Execute(a, b) = %v, want a validation error", err)
+    }
+    if len(deps.added) != 0 {
+        t.Errorf("edges added: %v", deps.added)
+    }
+}
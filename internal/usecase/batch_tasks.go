@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// TaskBatchOp identifica a operação de um item do lote.
+type TaskBatchOp string
+
+const (
+    TaskBatchCreate   TaskBatchOp = "create"
+    TaskBatchUpdate   TaskBatchOp = "update" // atualização parcial, como o PATCH
+    TaskBatchDelete   TaskBatchOp = "delete"
+    TaskBatchComplete TaskBatchOp = "complete" // muda o status para done
+)
+
+// TaskBatchOperation é um item do lote. ID vale para update, delete e
+// complete; Create só é usado em create e Patch só em update. Err traz um
+// problema encontrado ao ler o item (ex.: payload inválido): a operação não
+// é executada e falha com ele.
+type TaskBatchOperation struct {
+    Op              TaskBatchOp
+    ID              string
+    Create          CreateTaskInput
+    Patch           TaskPatch
+    ExpectedVersion int // versão esperada em update, delete e complete; zero não confere
+    Err             error
+}
+
+// TaskBatchResult é o resultado de um item: a Task gravada (nil em delete)
+// ou o erro que a operação teria devolvido sozinha.
+type TaskBatchResult struct {
+    ID   string // Task afetada; vazio em create que falhou
+    Task *domain.Task
+    Err  error
+}
+
+// TaskBatchError é devolvido pelo lote atômico: a operação Index falhou e
+// nenhuma foi gravada.
+type TaskBatchError struct {
+    Index int
+    ID    string
+    Err   error
+}
+
+func (e *TaskBatchError) Error() string {
+    return fmt.Sprintf("batch operation %d: %v", e.Index, e.Err)
+}
+
+func (e *TaskBatchError) Unwrap() error {
+    return e.Err
+}
+
+// BatchTasksUseCase aplica várias operações de Task em uma requisição.
+type BatchTasksUseCase struct {
+    Repo          domain.TaskRepository
+    Projects      domain.ProjectRepository
+    Deps          domain.TaskDependencyRepository
+    Users         domain.UserRepository
+    Events        domain.EventPublisher
+    Tx            domain.TxManager
+    MaxOperations int // operações aceitas por lote
+}
+
+// NewBatchTasksUseCase injeta os repositórios usados pelas operações de Task,
+// o publisher dos eventos, o gerenciador de transações e o tamanho máximo do lote.
+func NewBatchTasksUseCase(
+    repo domain.TaskRepository,
+    projects domain.ProjectRepository,
+    deps domain.TaskDependencyRepository,
+    users domain.UserRepository,
+    events domain.EventPublisher,
+    tx domain.TxManager,
+    maxOperations int,
+) *BatchTasksUseCase {
+    return &BatchTasksUseCase{
+        Repo:          repo,
+        Projects:      projects,
+        Deps:          deps,
+        Users:         users,
+        Events:        events,
+        Tx:            tx,
+        MaxOperations: maxOperations,
+    }
+}
+
+// Execute aplica as operações em ordem, com as mesmas regras dos endpoints
+// de uma Task. Com atomic, todas rodam em uma única transação e a primeira
+// que falha desfaz as anteriores e volta como *TaskBatchError; sem atomic,
+// cada operação é gravada por si e os erros ficam nos resultados. Os eventos
+// de cada operação são publicados na transação em que ela é gravada.
+func (uc *BatchTasksUseCase) Execute(ctx context.Context, ops []TaskBatchOperation, atomic bool) ([]TaskBatchResult, error) {
+    if len(ops) == 0 {
+        return nil, domain.NewValidationError(domain.FieldError{Field: "operations", Message: "must not be empty"})
+    }
+    if uc.MaxOperations > 0 && len(ops) > uc.MaxOperations {
+        return nil, domain.NewValidationError(domain.FieldError{
+            Field:   "operations",
+            Message: fmt.Sprintf("must have at most %d items", uc.MaxOperations),
+        })
+    }
+    // Um item inválido derruba o lote atômico antes de qualquer gravação
+    if atomic {
+        for i, op := range ops {
+            if op.Err != nil {
+                return nil, &TaskBatchError{Index: i, ID: op.ID, Err: op.Err}
+            }
+        }
+    }
+    return uc.run(ctx, atomic, func(context.Context) ([]TaskBatchOperation, error) {
+        return ops, nil
+    })
+}
+
+// run executa as operações devolvidas por plan; no modo atômico, plan já
+// roda dentro da transação, para que o que ele lê seja o que é gravado.
+func (uc *BatchTasksUseCase) run(ctx context.Context, atomic bool, plan func(ctx context.Context) ([]TaskBatchOperation, error)) ([]TaskBatchResult, error) {
+    // No modo atômico, os use cases de uma Task entram na transação do lote
+    create := NewCreateTaskUseCase(uc.Repo, uc.Projects, uc.Users, uc.Events, uc.Tx)
+    patch := NewPatchTaskUseCase(uc.Repo, uc.Projects, uc.Deps, uc.Users, uc.Events, uc.Tx)
+    remove := NewDeleteTaskUseCase(uc.Repo, uc.Deps, uc.Events, uc.Tx)
+
+    apply := func(ctx context.Context, op TaskBatchOperation) TaskBatchResult {
+        res := TaskBatchResult{ID: op.ID}
+        if op.Err != nil {
+            res.Err = op.Err
+            return res
+        }
+        switch op.Op {
+        case TaskBatchCreate:
+            res.Task, res.Err = create.Execute(ctx, op.Create)
+        case TaskBatchUpdate:
+            p := op.Patch
+            p.ExpectedVersion = op.ExpectedVersion
+            res.Task, res.Err = patch.Execute(ctx, op.ID, p)
+        case TaskBatchComplete:
+            done := domain.StatusDone
+            res.Task, res.Err = patch.Execute(ctx, op.ID, TaskPatch{Status: &done, ExpectedVersion: op.ExpectedVersion})
+        case TaskBatchDelete:
+            res.Err = remove.Execute(ctx, op.ID, op.ExpectedVersion)
+        default:
+            res.Err = domain.NewValidationError(domain.FieldError{
+                Field:   "op",
+                Message: "must be one of create, update, delete, complete",
+            })
+        }
+        if res.Task != nil {
+            res.ID = res.Task.ID
+        }
+        return res
+    }
+
+    var results []TaskBatchResult
+    if atomic {
+        err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+            ops, err := plan(ctx)
+            if err != nil {
+                return err
+            }
+            results = make([]TaskBatchResult, len(ops))
+            for i, op := range ops {
+                results[i] = apply(ctx, op)
+                if err := results[i].Err; err != nil {
+                    return &TaskBatchError{Index: i, ID: op.ID, Err: err}
+                }
+            }
+            return nil
+        })
+        if err != nil {
+            return nil, err
+        }
+    } else {
+        ops, err := plan(ctx)
+        if err != nil {
+            return nil, err
+        }
+        results = make([]TaskBatchResult, len(ops))
+        for i, op := range ops {
+            results[i] = apply(ctx, op)
+        }
+    }
+    return results, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+type fakeTxKey struct{}
+
+// fakeTx conta as transações abertas; as chamadas aninhadas (os use cases de
+// cada operação) entram na transação externa e não contam.
+type fakeTx struct {
+    calls int
+}
+
+func (f *fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+    if ctx.Value(fakeTxKey{}) == nil {
+        f.calls++
+        ctx = context.WithValue(ctx, fakeTxKey{}, true)
+    }
+    return fn(ctx)
+}
+
+func TestBatchTasksAtomic(t *testing.T) {
+    ops := []TaskBatchOperation{
+        {Op: TaskBatchUpdate, ID: "a", Patch: TaskPatch{Title: ptr("Renamed")}},
+        {Op: TaskBatchComplete, ID: "missing"},
+        {Op: TaskBatchComplete, ID: "b"},
+    }
+    tests := []struct {
+        name string
+        tx   *fakeTx
+    }{
+        {"transaction manager", &fakeTx{}},
+        {"no transaction manager", nil},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            tasks := newFakeTaskRepo(openTask("a"), openTask("b"))
+            uc := NewBatchTasksUseCase(tasks, &fakeProjectRepo{}, &fakeDependencyRepo{}, nil, &fakePublisher{}, nil, 10)
+            if tt.tx != nil {
+                uc.Tx = tt.tx
+            }
+
+            results, err := uc.Execute(context.Background(), ops, true)
+            var batchErr *TaskBatchError
+            if !errors.As(err, &batchErr) || batchErr.Index != 1 || !errors.Is(err, domain.ErrTaskNotFound) {
+                t.Fatalf("Execute() = %v, %v; want operation 1 not found", results, err)
+            }
+            // A operação seguinte à que falhou não roda.
+            if tasks.tasks["b"].Status != domain.StatusTodo {
+                t.Errorf("operation after the failure ran")
+            }
+            if tt.tx != nil && tt.tx.calls != 1 {
+                t.Errorf("WithinTx called %d times, want 1", tt.tx.calls)
+            }
+        })
+    }
+}
+
+func TestBatchTasksNonAtomicKeepsGoing(t *testing.T) {
+    tasks := newFakeTaskRepo(openTask("a"), openTask("b"))
+    uc := NewBatchTasksUseCase(tasks, &fakeProjectRepo{}, &fakeDependencyRepo{}, nil, &fakePublisher{}, nil, 10)
+
+    results, err := uc.Execute(context.Background(), []TaskBatchOperation{
+        {Op: TaskBatchComplete, ID: "missing"},
+        {Op: TaskBatchComplete, ID: "b"},
+    }, false)
+    if err != nil {
+        t.Fatalf("Execute: %v", err)
+    }
+    if !errors.Is(results[0].Err, domain.ErrTaskNotFound) || results[1].Err != nil {
+        t.Fatalf("results = %+v", results)
+    }
+    if results[1].Task.Status != domain.StatusDone {
+        t.Errorf("b = %s, want done", results[1].Task.Status)
+    }
+}
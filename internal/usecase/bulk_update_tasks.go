@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// BulkUpdateTasksUseCase aplica o mesmo patch a todas as Tasks que casam com
+// um filtro (ex.: marcar como blocked as vencidas de um projeto).
+type BulkUpdateTasksUseCase struct {
+    Batch *BatchTasksUseCase
+}
+
+// NewBulkUpdateTasksUseCase reaproveita o use case de lote, que limita
+// quantas Tasks podem ser alteradas de uma vez.
+func NewBulkUpdateTasksUseCase(batch *BatchTasksUseCase) *BulkUpdateTasksUseCase {
+    return &BulkUpdateTasksUseCase{Batch: batch}
+}
+
+// Execute busca as Tasks do filtro e aplica patch a cada uma, com a mesma
+// semântica de atomic de BatchTasksUseCase. Cada Task é gravada na versão
+// lida: se mudou nesse meio-tempo, o resultado dela é um conflito. Um filtro
+// que casa com mais de MaxOperations Tasks é recusado sem alterar nada.
+func (uc *BulkUpdateTasksUseCase) Execute(ctx context.Context, filter domain.TaskFilter, patch TaskPatch, atomic bool) ([]TaskBatchResult, error) {
+    max := uc.Batch.MaxOperations
+    filter.Cursor = ""
+    filter.IncludeTotal = false
+    filter.Limit = max
+
+    return uc.Batch.run(ctx, atomic, func(ctx context.Context) ([]TaskBatchOperation, error) {
+        page, err := uc.Batch.Repo.List(ctx, filter)
+        if err != nil {
+            return nil, err
+        }
+        if page.NextCursor != "" {
+            return nil, domain.NewValidationError(domain.FieldError{
+                Field:   "filter",
+                Message: fmt.Sprintf("matches more than %d tasks; narrow it down", max),
+            })
+        }
+        ops := make([]TaskBatchOperation, len(page.Tasks))
+        for i, t := range page.Tasks {
+            ops[i] = TaskBatchOperation{Op: TaskBatchUpdate, ID: t.ID, Patch: patch, ExpectedVersion: t.Version}
+        }
+        return ops, nil
+    })
+}
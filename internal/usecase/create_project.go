@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// CreateProjectInput reúne os dados de entrada para criação de um Project.
+type CreateProjectInput struct {
+    Name        string
+    Description string
+    Color       string // opcional, #RRGGBB
+}
+
+// CreateProjectUseCase encapsula a lógica de criar um Project.
+type CreateProjectUseCase struct {
+    Repo domain.ProjectRepository
+}
+
+// NewCreateProjectUseCase injeta o repositório de projetos.
+func NewCreateProjectUseCase(repo domain.ProjectRepository) *CreateProjectUseCase {
+    return &CreateProjectUseCase{Repo: repo}
+}
+
+// Execute valida a entrada e cria o Project para o usuário do contexto.
+func (uc *CreateProjectUseCase) Execute(ctx context.Context, in CreateProjectInput) (*domain.Project, error) {
+    userID, _ := domain.UserIDFromContext(ctx)
+    project := &domain.Project{
+        UserID:      userID,
+        Name:        in.Name,
+        Description: in.Description,
+        Color:       in.Color,
+    }
+    if err := project.Validate(); err != nil {
+        return nil, err
+    }
+    if err := uc.Repo.Create(ctx, project); err != nil {
+        return nil, err
+    }
+    return project, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// CreateReminderInput é o gatilho do novo lembrete: At ou BeforeMinutes.
+type CreateReminderInput struct {
+    At            *time.Time
+    BeforeMinutes *int
+}
+
+// CreateReminderUseCase encapsula a lógica de agendar um lembrete para uma Task.
+type CreateReminderUseCase struct {
+    Repo      domain.TaskRepository
+    Reminders domain.ReminderRepository
+}
+
+// NewCreateReminderUseCase injeta os repositórios de tarefas e de lembretes.
+func NewCreateReminderUseCase(repo domain.TaskRepository, reminders domain.ReminderRepository) *CreateReminderUseCase {
+    return &CreateReminderUseCase{Repo: repo, Reminders: reminders}
+}
+
+// Execute valida o gatilho contra a Task e agenda o lembrete.
+func (uc *CreateReminderUseCase) Execute(ctx context.Context, taskID string, in CreateReminderInput) (*domain.Reminder, error) {
+    task, err := uc.Repo.FindByID(ctx, taskID)
+    if err != nil {
+        return nil, err
+    }
+
+    reminder := &domain.Reminder{TaskID: task.ID, At: in.At, BeforeMinutes: in.BeforeMinutes}
+    if err := reminder.Validate(task, time.Now()); err != nil {
+        return nil, err
+    }
+    existing, err := uc.Reminders.ListByTask(ctx, task.ID)
+    if err != nil {
+        return nil, err
+    }
+    if len(existing) >= domain.ReminderMaxPerTask {
+        return nil, domain.NewValidationError(domain.FieldError{
+            Field:   "task_id",
+            Message: fmt.Sprintf("must have at most %d reminders", domain.ReminderMaxPerTask),
+        })
+    }
+
+    if err := uc.Reminders.Create(ctx, reminder); err != nil {
+        return nil, err
+    }
+    return reminder, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// CreateTagInput reúne os dados de entrada para criação de uma Tag.
+type CreateTagInput struct {
+    Name  string
+    Color string // opcional, #RRGGBB
+}
+
+// CreateTagUseCase encapsula a lógica de criar uma Tag.
+type CreateTagUseCase struct {
+    Repo domain.TagRepository
+}
+
+// NewCreateTagUseCase injeta o repositório de tags.
+func NewCreateTagUseCase(repo domain.TagRepository) *CreateTagUseCase {
+    return &CreateTagUseCase{Repo: repo}
+}
+
+// Execute valida a entrada e cria a Tag para o usuário do contexto.
+func (uc *CreateTagUseCase) Execute(ctx context.Context, in CreateTagInput) (*domain.Tag, error) {
+    userID, _ := domain.UserIDFromContext(ctx)
+    tag := &domain.Tag{UserID: userID, Name: in.Name, Color: in.Color}
+    if err := tag.Validate(); err != nil {
+        return nil, err
+    }
+    if err := uc.Repo.Create(ctx, tag); err != nil {
+        return nil, err
+    }
+    return tag, nil
+}
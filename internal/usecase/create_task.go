@@ -1,29 +1,110 @@
 package usecase
 
 import (
+	"context"
 	"time"
 
 	"github.com/rubenfabio/gopher-tasks/internal/domain"
 )
 
+// CreateTaskInput reúne os dados de entrada para criação de uma Task.
+type CreateTaskInput struct {
+    ProjectID        string // opcional; o projeto precisa estar ativo
+    ParentID         string // opcional; cria como subtarefa
+    Title            string
+    Description      string
+    Status           domain.TaskStatus // padrão: todo
+    Priority         domain.Priority   // padrão: medium
+    DueDate          *time.Time        // opcional
+    Tags             []string          // nomes; tags inexistentes são criadas
+    RRule            string            // opcional; torna a Task recorrente (exige DueDate)
+    AllowPastDueDate bool
+}
+
 // CreateTaskUseCase encapsula a lógica de criar uma Task.
 type CreateTaskUseCase struct {
-    Repo domain.TaskRepository
+    Repo     domain.TaskRepository
+    Projects domain.ProjectRepository
+    Users    domain.UserRepository
+    Events   domain.EventPublisher
+    Tx       domain.TxManager
 }
 
-// NewCreateTaskUseCase injeta o repositório de tarefas.
-func NewCreateTaskUseCase(repo domain.TaskRepository) *CreateTaskUseCase {
-    return &CreateTaskUseCase{Repo: repo}
+// NewCreateTaskUseCase injeta os repositórios de tarefas, de projetos e de
+// usuários, o publisher dos eventos de Task e o gerenciador de transações.
+func NewCreateTaskUseCase(
+    repo domain.TaskRepository,
+    projects domain.ProjectRepository,
+    users domain.UserRepository,
+    events domain.EventPublisher,
+    tx domain.TxManager,
+) *CreateTaskUseCase {
+    return &CreateTaskUseCase{Repo: repo, Projects: projects, Users: users, Events: events, Tx: tx}
 }
 
-// Execute cria uma nova Task no repositório e retorna a entidade preenchida.
-func (uc *CreateTaskUseCase) Execute(title, description string, dueDate time.Time) (*domain.Task, error) {
+// Execute valida a entrada, cria uma nova Task no repositório, publica
+// task.created e retorna a entidade preenchida. As verificações do pai e do
+// projeto, a gravação e o evento rodam na mesma transação.
+func (uc *CreateTaskUseCase) Execute(ctx context.Context, in CreateTaskInput) (*domain.Task, error) {
+    var task *domain.Task
+    err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        var err error
+        task, err = uc.create(ctx, in)
+        if err != nil {
+            return err
+        }
+        return publish(ctx, uc.Events, newTaskEvent(domain.EventTaskCreated, task))
+    })
+    if err != nil {
+        return nil, err
+    }
+    return task, nil
+}
+
+// create monta, valida e grava a Task. Roda dentro da transação e pode ser
+// repetida, por isso parte sempre da entrada.
+func (uc *CreateTaskUseCase) create(ctx context.Context, in CreateTaskInput) (*domain.Task, error) {
+    userID, _ := domain.UserIDFromContext(ctx)
     task := &domain.Task{
-        Title:       title,
-        Description: description,
-        DueDate:     dueDate,
+        UserID:      userID,
+        ProjectID:   in.ProjectID,
+        ParentID:    in.ParentID,
+        Title:       in.Title,
+        Description: in.Description,
+        Status:      in.Status,
+        Priority:    in.Priority,
+        DueDate:     in.DueDate,
+        Tags:        in.Tags,
+    }
+    if task.Status == "" {
+        task.Status = domain.StatusTodo
+    }
+    if task.Priority == 0 {
+        task.Priority = domain.PriorityMedium
     }
-    if err := uc.Repo.Create(task); err != nil {
+    task.Completed = task.Status == domain.StatusDone
+    if in.RRule != "" {
+        if err := applyTaskRecurrence(ctx, uc.Users, task, &in.RRule); err != nil {
+            return nil, err
+        }
+    }
+    opts := domain.TaskValidationOptions{Now: time.Now(), AllowPastDueDate: in.AllowPastDueDate}
+    if err := task.Validate(opts); err != nil {
+        return nil, err
+    }
+    parent, err := checkTaskParent(ctx, uc.Repo, task)
+    if err != nil {
+        return nil, err
+    }
+    // Sem projeto explícito, a subtarefa fica no projeto do pai
+    if parent != nil && task.ProjectID == "" {
+        task.ProjectID = parent.ProjectID
+    }
+    if err := checkTaskProject(ctx, uc.Projects, task.ProjectID); err != nil {
+        return nil, err
+    }
+
+    if err := uc.Repo.Create(ctx, task); err != nil {
         return nil, err
     }
     return task, nil
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// CreateWebhookInput reúne os dados de uma nova assinatura.
+type CreateWebhookInput struct {
+    URL    string
+    Events []domain.EventType
+    Secret string // opcional; vazio gera um segredo aleatório
+}
+
+// CreateWebhookUseCase encapsula a lógica de assinar os eventos das Tasks.
+type CreateWebhookUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewCreateWebhookUseCase injeta o repositório de webhooks.
+func NewCreateWebhookUseCase(repo domain.WebhookRepository) *CreateWebhookUseCase {
+    return &CreateWebhookUseCase{Repo: repo}
+}
+
+// Execute valida e cria a assinatura, ativa, para o usuário do contexto. O
+// segredo só pode ser lido na resposta desta chamada.
+func (uc *CreateWebhookUseCase) Execute(ctx context.Context, in CreateWebhookInput) (*domain.Webhook, error) {
+    userID, _ := domain.UserIDFromContext(ctx)
+    webhook := &domain.Webhook{UserID: userID, URL: in.URL, Events: in.Events, Secret: in.Secret, Active: true}
+    if webhook.Secret == "" {
+        secret, err := newWebhookSecret()
+        if err != nil {
+            return nil, err
+        }
+        webhook.Secret = secret
+    }
+    if err := webhook.Validate(); err != nil {
+        return nil, err
+    }
+
+    existing, err := uc.Repo.List(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if len(existing) >= domain.WebhookMaxPerUser {
+        return nil, domain.NewConflictError("at most %d webhooks per user", domain.WebhookMaxPerUser)
+    }
+
+    if err := uc.Repo.Create(ctx, webhook); err != nil {
+        return nil, err
+    }
+    return webhook, nil
+}
+
+// newWebhookSecret gera um segredo de 192 bits.
+func newWebhookSecret() (string, error) {
+    b := make([]byte, 24)
+    if _, err := rand.Read(b); err != nil {
+        return "", fmt.Errorf("generate webhook secret: %w", err)
+    }
+    return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// DeleteProjectUseCase encapsula a lógica de remover um Project.
+type DeleteProjectUseCase struct {
+    Repo domain.ProjectRepository
+}
+
+// NewDeleteProjectUseCase injeta o repositório de projetos.
+func NewDeleteProjectUseCase(repo domain.ProjectRepository) *DeleteProjectUseCase {
+    return &DeleteProjectUseCase{Repo: repo}
+}
+
+// Execute remove o Project. Sem cascade, um projeto com Tasks não é removido
+// (domain.ErrProjectNotEmpty): arquive-o ou confirme a remoção das Tasks.
+func (uc *DeleteProjectUseCase) Execute(ctx context.Context, id string, cascade bool) error {
+    return uc.Repo.Delete(ctx, id, cascade)
+}
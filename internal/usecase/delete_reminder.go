@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// DeleteReminderUseCase encapsula a lógica de cancelar um lembrete.
+type DeleteReminderUseCase struct {
+    Reminders domain.ReminderRepository
+}
+
+// NewDeleteReminderUseCase injeta o repositório de lembretes.
+func NewDeleteReminderUseCase(reminders domain.ReminderRepository) *DeleteReminderUseCase {
+    return &DeleteReminderUseCase{Reminders: reminders}
+}
+
+// Execute remove o lembrete da Task ou retorna domain.ErrReminderNotFound.
+func (uc *DeleteReminderUseCase) Execute(ctx context.Context, taskID, id string) error {
+    return uc.Reminders.Delete(ctx, taskID, id)
+}
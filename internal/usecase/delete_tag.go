@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// DeleteTagUseCase encapsula a lógica de remover uma Tag.
+type DeleteTagUseCase struct {
+    Repo domain.TagRepository
+}
+
+// NewDeleteTagUseCase injeta o repositório de tags.
+func NewDeleteTagUseCase(repo domain.TagRepository) *DeleteTagUseCase {
+    return &DeleteTagUseCase{Repo: repo}
+}
+
+// Execute remove a Tag (e suas associações) ou retorna domain.ErrTagNotFound.
+func (uc *DeleteTagUseCase) Execute(ctx context.Context, id string) error {
+    return uc.Repo.Delete(ctx, id)
+}
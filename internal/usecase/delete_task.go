@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// DeleteTaskUseCase encapsula a lógica de remover uma Task.
+type DeleteTaskUseCase struct {
+    Repo   domain.TaskRepository
+    Deps   domain.TaskDependencyRepository
+    Events domain.EventPublisher
+    Tx     domain.TxManager
+}
+
+// NewDeleteTaskUseCase injeta os repositórios de tarefas e de dependências,
+// o publisher dos eventos de Task e o gerenciador de transações.
+func NewDeleteTaskUseCase(
+    repo domain.TaskRepository,
+    deps domain.TaskDependencyRepository,
+    events domain.EventPublisher,
+    tx domain.TxManager,
+) *DeleteTaskUseCase {
+    return &DeleteTaskUseCase{Repo: repo, Deps: deps, Events: events, Tx: tx}
+}
+
+// Execute remove a Task (e suas subtarefas) ou retorna domain.ErrTaskNotFound
+// se ela não existir. Quem dependia das Tasks removidas é desbloqueado se não
+// restar outro pré-requisito aberto, e cada Task removida gera task.deleted.
+// expectedVersion diferente de zero exige que a Task esteja nessa versão.
+// A remoção, o desbloqueio dos dependentes e os eventos rodam na mesma transação.
+func (uc *DeleteTaskUseCase) Execute(ctx context.Context, id string, expectedVersion int) error {
+    return withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        removed, err := uc.delete(ctx, id, expectedVersion)
+        if err != nil {
+            return err
+        }
+        events := make([]*domain.Event, len(removed))
+        for i, t := range removed {
+            events[i] = newTaskEvent(domain.EventTaskDeleted, t)
+        }
+        return publish(ctx, uc.Events, events...)
+    })
+}
+
+// delete remove a Task e as subtarefas e devolve todas as removidas.
+func (uc *DeleteTaskUseCase) delete(ctx context.Context, id string, expectedVersion int) ([]*domain.Task, error) {
+    task, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if err := checkTaskVersion(task, expectedVersion); err != nil {
+        return nil, err
+    }
+    removed, err := uc.Repo.Descendants(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    removed = append(removed, task)
+
+    var dependents []*domain.Task
+    for _, t := range removed {
+        blocks, err := uc.Deps.Blocks(ctx, t.ID)
+        if err != nil {
+            return nil, err
+        }
+        dependents = append(dependents, blocks...)
+    }
+
+    // A versão lida é conferida de novo ao apagar: uma alteração feita
+    // depois da leitura não é descartada em silêncio
+    if err := uc.Repo.Delete(ctx, id, task.Version); err != nil {
+        return nil, err
+    }
+    if err := syncBlocked(ctx, uc.Deps, dependents); err != nil {
+        return nil, err
+    }
+    return removed, nil
+}
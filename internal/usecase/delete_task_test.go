@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestDeleteTaskChecksVersion(t *testing.T) {
+    tests := []struct {
+        name     string
+        expected int  // If-Match
+        race     bool // outra gravação entre a leitura e a remoção
+        wantErr  error
+        wantKind domain.ErrorKind
+    }{
+        {name: "without If-Match", expected: 0},
+        {name: "matching If-Match", expected: 2},
+        {name: "stale If-Match", expected: 1, wantKind: domain.KindPrecondition},
+        {name: "change after the read", expected: 2, race: true, wantErr: domain.ErrTaskVersionConflict},
+        {name: "change after the read without If-Match", race: true, wantErr: domain.ErrTaskVersionConflict},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            parent, child := openTask("a"), openTask("b")
+            parent.Version = 2
+            child.ParentID = "a"
+            tasks := newFakeTaskRepo(parent, child)
+            if tt.race {
+                // Um PUT grava a Task depois que o use case a leu.
+                tasks.beforeDelete = func() { tasks.tasks["a"].Version++ }
+            }
+            events := &fakePublisher{}
+            uc := NewDeleteTaskUseCase(tasks, &fakeDependencyRepo{}, events, nil)
+
+            err := uc.Execute(context.Background(), "a", tt.expected)
+            switch {
+            case tt.wantErr != nil:
+                if !errors.Is(err, tt.wantErr) {
+                    t.Fatalf("Execute() = %v, want %v", err, tt.wantErr)
+                }
+            case tt.wantKind != "":
+                if de, ok := domain.AsError(err); !ok || de.Kind != tt.wantKind {
+                    t.Fatalf("Execute() = %v, want a %s error", err, tt.wantKind)
+                }
+            default:
+                if err != nil {
+                    t.Fatalf("Execute: %v", err)
+                }
+                sort.Strings(tasks.deleted)
+                if fmt.Sprint(tasks.deleted) != "[a b]" || len(events.events) != 2 {
+                    t.Errorf("deleted %v with events %v, want a and its subtask", tasks.deleted, eventTypes(events.events))
+                }
+                return
+            }
+            if len(tasks.tasks) != 2 || len(events.events) != 0 {
+                t.Errorf("a failed delete removed %v and published %v", tasks.deleted, eventTypes(events.events))
+            }
+        })
+    }
+}
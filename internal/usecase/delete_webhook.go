@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// DeleteWebhookUseCase encapsula a lógica de cancelar uma assinatura.
+type DeleteWebhookUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewDeleteWebhookUseCase injeta o repositório de webhooks.
+func NewDeleteWebhookUseCase(repo domain.WebhookRepository) *DeleteWebhookUseCase {
+    return &DeleteWebhookUseCase{Repo: repo}
+}
+
+// Execute remove a assinatura, com as entregas pendentes e o histórico, ou
+// retorna domain.ErrWebhookNotFound se ela não existir.
+func (uc *DeleteWebhookUseCase) Execute(ctx context.Context, id string) error {
+    return uc.Repo.Delete(ctx, id)
+}
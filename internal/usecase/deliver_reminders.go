@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Espera entre tentativas de entrega de um lembrete: dobra a cada falha,
+// até reminderMaxRetryDelay.
+const (
+    reminderRetryDelay    = time.Minute
+    reminderMaxRetryDelay = time.Hour
+)
+
+// ReminderDelivery resume uma rodada de entregas.
+type ReminderDelivery struct {
+    Sent    int // entregues
+    Retried int // falharam e serão tentados de novo
+    Failed  int // falharam pela última vez
+}
+
+// DeliverRemindersUseCase encapsula a entrega dos lembretes vencidos; é
+// chamado periodicamente pelo scheduler de cada réplica do servidor.
+type DeliverRemindersUseCase struct {
+    Reminders   domain.ReminderRepository
+    Notifier    domain.Notifier
+    BatchSize   int           // lembretes reservados por consulta
+    MaxAttempts int           // tentativas antes de desistir de um lembrete
+    Lease       time.Duration // reserva de um lembrete enquanto é entregue
+}
+
+// NewDeliverRemindersUseCase injeta o repositório de lembretes, o canal de
+// entrega e os limites de cada rodada.
+func NewDeliverRemindersUseCase(
+    reminders domain.ReminderRepository,
+    notifier domain.Notifier,
+    batchSize, maxAttempts int,
+    lease time.Duration,
+) *DeliverRemindersUseCase {
+    return &DeliverRemindersUseCase{
+        Reminders:   reminders,
+        Notifier:    notifier,
+        BatchSize:   batchSize,
+        MaxAttempts: maxAttempts,
+        Lease:       lease,
+    }
+}
+
+// Execute reserva e entrega lotes de lembretes até não sobrar nenhum vencido.
+// A entrega é "pelo menos uma vez": se o servidor cair entre notificar e
+// registrar o envio, o lembrete é reenviado quando a reserva expirar.
+func (uc *DeliverRemindersUseCase) Execute(ctx context.Context) (ReminderDelivery, error) {
+    var out ReminderDelivery
+    for ctx.Err() == nil {
+        batch, err := uc.Reminders.ClaimDue(ctx, uc.BatchSize, uc.Lease)
+        if err != nil {
+            return out, err
+        }
+        for _, n := range batch {
+            if err := uc.deliver(ctx, n, &out); err != nil {
+                return out, err
+            }
+        }
+        if len(batch) == 0 || len(batch) < uc.BatchSize {
+            break
+        }
+    }
+    return out, ctx.Err()
+}
+
+func (uc *DeliverRemindersUseCase) deliver(ctx context.Context, n *domain.ReminderNotification, out *ReminderDelivery) error {
+    now := time.Now()
+    n.Overdue = n.DueDate != nil && now.After(*n.DueDate)
+
+    err := uc.Notifier.Notify(ctx, n)
+    switch {
+    case err == nil:
+        out.Sent++
+        return uc.Reminders.MarkSent(ctx, n.ReminderID)
+    case n.Attempt >= uc.MaxAttempts:
+        out.Failed++
+        return uc.Reminders.MarkFailed(ctx, n.ReminderID, err.Error())
+    default:
+        out.Retried++
+        return uc.Reminders.MarkRetry(ctx, n.ReminderID, err.Error(), now.Add(reminderBackoff(n.Attempt)))
+    }
+}
+
+// reminderBackoff é a espera antes da tentativa seguinte à de número attempt.
+func reminderBackoff(attempt int) time.Duration {
+    delay := reminderRetryDelay
+    for i := 1; i < attempt && delay < reminderMaxRetryDelay; i++ {
+        delay *= 2
+    }
+    if delay > reminderMaxRetryDelay {
+        delay = reminderMaxRetryDelay
+    }
+    return delay
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func TestDeliverReminders(t *testing.T) {
+    past := time.Now().Add(-time.Hour)
+    future := time.Now().Add(time.Hour)
+    repo := &fakeReminderRepo{due: []*domain.ReminderNotification{
+        {ReminderID: "ok", Attempt: 1, DueDate: &future},
+        {ReminderID: "late", Attempt: 1, DueDate: &past},
+        {ReminderID: "retry", Attempt: 2},
+        {ReminderID: "give-up", Attempt: 3},
+        {ReminderID: "ok-2", Attempt: 3},
+    }}
+    notifier := &fakeNotifier{fail: map[string]bool{"retry": true, "give-up": true}}
+    uc := NewDeliverRemindersUseCase(repo, notifier, 2, 3, time.Minute)
+
+    before := time.Now()
+    got, err := uc.Execute(context.Background())
+    if err != nil {
+        t.Fatalf("Execute: %v", err)
+    }
+
+    if want := (ReminderDelivery{Sent: 3, Retried: 1, Failed: 1}); got != want {
+        t.Errorf("result = %+v, want %+v", got, want)
+    }
+    // Lotes de 2 até um lote incompleto
+    if fmt.Sprint(repo.claims) != "[2 2 1]" {
+        t.Errorf("claims = %v, want [2 2 1]", repo.claims)
+    }
+    if fmt.Sprint(repo.sent) != "[ok late ok-2]" {
+        t.Errorf("sent = %v", repo.sent)
+    }
+    at, ok := repo.retried["retry"]
+    if !ok || len(repo.retried) != 1 {
+        t.Fatalf("retried = %v, want only retry", repo.retried)
+    }
+    // Segunda tentativa: espera o dobro do atraso inicial
+    if delay := at.Sub(before); delay < 2*reminderRetryDelay || delay > 2*reminderRetryDelay+time.Second {
+        t.Errorf("retry scheduled in %v, want %v", delay, 2*reminderRetryDelay)
+    }
+    if repo.failed["give-up"] != "channel down" || len(repo.failed) != 1 {
+        t.Errorf("failed = %v, want give-up", repo.failed)
+    }
+
+    overdue := map[string]bool{}
+    for _, n := range notifier.notified {
+        overdue[n.ReminderID] = n.Overdue
+    }
+    if overdue["ok"] || !overdue["late"] || overdue["retry"] {
+        t.Errorf("overdue = %v, want only late", overdue)
+    }
+}
+
+func TestDeliverRemindersStopsOnCanceledContext(t *testing.T) {
+    repo := &fakeReminderRepo{due: []*domain.ReminderNotification{{ReminderID: "a", Attempt: 1}}}
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    uc := NewDeliverRemindersUseCase(repo, &fakeNotifier{}, 10, 3, time.Minute)
+    if _, err := uc.Execute(ctx); err != context.Canceled {
+        t.Fatalf("Execute = %v, want context.Canceled", err)
+    }
+    if len(repo.claims) != 0 {
+        t.Errorf("claimed %v after cancellation", repo.claims)
+    }
+}
+
+func TestReminderBackoff(t *testing.T) {
+    tests := []struct {
+        attempt int
+        want    time.Duration
+    }{
+        {1, time.Minute},
+        {2, 2 * time.Minute},
+        {3, 4 * time.Minute},
+        {7, 60 * time.Minute},
+        {100, reminderMaxRetryDelay},
+    }
+    for _, tt := range tests {
+        if got := reminderBackoff(tt.attempt); got != tt.want {
+            t.Errorf("reminderBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+        }
+    }
+}
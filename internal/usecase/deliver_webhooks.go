@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Espera entre tentativas de uma entrega de webhook: dobra a cada falha, até
+// webhookMaxRetryDelay.
+const (
+    webhookRetryDelay    = 30 * time.Second
+    webhookMaxRetryDelay = time.Hour
+)
+
+// WebhookDeliveryResult resume uma rodada de entregas.
+type WebhookDeliveryResult struct {
+    Delivered int // o destino respondeu 2xx
+    Retried   int // falharam e serão tentadas de novo
+    Dead      int // falharam pela última vez (dead letter)
+}
+
+// DeliverWebhooksUseCase encapsula o envio das entregas pendentes; é chamado
+// periodicamente pelo worker de cada réplica do servidor.
+type DeliverWebhooksUseCase struct {
+    Repo        domain.WebhookRepository
+    Sender      domain.WebhookSender
+    BatchSize   int           // entregas reservadas por consulta
+    MaxAttempts int           // tentativas antes da dead letter
+    Lease       time.Duration // reserva de uma entrega enquanto o POST está em andamento
+}
+
+// NewDeliverWebhooksUseCase injeta o repositório de webhooks, o cliente que
+// faz os POSTs e os limites de cada rodada.
+func NewDeliverWebhooksUseCase(
+    repo domain.WebhookRepository,
+    sender domain.WebhookSender,
+    batchSize, maxAttempts int,
+    lease time.Duration,
+) *DeliverWebhooksUseCase {
+    return &DeliverWebhooksUseCase{
+        Repo:        repo,
+        Sender:      sender,
+        BatchSize:   batchSize,
+        MaxAttempts: maxAttempts,
+        Lease:       lease,
+    }
+}
+
+// Execute reserva e envia lotes até não sobrar entrega vencida. A entrega é
+// "pelo menos uma vez": receptores devem deduplicar pelo ID do evento.
+func (uc *DeliverWebhooksUseCase) Execute(ctx context.Context) (WebhookDeliveryResult, error) {
+    var out WebhookDeliveryResult
+    for ctx.Err() == nil {
+        batch, err := uc.Repo.ClaimDue(ctx, uc.BatchSize, uc.Lease)
+        if err != nil {
+            return out, err
+        }
+        for _, req := range batch {
+            if err := uc.deliver(ctx, req, &out); err != nil {
+                return out, err
+            }
+        }
+        if len(batch) == 0 || len(batch) < uc.BatchSize {
+            break
+        }
+    }
+    return out, ctx.Err()
+}
+
+func (uc *DeliverWebhooksUseCase) deliver(ctx context.Context, req *domain.WebhookRequest, out *WebhookDeliveryResult) error {
+    status, err := uc.Sender.Send(ctx, req)
+    switch {
+    case err == nil:
+        out.Delivered++
+        return uc.Repo.MarkDelivered(ctx, req.DeliveryID, status)
+    case req.Attempt >= uc.MaxAttempts:
+        out.Dead++
+        return uc.Repo.MarkDead(ctx, req.DeliveryID, status, err.Error())
+    default:
+        out.Retried++
+        return uc.Repo.MarkRetry(ctx, req.DeliveryID, status, err.Error(), time.Now().Add(webhookBackoff(req.Attempt)))
+    }
+}
+
+// webhookBackoff é a espera antes da tentativa seguinte à de número attempt.
+func webhookBackoff(attempt int) time.Duration {
+    delay := webhookRetryDelay
+    for i := 1; i < attempt && delay < webhookMaxRetryDelay; i++ {
+        delay *= 2
+    }
+    if delay > webhookMaxRetryDelay {
+        delay = webhookMaxRetryDelay
+    }
+    return delay
+}
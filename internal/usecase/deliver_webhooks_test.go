@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+	"github.com/rubenfabio/gopher-tasks/internal/infrastructure/webhook"
+)
+
+// webhookReceiver é um destino de webhooks que confere as assinaturas e
+// responde com status enquanto ele for diferente de zero (senão 204).
+type webhookReceiver struct {
+    mu        sync.Mutex
+    status    int
+    received  []string // X-Gopher-Tasks-Event-Id de cada POST
+    badSigned int
+}
+
+func (rv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    rv.mu.Lock()
+    defer rv.mu.Unlock()
+    body, _ := io.ReadAll(r.Body)
+    if webhook.Verify("s3cret", r.Header.Get(webhook.HeaderSignature), body, time.Now(), webhook.DefaultTolerance) != nil {
+        rv.badSigned++
+    }
+    rv.received = append(rv.received, r.Header.Get(webhook.HeaderEventID))
+    if rv.status != 0 {
+        w.WriteHeader(rv.status)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (rv *webhookReceiver) setStatus(status int) {
+    rv.mu.Lock()
+    rv.status = status
+    rv.mu.Unlock()
+}
+
+func newWebhookFixture(t *testing.T, status int) (*webhookReceiver, *fakeWebhookRepo, *DeliverWebhooksUseCase) {
+    rv := &webhookReceiver{status: status}
+    srv := httptest.NewServer(rv)
+    t.Cleanup(srv.Close)
+    repo := &fakeWebhookRepo{url: srv.URL, secret: "s3cret", deliveries: []*domain.WebhookDelivery{
+        {ID: "d1", WebhookID: "w1", EventID: "e1", EventType: domain.EventTaskCreated, Status: domain.DeliveryPending, Payload: `{"id":"e1"}`},
+    }}
+    return rv, repo, NewDeliverWebhooksUseCase(repo, webhook.NewSender(time.Second), 10, 3, time.Minute)
+}
+
+func TestDeliverWebhooksRetriesThenDelivers(t *testing.T) {
+    rv, repo, uc := newWebhookFixture(t, http.StatusServiceUnavailable)
+    ctx := context.Background()
+
+    for i := 0; i < 2; i++ {
+        got, err := uc.Execute(ctx)
+        if err != nil {
+            t.Fatalf("Execute: %v", err)
+        }
+        if got != (WebhookDeliveryResult{Retried: 1}) {
+            t.Fatalf("round %d = %+v, want one retry", i+1, got)
+        }
+    }
+    // A espera dobra a cada falha
+    if fmt.Sprint(repo.retryAt) != fmt.Sprint([]time.Duration{webhookRetryDelay, 2 * webhookRetryDelay}) {
+        t.Errorf("retry delays = %v", repo.retryAt)
+    }
+    if d := repo.deliveries[0]; *d.LastStatusCode != http.StatusServiceUnavailable || d.LastError == "" {
+        t.Errorf("last attempt = %d %q", *d.LastStatusCode, d.LastError)
+    }
+
+    rv.setStatus(0)
+    got, err := uc.Execute(ctx)
+    if err != nil || got != (WebhookDeliveryResult{Delivered: 1}) {
+        t.Fatalf("third round = %+v, %v; want delivered", got, err)
+    }
+    if d := repo.deliveries[0]; d.Status != domain.DeliveryDelivered || d.Attempts != 3 {
+        t.Errorf("delivery = %s after %d attempts", d.Status, d.Attempts)
+    }
+    // Todas as tentativas levam o mesmo ID de evento e assinaturas válidas
+    if fmt.Sprint(rv.received) != "[e1 e1 e1]" || rv.badSigned != 0 {
+        t.Errorf("receiver got %v, %d badly signed", rv.received, rv.badSigned)
+    }
+}
+
+func TestDeliverWebhooksDeadLetterAndRedelivery(t *testing.T) {
+    rv, repo, uc := newWebhookFixture(t, http.StatusInternalServerError)
+    ctx := context.Background()
+
+    var total WebhookDeliveryResult
+    for i := 0; i < 4; i++ {
+        got, err := uc.Execute(ctx)
+        if err != nil {
+            t.Fatalf("Execute: %v", err)
+        }
+        total.Retried += got.Retried
+        total.Dead += got.Dead
+    }
+    // Três tentativas; depois da dead letter a entrega sai da fila
+    if total != (WebhookDeliveryResult{Retried: 2, Dead: 1}) || len(rv.received) != 3 {
+        t.Fatalf("result = %+v after %d POSTs, want 2 retries and a dead letter", total, len(rv.received))
+    }
+    if d := repo.deliveries[0]; d.Status != domain.DeliveryDead || *d.LastStatusCode != http.StatusInternalServerError {
+        t.Fatalf("delivery = %s (%d), want dead", d.Status, *d.LastStatusCode)
+    }
+
+    // O destino voltou: o reenvio manual põe a entrega de novo na fila
+    rv.setStatus(0)
+    d, err := NewRedeliverWebhookUseCase(repo).Execute(ctx, "w1", "d1")
+    if err != nil || d.Status != domain.DeliveryPending {
+        t.Fatalf("Redeliver = %+v, %v", d, err)
+    }
+    got, err := uc.Execute(ctx)
+    if err != nil || got != (WebhookDeliveryResult{Delivered: 1}) {
+        t.Fatalf("after redelivery = %+v, %v; want delivered", got, err)
+    }
+    if repo.deliveries[0].Status != domain.DeliveryDelivered {
+        t.Errorf("delivery = %s, want delivered", repo.deliveries[0].Status)
+    }
+}
+
+func TestWebhookBackoff(t *testing.T) {
+    tests := []struct {
+        attempt int
+        want    time.Duration
+    }{
+        {1, 30 * time.Second},
+        {2, time.Minute},
+        {3, 2 * time.Minute},
+        {8, time.Hour},
+        {50, webhookMaxRetryDelay},
+    }
+    for _, tt := range tests {
+        if got := webhookBackoff(tt.attempt); got != tt.want {
+            t.Errorf("webhookBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+        }
+    }
+}
@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// fakeTaskRepo guarda as Tasks em memória; os métodos não usados pelos
+// testes caem na interface embutida (nil) e entram em pânico.
+type fakeTaskRepo struct {
+    domain.TaskRepository
+    tasks   map[string]*domain.Task
+    created []*domain.Task
+    writes  [][]domain.TaskField // colunas de cada gravação (nil = todas)
+    deleted []string
+    // beforeDelete, se definido, roda antes de Delete conferir a versão
+    // (simula uma gravação concorrente entre a leitura e a remoção)
+    beforeDelete func()
+}
+
+func newFakeTaskRepo(tasks ...*domain.Task) *fakeTaskRepo {
+    r := &fakeTaskRepo{tasks: map[string]*domain.Task{}}
+    for _, t := range tasks {
+        r.tasks[t.ID] = t
+    }
+    return r
+}
+
+func (r *fakeTaskRepo) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+    t, ok := r.tasks[id]
+    if !ok {
+        return nil, domain.ErrTaskNotFound
+    }
+    clone := *t
+    return &clone, nil
+}
+
+func (r *fakeTaskRepo) Create(ctx context.Context, t *domain.Task) error {
+    t.ID = fmt.Sprintf("task-%d", len(r.tasks)+1)
+    t.Version = 1
+    if t.Recurrence != nil && t.Recurrence.SeriesID == "" {
+        t.Recurrence.SeriesID = t.ID
+    }
+    r.created = append(r.created, t)
+    clone := *t
+    r.tasks[t.ID] = &clone
+    return nil
+}
+
+func (r *fakeTaskRepo) Update(ctx context.Context, t *domain.Task) error {
+    return r.UpdateFields(ctx, t, nil)
+}
+
+// UpdateFields confere a versão como o repositório real; fields nil é Update.
+func (r *fakeTaskRepo) UpdateFields(ctx context.Context, t *domain.Task, fields []domain.TaskField) error {
+    stored, ok := r.tasks[t.ID]
+    if !ok {
+        return domain.ErrTaskNotFound
+    }
+    if stored.Version != t.Version {
+        return domain.ErrTaskVersionConflict
+    }
+    t.Version++
+    r.writes = append(r.writes, fields)
+    clone := *t
+    r.tasks[t.ID] = &clone
+    return nil
+}
+
+// Delete confere a versão como o repositório real e remove a Task e as subtarefas.
+func (r *fakeTaskRepo) Delete(ctx context.Context, id string, version int) error {
+    if r.beforeDelete != nil {
+        r.beforeDelete()
+    }
+    stored, ok := r.tasks[id]
+    if !ok {
+        return domain.ErrTaskNotFound
+    }
+    if stored.Version != version {
+        return domain.ErrTaskVersionConflict
+    }
+    descendants, _ := r.Descendants(ctx, id)
+    for _, t := range append(descendants, stored) {
+        delete(r.tasks, t.ID)
+        r.deleted = append(r.deleted, t.ID)
+    }
+    return nil
+}
+
+func (r *fakeTaskRepo) Descendants(ctx context.Context, id string) ([]*domain.Task, error) {
+    var out []*domain.Task
+    for _, t := range r.tasks {
+        if t.ParentID == id {
+            below, _ := r.Descendants(ctx, t.ID)
+            clone := *t
+            out = append(out, append([]*domain.Task{&clone}, below...)...)
+        }
+    }
+    return out, nil
+}
+
+// fakeProjectRepo conhece apenas os IDs dos projetos existentes.
+type fakeProjectRepo struct {
+    domain.ProjectRepository
+    ids map[string]bool
+}
+
+func (r *fakeProjectRepo) FindByID(ctx context.Context, id string) (*domain.Project, error) {
+    if !r.ids[id] {
+        return nil, domain.ErrProjectNotFound
+    }
+    return &domain.Project{ID: id}, nil
+}
+
+// fakeDependencyRepo registra as arestas criadas e devolve um grafo fixo.
+type fakeDependencyRepo struct {
+    domain.TaskDependencyRepository
+    added     []domain.TaskDependency
+    tasks     []*domain.Task
+    edges     []domain.TaskDependency
+    blockedBy map[string][]*domain.Task
+    blocks    map[string][]*domain.Task
+    synced    [][]string
+}
+
+func (r *fakeDependencyRepo) Add(ctx context.Context, taskID, dependsOnID string) error {
+    r.added = append(r.added, domain.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID})
+    return nil
+}
+
+func (r *fakeDependencyRepo) BlockedBy(ctx context.Context, taskID string) ([]*domain.Task, error) {
+    return r.blockedBy[taskID], nil
+}
+
+func (r *fakeDependencyRepo) Blocks(ctx context.Context, taskID string) ([]*domain.Task, error) {
+    return r.blocks[taskID], nil
+}
+
+func (r *fakeDependencyRepo) SyncBlocked(ctx context.Context, taskIDs []string) error {
+    r.synced = append(r.synced, taskIDs)
+    return nil
+}
+
+func (r *fakeDependencyRepo) ProjectGraph(ctx context.Context, projectID string) ([]*domain.Task, []domain.TaskDependency, error) {
+    return r.tasks, r.edges, nil
+}
+
+// fakeReminderRepo entrega os lembretes de due em lotes e registra as marcações.
+type fakeReminderRepo struct {
+    domain.ReminderRepository
+    due     []*domain.ReminderNotification
+    claims  []int // tamanho de cada lote reservado
+    sent    []string
+    retried map[string]time.Time
+    failed  map[string]string
+}
+
+func (r *fakeReminderRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.ReminderNotification, error) {
+    n := min(limit, len(r.due))
+    batch := r.due[:n]
+    r.due = r.due[n:]
+    r.claims = append(r.claims, n)
+    return batch, nil
+}
+
+func (r *fakeReminderRepo) MarkSent(ctx context.Context, id string) error {
+    r.sent = append(r.sent, id)
+    return nil
+}
+
+func (r *fakeReminderRepo) MarkRetry(ctx context.Context, id, cause string, at time.Time) error {
+    if r.retried == nil {
+        r.retried = map[string]time.Time{}
+    }
+    r.retried[id] = at
+    return nil
+}
+
+func (r *fakeReminderRepo) MarkFailed(ctx context.Context, id, cause string) error {
+    if r.failed == nil {
+        r.failed = map[string]string{}
+    }
+    r.failed[id] = cause
+    return nil
+}
+
+// fakeNotifier falha para os lembretes em fail e guarda os recebidos.
+type fakeNotifier struct {
+    fail     map[string]bool
+    notified []*domain.ReminderNotification
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, rn *domain.ReminderNotification) error {
+    n.notified = append(n.notified, rn)
+    if n.fail[rn.ReminderID] {
+        return errors.New("channel down")
+    }
+    return nil
+}
+
+// fakeWebhookRepo é a fila de entregas em memória de uma única assinatura.
+// ClaimDue ignora o horário agendado, como se a espera já tivesse passado.
+type fakeWebhookRepo struct {
+    domain.WebhookRepository
+    url, secret string
+    deliveries  []*domain.WebhookDelivery
+    retryAt     []time.Duration // espera agendada por MarkRetry, relativa ao agendamento
+}
+
+func (r *fakeWebhookRepo) find(id string) *domain.WebhookDelivery {
+    for _, d := range r.deliveries {
+        if d.ID == id {
+            return d
+        }
+    }
+    return nil
+}
+
+func (r *fakeWebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookRequest, error) {
+    var out []*domain.WebhookRequest
+    for _, d := range r.deliveries {
+        if d.Status != domain.DeliveryPending || len(out) == limit {
+            continue
+        }
+        d.Attempts++
+        out = append(out, &domain.WebhookRequest{
+            DeliveryID: d.ID,
+            EventID:    d.EventID,
+            EventType:  d.EventType,
+            URL:        r.url,
+            Secret:     r.secret,
+            Payload:    []byte(d.Payload),
+            Attempt:    d.Attempts,
+        })
+    }
+    return out, nil
+}
+
+func (r *fakeWebhookRepo) MarkDelivered(ctx context.Context, id string, status int) error {
+    d := r.find(id)
+    d.Status, d.LastStatusCode = domain.DeliveryDelivered, &status
+    return nil
+}
+
+func (r *fakeWebhookRepo) MarkRetry(ctx context.Context, id string, status int, cause string, at time.Time) error {
+    d := r.find(id)
+    d.LastStatusCode, d.LastError = &status, cause
+    r.retryAt = append(r.retryAt, time.Until(at).Round(time.Second))
+    return nil
+}
+
+func (r *fakeWebhookRepo) MarkDead(ctx context.Context, id string, status int, cause string) error {
+    d := r.find(id)
+    d.Status, d.LastStatusCode, d.LastError = domain.DeliveryDead, &status, cause
+    return nil
+}
+
+func (r *fakeWebhookRepo) Redeliver(ctx context.Context, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+    d := r.find(deliveryID)
+    if d == nil {
+        return nil, domain.ErrWebhookDeliveryNotFound
+    }
+    d.Status = domain.DeliveryPending
+    clone := *d
+    return &clone, nil
+}
+
+// fakePublisher guarda os eventos publicados.
+type fakePublisher struct {
+    events []*domain.Event
+    err    error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, events ...*domain.Event) error {
+    if p.err != nil {
+        return p.err
+    }
+    p.events = append(p.events, events...)
+    return nil
+}
+
+func eventTypes(events []*domain.Event) []domain.EventType {
+    out := []domain.EventType{}
+    for _, e := range events {
+        out = append(out, e.Type)
+    }
+    return out
+}
+
+// fakeOutboxRepo guarda a outbox em memória. Como o OutboxRepo, ClaimPending
+// reserva até limit eventos, em ordem de ID, das Tasks cujo evento pendente
+// mais antigo está livre e na hora; a reserva dura até Mark* ou Release e a
+// hora é now.
+type fakeOutboxRepo struct {
+    domain.OutboxRepository
+    now         time.Time
+    entries     []*fakeOutboxRow
+    claims      []int       // eventos reservados por ClaimPending
+    publishedAt []time.Time // published_at das linhas que DeletePublished coleta
+    deletes     []int       // limite de cada DeletePublished
+}
+
+type fakeOutboxRow struct {
+    entry     domain.OutboxEntry
+    nextAt    time.Time
+    claimed   bool
+    published *time.Time
+    lastError string
+}
+
+func (r *fakeOutboxRepo) add(taskID string, events ...*domain.Event) {
+    for _, e := range events {
+        r.entries = append(r.entries, &fakeOutboxRow{
+            entry: domain.OutboxEntry{ID: int64(len(r.entries) + 1), TaskID: taskID, Event: e},
+        })
+    }
+}
+
+func (r *fakeOutboxRepo) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+    var out []*domain.OutboxEntry
+    busy := make(map[string]bool) // Tasks cujo evento mais antigo está reservado ou fora da hora
+    for _, row := range r.entries {
+        if len(out) == limit {
+            break
+        }
+        if row.published != nil || busy[row.entry.TaskID] {
+            continue
+        }
+        if row.claimed || row.nextAt.After(r.now) {
+            busy[row.entry.TaskID] = true
+            continue
+        }
+        row.claimed = true
+        e := row.entry
+        out = append(out, &e)
+    }
+    r.claims = append(r.claims, len(out))
+    return out, nil
+}
+
+func (r *fakeOutboxRepo) Release(ctx context.Context, ids []int64) error {
+    for _, id := range ids {
+        r.entries[id-1].claimed = false
+    }
+    return nil
+}
+
+func (r *fakeOutboxRepo) MarkPublished(ctx context.Context, ids []int64) error {
+    for _, id := range ids {
+        row := r.entries[id-1]
+        at := r.now
+        row.published, row.claimed = &at, false
+    }
+    return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(ctx context.Context, id int64, delivered []string, cause string, at time.Time) error {
+    row := r.entries[id-1]
+    row.entry.Attempts++
+    row.entry.Delivered = delivered
+    row.lastError, row.nextAt, row.claimed = cause, at, false
+    return nil
+}
+
+func (r *fakeOutboxRepo) DeletePublished(ctx context.Context, before time.Time, limit int) (int, error) {
+    r.deletes = append(r.deletes, limit)
+    n := 0
+    kept := r.publishedAt[:0]
+    for _, at := range r.publishedAt {
+        if at.Before(before) && n < limit {
+            n++
+            continue
+        }
+        kept = append(kept, at)
+    }
+    r.publishedAt = kept
+    return n, nil
+}
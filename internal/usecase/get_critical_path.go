@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetCriticalPathUseCase encapsula a lógica de calcular o caminho crítico de um Project.
+type GetCriticalPathUseCase struct {
+    Projects domain.ProjectRepository
+    Deps     domain.TaskDependencyRepository
+}
+
+// NewGetCriticalPathUseCase injeta os repositórios de projetos e de dependências.
+func NewGetCriticalPathUseCase(projects domain.ProjectRepository, deps domain.TaskDependencyRepository) *GetCriticalPathUseCase {
+    return &GetCriticalPathUseCase{Projects: projects, Deps: deps}
+}
+
+// Execute calcula o caminho crítico entre as Tasks abertas do projeto, ou
+// retorna domain.ErrProjectNotFound.
+func (uc *GetCriticalPathUseCase) Execute(ctx context.Context, projectID string) (*domain.CriticalPath, error) {
+    if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+        return nil, err
+    }
+    tasks, edges, err := uc.Deps.ProjectGraph(ctx, projectID)
+    if err != nil {
+        return nil, err
+    }
+    return domain.ComputeCriticalPath(tasks, edges), nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+func day(n int) *time.Time {
+    d := time.Date(2026, 3, n, 0, 0, 0, 0, time.UTC)
+    return &d
+}
+
+func taskIDs(tasks []*domain.Task) []string {
+    ids := make([]string, len(tasks))
+    for i, t := range tasks {
+        ids[i] = t.ID
+    }
+    return ids
+}
+
+func TestGetCriticalPathDiamond(t *testing.T) {
+    // a <- b <- d e a <- c <- d: dois caminhos de mesmo tamanho até d
+    edges := []domain.TaskDependency{
+        {TaskID: "b", DependsOnID: "a"},
+        {TaskID: "c", DependsOnID: "a"},
+        {TaskID: "d", DependsOnID: "b"},
+        {TaskID: "d", DependsOnID: "c"},
+    }
+
+    tests := []struct {
+        name      string
+        due       map[string]*time.Time
+        want      []string
+        wantDue   *time.Time
+        conflicts []domain.TaskDependency
+    }{
+        {
+            name: "no due dates breaks ties by id",
+            want: []string{"a", "b", "d"},
+        },
+        {
+            name:    "later branch wins",
+            due:     map[string]*time.Time{"b": day(5), "c": day(9), "d": day(10)},
+            want:    []string{"a", "c", "d"},
+            wantDue: day(10),
+        },
+        {
+            name:      "prerequisite due after its dependent is a conflict",
+            due:       map[string]*time.Time{"a": day(1), "b": day(12), "d": day(10)},
+            want:      []string{"a", "b", "d"},
+            wantDue:   day(12),
+            conflicts: []domain.TaskDependency{{TaskID: "d", DependsOnID: "b"}},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var tasks []*domain.Task
+            // Ordem embaralhada: o resultado não pode depender dela
+            for _, id := range []string{"d", "b", "c", "a", "e"} {
+                tasks = append(tasks, &domain.Task{ID: id, DueDate: tt.due[id]})
+            }
+            uc := NewGetCriticalPathUseCase(
+                &fakeProjectRepo{ids: map[string]bool{"p": true}},
+                &fakeDependencyRepo{tasks: tasks, edges: edges},
+            )
+
+            path, err := uc.Execute(context.Background(), "p")
+            if err != nil {
+                t.Fatalf("Execute: %v", err)
+            }
+            if got := taskIDs(path.Tasks); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+                t.Errorf("path = %v, want %v", got, tt.want)
+            }
+            if fmt.Sprint(path.DueDate) != fmt.Sprint(tt.wantDue) {
+                t.Errorf("due = %v, want %v", path.DueDate, tt.wantDue)
+            }
+            if fmt.Sprint(path.Conflicts) != fmt.Sprint(append([]domain.TaskDependency{}, tt.conflicts...)) {
+                t.Errorf("conflicts = %v, want %v", path.Conflicts, tt.conflicts)
+            }
+        })
+    }
+}
+
+func TestGetCriticalPathUnknownProject(t *testing.T) {
+    uc := NewGetCriticalPathUseCase(&fakeProjectRepo{}, &fakeDependencyRepo{})
+    if _, err := uc.Execute(context.Background(), "p"); !errors.Is(err, domain.ErrProjectNotFound) {
+        t.Fatalf("Execute = %v, want ErrProjectNotFound", err)
+    }
+}
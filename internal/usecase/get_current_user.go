@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetCurrentUserUseCase encapsula a lógica de buscar o usuário autenticado.
+type GetCurrentUserUseCase struct {
+    Repo domain.UserRepository
+}
+
+// NewGetCurrentUserUseCase injeta o repositório de usuários.
+func NewGetCurrentUserUseCase(repo domain.UserRepository) *GetCurrentUserUseCase {
+    return &GetCurrentUserUseCase{Repo: repo}
+}
+
+// Execute retorna o User do contexto.
+func (uc *GetCurrentUserUseCase) Execute(ctx context.Context) (*domain.User, error) {
+    userID, ok := domain.UserIDFromContext(ctx)
+    if !ok {
+        return nil, domain.ErrInvalidCredentials
+    }
+    return uc.Repo.FindByID(ctx, userID)
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetProjectUseCase encapsula a lógica de buscar um Project pelo ID.
+type GetProjectUseCase struct {
+    Repo domain.ProjectRepository
+}
+
+// NewGetProjectUseCase injeta o repositório de projetos.
+func NewGetProjectUseCase(repo domain.ProjectRepository) *GetProjectUseCase {
+    return &GetProjectUseCase{Repo: repo}
+}
+
+// Execute retorna o Project ou domain.ErrProjectNotFound se ele não existir.
+func (uc *GetProjectUseCase) Execute(ctx context.Context, id string) (*domain.Project, error) {
+    return uc.Repo.FindByID(ctx, id)
+}
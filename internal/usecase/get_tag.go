@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetTagUseCase encapsula a lógica de buscar uma Tag pelo ID.
+type GetTagUseCase struct {
+    Repo domain.TagRepository
+}
+
+// NewGetTagUseCase injeta o repositório de tags.
+func NewGetTagUseCase(repo domain.TagRepository) *GetTagUseCase {
+    return &GetTagUseCase{Repo: repo}
+}
+
+// Execute retorna a Tag ou domain.ErrTagNotFound se ela não existir.
+func (uc *GetTagUseCase) Execute(ctx context.Context, id string) (*domain.Tag, error) {
+    return uc.Repo.FindByID(ctx, id)
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetTaskUseCase encapsula a lógica de buscar uma Task pelo ID.
+type GetTaskUseCase struct {
+    Repo domain.TaskRepository
+}
+
+// NewGetTaskUseCase injeta o repositório de tarefas.
+func NewGetTaskUseCase(repo domain.TaskRepository) *GetTaskUseCase {
+    return &GetTaskUseCase{Repo: repo}
+}
+
+// Execute retorna a Task ou domain.ErrTaskNotFound se ela não existir.
+func (uc *GetTaskUseCase) Execute(ctx context.Context, id string) (*domain.Task, error) {
+    return uc.Repo.FindByID(ctx, id)
+}
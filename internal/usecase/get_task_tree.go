@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetTaskTreeUseCase encapsula a lógica de montar a árvore de subtarefas de uma Task.
+type GetTaskTreeUseCase struct {
+    Repo domain.TaskRepository
+}
+
+// NewGetTaskTreeUseCase injeta o repositório de tarefas.
+func NewGetTaskTreeUseCase(repo domain.TaskRepository) *GetTaskTreeUseCase {
+    return &GetTaskTreeUseCase{Repo: repo}
+}
+
+// Execute retorna a Task com todas as subtarefas aninhadas e o progresso de
+// cada nível, ou domain.ErrTaskNotFound.
+func (uc *GetTaskTreeUseCase) Execute(ctx context.Context, id string) (*domain.TaskNode, error) {
+    root, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    descendants, err := uc.Repo.Descendants(ctx, root.ID)
+    if err != nil {
+        return nil, err
+    }
+    tree, _ := domain.BuildTaskTree(root, descendants)
+    return tree, nil
+}
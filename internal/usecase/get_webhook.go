@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// GetWebhookUseCase encapsula a lógica de buscar uma assinatura pelo ID.
+type GetWebhookUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewGetWebhookUseCase injeta o repositório de webhooks.
+func NewGetWebhookUseCase(repo domain.WebhookRepository) *GetWebhookUseCase {
+    return &GetWebhookUseCase{Repo: repo}
+}
+
+// Execute retorna a assinatura ou domain.ErrWebhookNotFound se ela não existir.
+func (uc *GetWebhookUseCase) Execute(ctx context.Context, id string) (*domain.Webhook, error) {
+    return uc.Repo.FindByID(ctx, id)
+}
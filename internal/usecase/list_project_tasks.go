@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListProjectTasksUseCase lista as Tasks de um Project com os mesmos filtros
+// e paginação de ListTasksUseCase.
+type ListProjectTasksUseCase struct {
+    Projects domain.ProjectRepository
+    Tasks    *ListTasksUseCase
+}
+
+// NewListProjectTasksUseCase injeta o repositório de projetos e a listagem de tasks.
+func NewListProjectTasksUseCase(projects domain.ProjectRepository, tasks *ListTasksUseCase) *ListProjectTasksUseCase {
+    return &ListProjectTasksUseCase{Projects: projects, Tasks: tasks}
+}
+
+// Execute confirma que o Project existe (e é do usuário) e lista suas Tasks.
+func (uc *ListProjectTasksUseCase) Execute(ctx context.Context, projectID string, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+        return nil, err
+    }
+    filter.ProjectID = projectID
+    return uc.Tasks.Execute(ctx, filter)
+}
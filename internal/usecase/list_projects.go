@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListProjectsUseCase encapsula a lógica de listar os Projects do usuário.
+type ListProjectsUseCase struct {
+    Repo domain.ProjectRepository
+}
+
+// NewListProjectsUseCase injeta o repositório de projetos.
+func NewListProjectsUseCase(repo domain.ProjectRepository) *ListProjectsUseCase {
+    return &ListProjectsUseCase{Repo: repo}
+}
+
+// Execute retorna os Projects do usuário; arquivados só com includeArchived.
+func (uc *ListProjectsUseCase) Execute(ctx context.Context, includeArchived bool) ([]*domain.Project, error) {
+    return uc.Repo.List(ctx, includeArchived)
+}
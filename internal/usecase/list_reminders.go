@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListRemindersUseCase encapsula a lógica de listar os lembretes de uma Task.
+type ListRemindersUseCase struct {
+    Repo      domain.TaskRepository
+    Reminders domain.ReminderRepository
+}
+
+// NewListRemindersUseCase injeta os repositórios de tarefas e de lembretes.
+func NewListRemindersUseCase(repo domain.TaskRepository, reminders domain.ReminderRepository) *ListRemindersUseCase {
+    return &ListRemindersUseCase{Repo: repo, Reminders: reminders}
+}
+
+// Execute retorna os lembretes da Task, enviados ou não, em ordem de disparo.
+func (uc *ListRemindersUseCase) Execute(ctx context.Context, taskID string) ([]*domain.Reminder, error) {
+    if _, err := uc.Repo.FindByID(ctx, taskID); err != nil {
+        return nil, err
+    }
+    return uc.Reminders.ListByTask(ctx, taskID)
+}
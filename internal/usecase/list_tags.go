@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListTagsUseCase encapsula a lógica de listar as Tags do usuário.
+type ListTagsUseCase struct {
+    Repo domain.TagRepository
+}
+
+// NewListTagsUseCase injeta o repositório de tags.
+func NewListTagsUseCase(repo domain.TagRepository) *ListTagsUseCase {
+    return &ListTagsUseCase{Repo: repo}
+}
+
+// Execute retorna as Tags do usuário do contexto.
+func (uc *ListTagsUseCase) Execute(ctx context.Context) ([]*domain.Tag, error) {
+    return uc.Repo.List(ctx)
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListTaskChildrenUseCase lista as subtarefas diretas de uma Task com os
+// mesmos filtros e paginação de ListTasksUseCase.
+type ListTaskChildrenUseCase struct {
+    Repo  domain.TaskRepository
+    Tasks *ListTasksUseCase
+}
+
+// NewListTaskChildrenUseCase injeta o repositório de tarefas e a listagem de tasks.
+func NewListTaskChildrenUseCase(repo domain.TaskRepository, tasks *ListTasksUseCase) *ListTaskChildrenUseCase {
+    return &ListTaskChildrenUseCase{Repo: repo, Tasks: tasks}
+}
+
+// Execute confirma que a Task existe (e é do usuário) e lista suas subtarefas.
+func (uc *ListTaskChildrenUseCase) Execute(ctx context.Context, parentID string, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    if _, err := uc.Repo.FindByID(ctx, parentID); err != nil {
+        return nil, err
+    }
+    filter.ParentID = parentID
+    return uc.Tasks.Execute(ctx, filter)
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListTaskDependenciesUseCase encapsula a lógica de listar as dependências de uma Task.
+type ListTaskDependenciesUseCase struct {
+    Repo domain.TaskRepository
+    Deps domain.TaskDependencyRepository
+}
+
+// NewListTaskDependenciesUseCase injeta os repositórios de tarefas e de dependências.
+func NewListTaskDependenciesUseCase(repo domain.TaskRepository, deps domain.TaskDependencyRepository) *ListTaskDependenciesUseCase {
+    return &ListTaskDependenciesUseCase{Repo: repo, Deps: deps}
+}
+
+// Execute retorna os pré-requisitos da Task e as Tasks que esperam por ela.
+func (uc *ListTaskDependenciesUseCase) Execute(ctx context.Context, id string) (*domain.TaskDependencies, error) {
+    if _, err := uc.Repo.FindByID(ctx, id); err != nil {
+        return nil, err
+    }
+    return loadTaskDependencies(ctx, uc.Deps, id)
+}
@@ -1,17 +1,36 @@
 package usecase
 
-import "github.com/rubenfabio/gopher-tasks/internal/domain"
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
 
 // ListTasksUseCase encapsula a lógica de listar Tasks.
 type ListTasksUseCase struct {
-    Repo domain.TaskRepository
+    Repo         domain.TaskRepository
+    DefaultLimit int // tamanho de página quando o filtro não informa Limit
+    MaxLimit     int // maior página aceita; valores acima são reduzidos
+}
+
+func NewListTasksUseCase(repo domain.TaskRepository, defaultLimit, maxLimit int) *ListTasksUseCase {
+    return &ListTasksUseCase{Repo: repo, DefaultLimit: defaultLimit, MaxLimit: maxLimit}
 }
 
-func NewListTasksUseCase(repo domain.TaskRepository) *ListTasksUseCase {
-    return &ListTasksUseCase{Repo: repo}
+// Execute retorna uma página de tasks de acordo com o filtro, aplicando o
+// tamanho de página padrão e o teto do servidor.
+func (uc *ListTasksUseCase) Execute(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    filter.Limit = uc.PageSize(filter.Limit)
+    return uc.Repo.List(ctx, filter)
 }
 
-// Execute retorna as tasks de acordo com o filtro.
-func (uc *ListTasksUseCase) Execute(filter domain.TaskFilter) ([]*domain.Task, error) {
-    return uc.Repo.List(filter)
+// PageSize é o tamanho de página efetivo para o limit pedido pelo cliente.
+func (uc *ListTasksUseCase) PageSize(limit int) int {
+    if limit <= 0 {
+        limit = uc.DefaultLimit
+    }
+    if uc.MaxLimit > 0 && limit > uc.MaxLimit {
+        limit = uc.MaxLimit
+    }
+    return limit
 }
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Tamanho da página do histórico de entregas.
+const (
+    webhookDeliveriesDefaultLimit = 50
+    webhookDeliveriesMaxLimit     = 200
+)
+
+// ListWebhookDeliveriesUseCase encapsula a lógica de consultar o histórico de
+// entregas de uma assinatura.
+type ListWebhookDeliveriesUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewListWebhookDeliveriesUseCase injeta o repositório de webhooks.
+func NewListWebhookDeliveriesUseCase(repo domain.WebhookRepository) *ListWebhookDeliveriesUseCase {
+    return &ListWebhookDeliveriesUseCase{Repo: repo}
+}
+
+// Execute retorna as entregas mais recentes da assinatura, até filter.Limit
+// (padrão 50, máximo 200).
+func (uc *ListWebhookDeliveriesUseCase) Execute(ctx context.Context, webhookID string, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+    if _, err := uc.Repo.FindByID(ctx, webhookID); err != nil {
+        return nil, err
+    }
+    switch {
+    case filter.Limit <= 0:
+        filter.Limit = webhookDeliveriesDefaultLimit
+    case filter.Limit > webhookDeliveriesMaxLimit:
+        filter.Limit = webhookDeliveriesMaxLimit
+    }
+    return uc.Repo.Deliveries(ctx, webhookID, filter)
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ListWebhooksUseCase encapsula a lógica de listar as assinaturas do usuário.
+type ListWebhooksUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewListWebhooksUseCase injeta o repositório de webhooks.
+func NewListWebhooksUseCase(repo domain.WebhookRepository) *ListWebhooksUseCase {
+    return &ListWebhooksUseCase{Repo: repo}
+}
+
+// Execute retorna as assinaturas do usuário do contexto.
+func (uc *ListWebhooksUseCase) Execute(ctx context.Context) ([]*domain.Webhook, error) {
+    return uc.Repo.List(ctx)
+}
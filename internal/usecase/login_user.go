@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// LoginOutput é o resultado de um login bem-sucedido.
+type LoginOutput struct {
+    Token     string
+    ExpiresAt time.Time
+    User      *domain.User
+}
+
+// LoginUserUseCase encapsula a lógica de autenticar um User e emitir o token.
+type LoginUserUseCase struct {
+    Repo   domain.UserRepository
+    Hasher domain.PasswordHasher
+    Tokens domain.TokenService
+}
+
+// NewLoginUserUseCase injeta o repositório, o verificador de senha e o emissor de tokens.
+func NewLoginUserUseCase(repo domain.UserRepository, hasher domain.PasswordHasher, tokens domain.TokenService) *LoginUserUseCase {
+    return &LoginUserUseCase{Repo: repo, Hasher: hasher, Tokens: tokens}
+}
+
+// Execute confere as credenciais e retorna um token de acesso. E-mail
+// inexistente e senha errada resultam no mesmo erro para não revelar contas.
+func (uc *LoginUserUseCase) Execute(ctx context.Context, email, password string) (*LoginOutput, error) {
+    user, err := uc.Repo.FindByEmail(ctx, normalizeEmail(email))
+    if errors.Is(err, domain.ErrUserNotFound) {
+        return nil, domain.ErrInvalidCredentials
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.Hasher.Compare(user.PasswordHash, password); err != nil {
+        return nil, err
+    }
+
+    token, expiresAt, err := uc.Tokens.Issue(user.ID)
+    if err != nil {
+        return nil, err
+    }
+    return &LoginOutput{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// TaskPatch descreve uma atualização parcial: campos nil são mantidos.
+// Status tem precedência sobre Completed quando ambos são informados.
+type TaskPatch struct {
+    ProjectID        *string // "" remove do projeto
+    ParentID         *string // "" promove a Task para a raiz
+    RRule            *string // "" remove a recorrência
+    Title            *string
+    Description      *string
+    Status           *domain.TaskStatus
+    Priority         *domain.Priority
+    DueDate          *time.Time
+    ClearDueDate     bool // remove o vencimento; DueDate é ignorado
+    Completed        *bool
+    Tags             []string // nil mantém as tags atuais; vazio remove todas
+    AllowPastDueDate bool
+    ExpectedVersion  int // versão do If-Match; zero não confere
+}
+
+// PatchTaskUseCase encapsula a lógica de atualizar parcialmente uma Task.
+type PatchTaskUseCase struct {
+    Repo     domain.TaskRepository
+    Projects domain.ProjectRepository
+    Deps     domain.TaskDependencyRepository
+    Users    domain.UserRepository
+    Events   domain.EventPublisher
+    Tx       domain.TxManager
+}
+
+// NewPatchTaskUseCase injeta os repositórios de tarefas, de projetos, de dependências e
+// de usuários, o publisher dos eventos de Task e o gerenciador de transações.
+func NewPatchTaskUseCase(
+    repo domain.TaskRepository,
+    projects domain.ProjectRepository,
+    deps domain.TaskDependencyRepository,
+    users domain.UserRepository,
+    events domain.EventPublisher,
+    tx domain.TxManager,
+) *PatchTaskUseCase {
+    return &PatchTaskUseCase{Repo: repo, Projects: projects, Deps: deps, Users: users, Events: events, Tx: tx}
+}
+
+// Execute aplica apenas os campos informados no patch, grava somente o que
+// mudou e retorna a Task atualizada. Como em UpdateTaskUseCase, as gravações
+// decorrentes e os eventos rodam na mesma transação.
+func (uc *PatchTaskUseCase) Execute(ctx context.Context, id string, patch TaskPatch) (*domain.Task, error) {
+    var task *domain.Task
+    var events []*domain.Event
+    err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        var err error
+        task, events, err = uc.patch(ctx, id, patch)
+        if err != nil {
+            return err
+        }
+        return publish(ctx, uc.Events, events...)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return task, nil
+}
+
+// patch aplica o patch à Task lida na transação e devolve os eventos da mudança.
+func (uc *PatchTaskUseCase) patch(ctx context.Context, id string, patch TaskPatch) (*domain.Task, []*domain.Event, error) {
+    repos := taskChangeRepos{Tasks: uc.Repo, Projects: uc.Projects, Deps: uc.Deps}
+    opts := taskChangeOptions{ExpectedVersion: patch.ExpectedVersion, AllowPastDueDate: patch.AllowPastDueDate, Partial: true}
+    return applyTaskChange(ctx, repos, id, opts, func(task *domain.Task) error {
+        if patch.Title != nil {
+            task.Title = *patch.Title
+        }
+        if patch.Description != nil {
+            task.Description = *patch.Description
+        }
+        switch {
+        case patch.ClearDueDate:
+            task.DueDate = nil
+        case patch.DueDate != nil:
+            task.DueDate = patch.DueDate
+        }
+        if patch.Priority != nil {
+            task.Priority = *patch.Priority
+        }
+        if patch.Tags != nil {
+            task.Tags = patch.Tags
+        }
+        if patch.ProjectID != nil {
+            task.ProjectID = *patch.ProjectID
+        }
+        if patch.ParentID != nil {
+            task.ParentID = *patch.ParentID
+        }
+        switch {
+        case patch.Status != nil:
+            if err := task.TransitionTo(*patch.Status); err != nil {
+                return err
+            }
+        case patch.Completed != nil:
+            if err := task.TransitionTo(statusFromCompleted(task.Status, *patch.Completed)); err != nil {
+                return err
+            }
+        }
+        return applyTaskRecurrence(ctx, uc.Users, task, patch.RRule)
+    })
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Limites da prévia de ocorrências.
+const (
+    DefaultOccurrencePreview = 5
+    MaxOccurrencePreview     = 50
+)
+
+// PreviewTaskOccurrencesUseCase encapsula a lógica de listar as próximas ocorrências de uma Task recorrente.
+type PreviewTaskOccurrencesUseCase struct {
+    Repo domain.TaskRepository
+}
+
+// NewPreviewTaskOccurrencesUseCase injeta o repositório de tarefas.
+func NewPreviewTaskOccurrencesUseCase(repo domain.TaskRepository) *PreviewTaskOccurrencesUseCase {
+    return &PreviewTaskOccurrencesUseCase{Repo: repo}
+}
+
+// Execute retorna a recorrência da Task e as até count ocorrências seguintes
+// ao seu vencimento (count <= 0 usa DefaultOccurrencePreview), no fuso da série.
+func (uc *PreviewTaskOccurrencesUseCase) Execute(ctx context.Context, id string, count int) (*domain.TaskRecurrence, []time.Time, error) {
+    task, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, nil, err
+    }
+    if task.Recurrence == nil || task.DueDate == nil {
+        return nil, nil, domain.ErrTaskNotRecurring
+    }
+
+    if count <= 0 {
+        count = DefaultOccurrencePreview
+    }
+    if count > MaxOccurrencePreview {
+        count = MaxOccurrencePreview
+    }
+    occurrences, err := task.Recurrence.Upcoming(*task.DueDate, count)
+    if err != nil {
+        return nil, nil, err
+    }
+    return task.Recurrence, occurrences, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// PurgeIdempotencyKeysUseCase apaga as chaves de idempotência expiradas; é
+// chamado periodicamente pelo worker de cada réplica do servidor.
+type PurgeIdempotencyKeysUseCase struct {
+    Repo      domain.IdempotencyRepository
+    BatchSize int // chaves apagadas por comando
+}
+
+// NewPurgeIdempotencyKeysUseCase injeta o repositório de chaves e o tamanho de cada lote.
+func NewPurgeIdempotencyKeysUseCase(repo domain.IdempotencyRepository, batchSize int) *PurgeIdempotencyKeysUseCase {
+    return &PurgeIdempotencyKeysUseCase{Repo: repo, BatchSize: batchSize}
+}
+
+// Execute apaga lotes até não sobrar chave expirada e devolve o total. Lotes
+// pequenos evitam segurar locks por muito tempo.
+func (uc *PurgeIdempotencyKeysUseCase) Execute(ctx context.Context) (int, error) {
+    now := time.Now()
+    total := 0
+    for {
+        n, err := uc.Repo.DeleteExpired(ctx, now, uc.BatchSize)
+        total += n
+        if err != nil || n < uc.BatchSize {
+            return total, err
+        }
+    }
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// PurgeOutboxUseCase apaga os eventos da outbox já publicados há mais de
+// Retention; é chamado periodicamente pelo worker de cada réplica do servidor.
+type PurgeOutboxUseCase struct {
+    Repo      domain.OutboxRepository
+    Retention time.Duration // por quanto tempo um evento publicado é mantido
+    BatchSize int           // eventos apagados por comando
+}
+
+// NewPurgeOutboxUseCase injeta o repositório da outbox, a retenção dos eventos
+// publicados e o tamanho de cada lote.
+func NewPurgeOutboxUseCase(repo domain.OutboxRepository, retention time.Duration, batchSize int) *PurgeOutboxUseCase {
+    return &PurgeOutboxUseCase{Repo: repo, Retention: retention, BatchSize: batchSize}
+}
+
+// Execute apaga lotes até não sobrar evento vencido e devolve o total.
+func (uc *PurgeOutboxUseCase) Execute(ctx context.Context) (int, error) {
+    before := time.Now().Add(-uc.Retention)
+    total := 0
+    for {
+        n, err := uc.Repo.DeletePublished(ctx, before, uc.BatchSize)
+        total += n
+        if err != nil || n < uc.BatchSize {
+            return total, err
+        }
+    }
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// RedeliverWebhookUseCase encapsula a lógica de reenviar uma entrega, por
+// exemplo uma dead letter depois que o destino voltou a funcionar.
+type RedeliverWebhookUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewRedeliverWebhookUseCase injeta o repositório de webhooks.
+func NewRedeliverWebhookUseCase(repo domain.WebhookRepository) *RedeliverWebhookUseCase {
+    return &RedeliverWebhookUseCase{Repo: repo}
+}
+
+// Execute devolve a entrega para a fila, para envio na próxima varredura do worker.
+func (uc *RedeliverWebhookUseCase) Execute(ctx context.Context, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+    return uc.Repo.Redeliver(ctx, webhookID, deliveryID)
+}
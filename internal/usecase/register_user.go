@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// PasswordMinLength é o tamanho mínimo aceito para senhas.
+const PasswordMinLength = 8
+
+// errInvalidTimezone é o erro de validação para fusos desconhecidos.
+var errInvalidTimezone = domain.NewValidationError(domain.FieldError{
+    Field:   "timezone",
+    Message: "must be an IANA time zone such as America/Sao_Paulo",
+})
+
+// RegisterUserInput reúne os dados de cadastro de um usuário.
+type RegisterUserInput struct {
+    Email    string
+    Name     string
+    Password string
+    Timezone string // opcional; padrão domain.DefaultTimezone
+}
+
+// RegisterUserUseCase encapsula a lógica de cadastrar um User.
+type RegisterUserUseCase struct {
+    Repo   domain.UserRepository
+    Hasher domain.PasswordHasher
+}
+
+// NewRegisterUserUseCase injeta o repositório de usuários e o gerador de hash.
+func NewRegisterUserUseCase(repo domain.UserRepository, hasher domain.PasswordHasher) *RegisterUserUseCase {
+    return &RegisterUserUseCase{Repo: repo, Hasher: hasher}
+}
+
+// Execute valida os dados, grava o hash da senha e retorna o usuário criado.
+func (uc *RegisterUserUseCase) Execute(ctx context.Context, in RegisterUserInput) (*domain.User, error) {
+    email := normalizeEmail(in.Email)
+    name := strings.TrimSpace(in.Name)
+
+    var fields []domain.FieldError
+    if _, err := mail.ParseAddress(email); err != nil || email == "" {
+        fields = append(fields, domain.FieldError{Field: "email", Message: "must be a valid email address"})
+    }
+    if utf8.RuneCountInString(in.Password) < PasswordMinLength {
+        fields = append(fields, domain.FieldError{Field: "password", Message: fmt.Sprintf("must be at least %d characters", PasswordMinLength)})
+    }
+    timezone := in.Timezone
+    if timezone == "" {
+        timezone = domain.DefaultTimezone
+    }
+    if _, err := domain.LoadTimezone(timezone); err != nil {
+        fields = append(fields, errInvalidTimezone.Fields...)
+    }
+    if len(fields) > 0 {
+        return nil, domain.NewValidationError(fields...)
+    }
+
+    hash, err := uc.Hasher.Hash(in.Password)
+    if err != nil {
+        return nil, err
+    }
+
+    user := &domain.User{Email: email, Name: name, PasswordHash: hash, Timezone: timezone}
+    if err := uc.Repo.Create(ctx, user); err != nil {
+        return nil, err
+    }
+    return user, nil
+}
+
+// normalizeEmail garante que o mesmo e-mail sempre resolva para a mesma conta.
+func normalizeEmail(email string) string {
+    return strings.ToLower(strings.TrimSpace(email))
+}
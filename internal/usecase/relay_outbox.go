@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// Espera entre tentativas de publicar um evento da outbox: dobra a cada
+// falha, até outboxMaxRetryDelay. Não há desistência: descartar um evento
+// quebraria a ordem dos eventos da Task.
+const (
+    outboxRetryDelay    = time.Second
+    outboxMaxRetryDelay = 5 * time.Minute
+)
+
+// OutboxRelayResult resume uma rodada do relay.
+type OutboxRelayResult struct {
+    Published int // entregues aos sinks
+    Failed    int // falharam e serão tentados de novo
+    Deferred  int // esperam um evento anterior da mesma Task que falhou
+}
+
+// OutboxSink é um destino dos eventos da outbox. Name identifica, na outbox,
+// os sinks que já receberam cada evento, então não deve mudar entre versões.
+type OutboxSink struct {
+    Name      string
+    Publisher domain.EventPublisher
+}
+
+// RelayOutboxUseCase publica nos sinks os eventos gravados na outbox; é
+// chamado periodicamente pelo worker de cada réplica do servidor.
+type RelayOutboxUseCase struct {
+    Repo      domain.OutboxRepository
+    Sinks     []OutboxSink
+    BatchSize int           // eventos reservados por consulta
+    Lease     time.Duration // reserva dos eventos enquanto são publicados
+}
+
+// NewRelayOutboxUseCase injeta o repositório da outbox, os sinks, quantos
+// eventos são reservados por consulta e a lease de cada reserva.
+func NewRelayOutboxUseCase(
+    repo domain.OutboxRepository,
+    sinks []OutboxSink,
+    batchSize int,
+    lease time.Duration,
+) *RelayOutboxUseCase {
+    return &RelayOutboxUseCase{Repo: repo, Sinks: sinks, BatchSize: batchSize, Lease: lease}
+}
+
+// Execute reserva e publica lotes até não sobrar evento pendente na hora. Os
+// eventos de uma Task saem na ordem em que foram gravados: quando um falha,
+// os seguintes dela são soltos na hora e esperam a nova tentativa. A entrega é "pelo menos
+// uma vez" (um relay que cai antes de marcar o lote o publica de novo quando
+// a lease expira), então os consumidores devem deduplicar pelo ID do evento.
+// Quando só alguns sinks falham, a nova tentativa entrega apenas aos que
+// faltam.
+func (uc *RelayOutboxUseCase) Execute(ctx context.Context) (OutboxRelayResult, error) {
+    var out OutboxRelayResult
+    for ctx.Err() == nil {
+        batch, err := uc.Repo.ClaimPending(ctx, uc.BatchSize, uc.Lease)
+        if err != nil {
+            return out, err
+        }
+        if len(batch) == 0 {
+            break
+        }
+        if err := uc.relay(ctx, batch, &out); err != nil {
+            return out, err
+        }
+    }
+    return out, ctx.Err()
+}
+
+func (uc *RelayOutboxUseCase) relay(ctx context.Context, batch []*domain.OutboxEntry, out *OutboxRelayResult) error {
+    // O resultado é gravado mesmo no shutdown, para não republicar o que já saiu
+    store := context.WithoutCancel(ctx)
+
+    failed := make(map[string]bool)
+    var published, deferred []int64
+    for _, e := range batch {
+        if failed[e.TaskID] {
+            deferred = append(deferred, e.ID)
+            out.Deferred++
+            continue
+        }
+        if delivered, err := uc.publish(ctx, e); err != nil {
+            failed[e.TaskID] = true
+            out.Failed++
+            at := time.Now().Add(outboxBackoff(e.Attempts + 1))
+            if err := uc.Repo.MarkFailed(store, e.ID, delivered, err.Error(), at); err != nil {
+                return err
+            }
+            continue
+        }
+        published = append(published, e.ID)
+        out.Published++
+    }
+    // Os adiados voltam à fila já: a Task só é reservada de novo quando o
+    // evento que falhou estiver na hora, e aí eles saem logo depois dele
+    if err := uc.Repo.Release(store, deferred); err != nil {
+        return err
+    }
+    return uc.Repo.MarkPublished(store, published)
+}
+
+// publish entrega o evento aos sinks que ainda não o receberam, mesmo que
+// algum falhe, e devolve os que já o receberam.
+func (uc *RelayOutboxUseCase) publish(ctx context.Context, e *domain.OutboxEntry) ([]string, error) {
+    delivered := slices.Clone(e.Delivered)
+    var errs []error
+    for _, sink := range uc.Sinks {
+        if slices.Contains(delivered, sink.Name) {
+            continue
+        }
+        if err := sink.Publisher.Publish(ctx, e.Event); err != nil {
+            errs = append(errs, fmt.Errorf("%s: %w", sink.Name, err))
+            continue
+        }
+        delivered = append(delivered, sink.Name)
+    }
+    return delivered, errors.Join(errs...)
+}
+
+// outboxBackoff é a espera antes da tentativa seguinte à de número attempt.
+func outboxBackoff(attempt int) time.Duration {
+    delay := outboxRetryDelay
+    for i := 1; i < attempt && delay < outboxMaxRetryDelay; i++ {
+        delay *= 2
+    }
+    if delay > outboxMaxRetryDelay {
+        delay = outboxMaxRetryDelay
+    }
+    return delay
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// flakySink falha as primeiras failures publicações e guarda os IDs dos
+// eventos entregues.
+type flakySink struct {
+    failures int
+    calls    int
+    received []string
+}
+
+func (s *flakySink) Publish(ctx context.Context, events ...*domain.Event) error {
+    s.calls++
+    if s.calls <= s.failures {
+        return errors.New("sink unavailable")
+    }
+    for _, e := range events {
+        s.received = append(s.received, e.ID)
+    }
+    return nil
+}
+
+func outboxEvent(id string) *domain.Event {
+    return &domain.Event{ID: id, Type: domain.EventTaskUpdated}
+}
+
+func TestRelayOutboxRetriesOnlyTheFailedSink(t *testing.T) {
+    repo := &fakeOutboxRepo{now: time.Now()}
+    repo.add("a", outboxEvent("a1"), outboxEvent("a2"))
+    repo.add("b", outboxEvent("b1"))
+    // O evento de b é o terceiro gravado, mas não espera pelos de a.
+    webhooks, stream := &flakySink{}, &flakySink{failures: 1}
+    uc := NewRelayOutboxUseCase(repo, []OutboxSink{{"webhooks", webhooks}, {"stream", stream}}, 10, time.Minute)
+
+    res, err := uc.Execute(context.Background())
+    if err != nil {
+        t.Fatalf("Execute: %v", err)
+    }
+    if res != (OutboxRelayResult{Published: 1, Failed: 1, Deferred: 1}) {
+        t.Fatalf("first run = %+v", res)
+    }
+    first := repo.entries[0]
+    if first.entry.Attempts != 1 || fmt.Sprint(first.entry.Delivered) != "[webhooks]" {
+        t.Errorf("failed entry = %+v, want one attempt delivered to webhooks", first.entry)
+    }
+    if !strings.Contains(first.lastError, "stream: sink unavailable") {
+        t.Errorf("last error = %q, want the failing sink named", first.lastError)
+    }
+    if wait := time.Until(first.nextAt); wait <= 0 || wait > outboxRetryDelay {
+        t.Errorf("next attempt in %v, want about %v", wait, outboxRetryDelay)
+    }
+
+    // a2 não fica reservado até a lease vencer, mas antes da hora nada sai.
+    if repo.entries[1].claimed {
+        t.Errorf("deferred entry still claimed")
+    }
+    if res, _ := uc.Execute(context.Background()); res != (OutboxRelayResult{}) {
+        t.Errorf("early run = %+v, want nothing", res)
+    }
+    repo.now = first.nextAt
+
+    res, err = uc.Execute(context.Background())
+    if err != nil || res != (OutboxRelayResult{Published: 2}) {
+        t.Fatalf("retry = %+v, %v", res, err)
+    }
+    // Cada sink recebe cada evento uma única vez, na ordem da Task.
+    if got := fmt.Sprint(webhooks.received); got != "[a1 b1 a2]" {
+        t.Errorf("webhooks received %s", got)
+    }
+    if got := fmt.Sprint(stream.received); got != "[b1 a1 a2]" {
+        t.Errorf("stream received %s", got)
+    }
+    for _, row := range repo.entries {
+        if row.published == nil {
+            t.Errorf("entry %d not published", row.entry.ID)
+        }
+    }
+}
+
+func TestRelayOutboxKeepsFailingUntilEverySinkSucceeds(t *testing.T) {
+    repo := &fakeOutboxRepo{now: time.Now()}
+    repo.add("a", outboxEvent("a1"))
+    down := &flakySink{failures: 2}
+    uc := NewRelayOutboxUseCase(repo, []OutboxSink{{"webhooks", &flakySink{}}, {"file", down}}, 10, time.Minute)
+
+    for attempt := 1; attempt <= 2; attempt++ {
+        if res, _ := uc.Execute(context.Background()); res.Failed != 1 {
+            t.Fatalf("attempt %d = %+v, want a failure", attempt, res)
+        }
+        row := repo.entries[0]
+        if row.entry.Attempts != attempt || fmt.Sprint(row.entry.Delivered) != "[webhooks]" {
+            t.Fatalf("attempt %d: entry = %+v", attempt, row.entry)
+        }
+        repo.now = row.nextAt
+    }
+    if res, _ := uc.Execute(context.Background()); res.Published != 1 {
+        t.Fatalf("third attempt = %+v, want published", res)
+    }
+}
+
+func TestRelayOutboxClaimsBatchSizeEvents(t *testing.T) {
+    repo := &fakeOutboxRepo{now: time.Now()}
+    repo.add("a", outboxEvent("a1"), outboxEvent("a2"), outboxEvent("a3"))
+    repo.add("b", outboxEvent("b1"), outboxEvent("b2"))
+    sink := &flakySink{}
+    uc := NewRelayOutboxUseCase(repo, []OutboxSink{{"webhooks", sink}}, 2, time.Minute)
+
+    res, err := uc.Execute(context.Background())
+    if err != nil || res != (OutboxRelayResult{Published: 5}) {
+        t.Fatalf("Execute() = %+v, %v", res, err)
+    }
+    // Lotes de no máximo 2 eventos, contando todas as Tasks, até um vazio.
+    if fmt.Sprint(repo.claims) != "[2 2 1 0]" {
+        t.Errorf("claims = %v, want [2 2 1 0]", repo.claims)
+    }
+    if got := fmt.Sprint(sink.received); got != "[a1 a2 a3 b1 b2]" {
+        t.Errorf("received %s", got)
+    }
+}
+
+func TestRelayOutboxStopsOnCancel(t *testing.T) {
+    repo := &fakeOutboxRepo{now: time.Now()}
+    repo.add("a", outboxEvent("a1"))
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    uc := NewRelayOutboxUseCase(repo, []OutboxSink{{"webhooks", &flakySink{}}}, 10, time.Minute)
+    if _, err := uc.Execute(ctx); !errors.Is(err, context.Canceled) {
+        t.Errorf("Execute() = %v, want context.Canceled", err)
+    }
+    if repo.entries[0].claimed {
+        t.Errorf("cancelled relay claimed events")
+    }
+}
+
+func TestOutboxBackoff(t *testing.T) {
+    tests := []struct {
+        attempt int
+        want    time.Duration
+    }{
+        {1, time.Second},
+        {2, 2 * time.Second},
+        {5, 16 * time.Second},
+        {9, 256 * time.Second},
+        {10, outboxMaxRetryDelay},
+        {100, outboxMaxRetryDelay},
+    }
+    for _, tt := range tests {
+        if got := outboxBackoff(tt.attempt); got != tt.want {
+            t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+        }
+    }
+}
+
+func TestPurgeOutbox(t *testing.T) {
+    now := time.Now()
+    repo := &fakeOutboxRepo{}
+    for i := 0; i < 5; i++ {
+        repo.publishedAt = append(repo.publishedAt, now.Add(-48*time.Hour))
+    }
+    repo.publishedAt = append(repo.publishedAt, now.Add(-time.Hour))
+
+    n, err := NewPurgeOutboxUseCase(repo, 24*time.Hour, 2).Execute(context.Background())
+    if err != nil {
+        t.Fatalf("Execute: %v", err)
+    }
+    // Lotes de 2 até um lote incompleto; o evento recente fica.
+    if n != 5 || len(repo.publishedAt) != 1 || fmt.Sprint(repo.deletes) != "[2 2 2]" {
+        t.Errorf("deleted %d in %v, left %d", n, repo.deletes, len(repo.publishedAt))
+    }
+}
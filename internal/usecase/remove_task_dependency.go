@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// RemoveTaskDependencyUseCase encapsula a lógica de remover uma dependência entre Tasks.
+type RemoveTaskDependencyUseCase struct {
+    Repo domain.TaskRepository
+    Deps domain.TaskDependencyRepository
+    Tx   domain.TxManager
+}
+
+// NewRemoveTaskDependencyUseCase injeta os repositórios de tarefas e de
+// dependências e o gerenciador de transações.
+func NewRemoveTaskDependencyUseCase(repo domain.TaskRepository, deps domain.TaskDependencyRepository, tx domain.TxManager) *RemoveTaskDependencyUseCase {
+    return &RemoveTaskDependencyUseCase{Repo: repo, Deps: deps, Tx: tx}
+}
+
+// Execute remove a dependência e desbloqueia a Task se não restar nenhum
+// pré-requisito aberto. Retorna domain.ErrDependencyNotFound se ela não existir.
+func (uc *RemoveTaskDependencyUseCase) Execute(ctx context.Context, taskID, dependsOnID string) error {
+    if _, err := uc.Repo.FindByID(ctx, taskID); err != nil {
+        return err
+    }
+    return withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        if err := uc.Deps.Remove(ctx, taskID, dependsOnID); err != nil {
+            return err
+        }
+        return uc.Deps.SyncBlocked(ctx, []string{taskID})
+    })
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// StreamTaskEventsUseCase acompanha ao vivo os eventos das Tasks do usuário
+// que satisfazem um filtro.
+type StreamTaskEventsUseCase struct {
+    Stream domain.EventStream
+}
+
+// NewStreamTaskEventsUseCase injeta o stream de eventos.
+func NewStreamTaskEventsUseCase(stream domain.EventStream) *StreamTaskEventsUseCase {
+    return &StreamTaskEventsUseCase{Stream: stream}
+}
+
+// Execute assina os eventos a partir de lastEventID (vazio = só os novos).
+// Um evento passa se a Task satisfaz o filtro antes ou depois da mudança,
+// para que quem mostra a lista também saiba quando uma Task sai dela.
+func (uc *StreamTaskEventsUseCase) Execute(ctx context.Context, filter domain.TaskFilter, lastEventID string) (*domain.EventSubscription, error) {
+    userID, _ := domain.UserIDFromContext(ctx)
+    sub, err := uc.Stream.Subscribe(ctx, lastEventID)
+    if err != nil {
+        return nil, err
+    }
+
+    visible := func(e *domain.Event) bool {
+        if e.UserID != userID {
+            return false
+        }
+        now := time.Now()
+        return filter.Matches(e.Task, now) || (e.Previous != nil && filter.Matches(e.Previous, now))
+    }
+
+    out := &domain.EventSubscription{Gap: sub.Gap}
+    for _, e := range sub.Missed {
+        if visible(e) {
+            out.Missed = append(out.Missed, e)
+        }
+    }
+
+    events := make(chan *domain.Event)
+    out.Events = events
+    go func() {
+        defer close(events)
+        for e := range sub.Events {
+            if !visible(e) {
+                continue
+            }
+            select {
+            case events <- e:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return out, nil
+}
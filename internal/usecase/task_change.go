@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// taskChangeRepos são os repositórios usados para gravar uma alteração de Task.
+type taskChangeRepos struct {
+    Tasks    domain.TaskRepository
+    Projects domain.ProjectRepository
+    Deps     domain.TaskDependencyRepository
+}
+
+// taskChangeOptions ajusta applyTaskChange a cada forma de alteração.
+type taskChangeOptions struct {
+    ExpectedVersion  int  // versão do If-Match; zero não confere
+    AllowPastDueDate bool // aceita um vencimento novo no passado
+    // Partial grava só as colunas alteradas (PATCH); uma alteração que não
+    // muda nada não gera versão nova nem evento.
+    Partial bool
+}
+
+// applyTaskChange lê a Task, confere a versão, aplica change e grava o
+// resultado com as regras comuns a PUT e PATCH: validação (um vencimento que
+// não mudou pode estar no passado), pai e projeto de destino, dependências
+// abertas, desbloqueio dos dependentes e próxima ocorrência da série.
+// Devolve a Task gravada e os eventos da mudança; deve rodar em withinTx.
+func applyTaskChange(
+    ctx context.Context,
+    repos taskChangeRepos,
+    id string,
+    opts taskChangeOptions,
+    change func(task *domain.Task) error,
+) (*domain.Task, []*domain.Event, error) {
+    task, err := repos.Tasks.FindByID(ctx, id)
+    if err != nil {
+        return nil, nil, err
+    }
+    if err := checkTaskVersion(task, opts.ExpectedVersion); err != nil {
+        return nil, nil, err
+    }
+    before := task.Clone()
+
+    if err := change(task); err != nil {
+        return nil, nil, err
+    }
+
+    validation := domain.TaskValidationOptions{
+        Now:              time.Now(),
+        AllowPastDueDate: opts.AllowPastDueDate || sameDueDate(before.DueDate, task.DueDate),
+    }
+    if err := task.Validate(validation); err != nil {
+        return nil, nil, err
+    }
+    if task.ParentID != before.ParentID {
+        if _, err := checkTaskParent(ctx, repos.Tasks, task); err != nil {
+            return nil, nil, err
+        }
+    }
+    if task.ProjectID != before.ProjectID {
+        if err := checkTaskProject(ctx, repos.Projects, task.ProjectID); err != nil {
+            return nil, nil, err
+        }
+    }
+    statusChanged := task.Status != before.Status
+    if statusChanged {
+        if err := checkOpenDependencies(ctx, repos.Deps, task); err != nil {
+            return nil, nil, err
+        }
+    }
+
+    if opts.Partial {
+        fields := task.ChangedFields(before)
+        if len(fields) == 0 {
+            return task, nil, nil
+        }
+        err = repos.Tasks.UpdateFields(ctx, task, fields)
+    } else {
+        err = repos.Tasks.Update(ctx, task)
+    }
+    if err != nil {
+        return nil, nil, err
+    }
+
+    // Concluir, cancelar ou reabrir a Task muda o bloqueio de quem depende dela
+    if statusChanged {
+        dependents, err := repos.Deps.Blocks(ctx, task.ID)
+        if err != nil {
+            return nil, nil, err
+        }
+        if err := syncBlocked(ctx, repos.Deps, dependents); err != nil {
+            return nil, nil, err
+        }
+    }
+    events := taskUpdatedEvents(task, before)
+    if statusChanged && task.Status == domain.StatusDone {
+        next, err := createNextOccurrence(ctx, repos.Tasks, task)
+        if err != nil {
+            return nil, nil, err
+        }
+        if next != nil {
+            events = append(events, newTaskEvent(domain.EventTaskCreated, next))
+        }
+    }
+    return task, events, nil
+}
+
+// sameDueDate compara dois vencimentos opcionais.
+func sameDueDate(a, b *time.Time) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return a.Equal(*b)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+type taskChangeFixture struct {
+    tasks  *fakeTaskRepo
+    deps   *fakeDependencyRepo
+    events *fakePublisher
+    update *UpdateTaskUseCase
+    patch  *PatchTaskUseCase
+}
+
+func newTaskChangeFixture(tasks ...*domain.Task) *taskChangeFixture {
+    f := &taskChangeFixture{
+        tasks:  newFakeTaskRepo(tasks...),
+        deps:   &fakeDependencyRepo{},
+        events: &fakePublisher{},
+    }
+    f.update = NewUpdateTaskUseCase(f.tasks, &fakeProjectRepo{}, f.deps, nil, f.events, nil)
+    f.patch = NewPatchTaskUseCase(f.tasks, &fakeProjectRepo{}, f.deps, nil, f.events, nil)
+    return f
+}
+
+func openTask(id string) *domain.Task {
+    return &domain.Task{ID: id, UserID: "u1", Title: "Write report", Status: domain.StatusTodo, Priority: domain.PriorityMedium, Tags: []string{}, Version: 1}
+}
+
+func ptr[T any](v T) *T {
+    return &v
+}
+
+func TestApplyTaskChangeChecksVersion(t *testing.T) {
+    f := newTaskChangeFixture(openTask("a"))
+
+    _, err := f.patch.Execute(context.Background(), "a", TaskPatch{Title: ptr("New"), ExpectedVersion: 2})
+    if de, ok := domain.AsError(err); !ok || de.Kind != domain.KindPrecondition {
+        t.Fatalf("patch = %v, want a precondition error", err)
+    }
+    _, err = f.update.Execute(context.Background(), "a", UpdateTaskInput{Title: "New", ExpectedVersion: 3})
+    if de, ok := domain.AsError(err); !ok || de.Kind != domain.KindPrecondition {
+        t.Fatalf("update = %v, want a precondition error", err)
+    }
+    if len(f.tasks.writes) != 0 || len(f.events.events) != 0 {
+        t.Errorf("stale change was written: %v, %v", f.tasks.writes, eventTypes(f.events.events))
+    }
+}
+
+func TestApplyTaskChangeWrites(t *testing.T) {
+    f := newTaskChangeFixture(openTask("a"))
+    ctx := context.Background()
+
+    // PATCH sem mudança: nada é gravado nem publicado
+    task, err := f.patch.Execute(ctx, "a", TaskPatch{Title: ptr("Write report"), ExpectedVersion: 1})
+    if err != nil || task.Version != 1 {
+        t.Fatalf("no-op patch = %+v, %v", task, err)
+    }
+    if len(f.tasks.writes) != 0 || len(f.events.events) != 0 {
+        t.Fatalf("no-op patch wrote %v and published %v", f.tasks.writes, eventTypes(f.events.events))
+    }
+
+    // PATCH grava só as colunas alteradas
+    task, err = f.patch.Execute(ctx, "a", TaskPatch{Title: ptr("Write the report"), ExpectedVersion: 1})
+    if err != nil || task.Version != 2 {
+        t.Fatalf("patch = %+v, %v", task, err)
+    }
+    if fmt.Sprint(f.tasks.writes) != "[[title]]" {
+        t.Errorf("writes = %v, want [[title]]", f.tasks.writes)
+    }
+
+    // PUT substitui tudo e sempre gera versão nova
+    task, err = f.update.Execute(ctx, "a", UpdateTaskInput{Title: "Write the report", ExpectedVersion: 2})
+    if err != nil || task.Version != 3 {
+        t.Fatalf("update = %+v, %v", task, err)
+    }
+    if len(f.tasks.writes) != 2 || f.tasks.writes[1] != nil {
+        t.Errorf("writes = %v, want a full write", f.tasks.writes)
+    }
+    if got := eventTypes(f.events.events); fmt.Sprint(got) != "[task.updated task.updated]" {
+        t.Errorf("events = %v", got)
+    }
+    if prev := f.events.events[0].Previous; prev == nil || prev.Title != "Write report" {
+        t.Errorf("event previous = %+v, want the task before the change", prev)
+    }
+}
+
+func TestApplyTaskChangeCompletesRecurringTask(t *testing.T) {
+    due := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+    task := openTask("a")
+    task.Status = domain.StatusInProgress
+    task.DueDate = &due
+    task.Recurrence = &domain.TaskRecurrence{Rule: "FREQ=DAILY", Timezone: "UTC", SeriesID: "a", Start: due, Occurrence: 1}
+    f := newTaskChangeFixture(task)
+    f.deps.blocks = map[string][]*domain.Task{"a": {{ID: "b"}, {ID: "c"}}}
+
+    for _, complete := range []func() (*domain.Task, error){
+        func() (*domain.Task, error) {
+            return f.patch.Execute(context.Background(), "a", TaskPatch{Completed: ptr(true)})
+        },
+        func() (*domain.Task, error) {
+            return f.update.Execute(context.Background(), "a", UpdateTaskInput{Title: "Write report", DueDate: &due, Status: domain.StatusDone})
+        },
+    } {
+        reset := *task
+        f.tasks.tasks["a"] = &reset
+        f.tasks.created, f.deps.synced, f.events.events = nil, nil, nil
+
+        done, err := complete()
+        if err != nil {
+            t.Fatalf("complete: %v", err)
+        }
+        if done.Status != domain.StatusDone || !done.Completed {
+            t.Errorf("task = %s (completed %v)", done.Status, done.Completed)
+        }
+        // Os dependentes são reavaliados
+        if fmt.Sprint(f.deps.synced) != "[[b c]]" {
+            t.Errorf("synced = %v, want [[b c]]", f.deps.synced)
+        }
+        // A próxima ocorrência é criada no dia seguinte
+        if len(f.tasks.created) != 1 {
+            t.Fatalf("created %d tasks, want the next occurrence", len(f.tasks.created))
+        }
+        next := f.tasks.created[0]
+        if !next.DueDate.Equal(due.AddDate(0, 0, 1)) || next.Recurrence.Occurrence != 2 || next.Status != domain.StatusTodo {
+            t.Errorf("next occurrence = due %v, occurrence %d, %s", next.DueDate, next.Recurrence.Occurrence, next.Status)
+        }
+        if got := eventTypes(f.events.events); fmt.Sprint(got) != "[task.updated task.completed task.created]" {
+            t.Errorf("events = %v", got)
+        }
+    }
+}
+
+func TestApplyTaskChangeOpenDependencies(t *testing.T) {
+    f := newTaskChangeFixture(openTask("a"))
+    f.deps.blockedBy = map[string][]*domain.Task{"a": {{ID: "p", Status: domain.StatusInProgress}}}
+
+    _, err := f.patch.Execute(context.Background(), "a", TaskPatch{Status: ptr(domain.StatusInProgress)})
+    if !errors.Is(err, domain.ErrTaskHasOpenDependencies) {
+        t.Fatalf("start with open prerequisite = %v, want ErrTaskHasOpenDependencies", err)
+    }
+    if len(f.tasks.writes) != 0 {
+        t.Errorf("writes = %v", f.tasks.writes)
+    }
+}
+
+func TestApplyTaskChangePastDueDate(t *testing.T) {
+    past := time.Now().Add(-48 * time.Hour).Truncate(time.Second).UTC()
+    earlier := past.Add(-time.Hour)
+    task := openTask("a")
+    task.DueDate = &past
+    ctx := context.Background()
+
+    tests := []struct {
+        name    string
+        run     func(f *taskChangeFixture) error
+        wantErr bool
+    }{
+        {"patch keeping an overdue date", func(f *taskChangeFixture) error {
+            _, err := f.patch.Execute(ctx, "a", TaskPatch{Title: ptr("Late report")})
+            return err
+        }, false},
+        {"update keeping an overdue date", func(f *taskChangeFixture) error {
+            _, err := f.update.Execute(ctx, "a", UpdateTaskInput{Title: "Late report", DueDate: &past})
+            return err
+        }, false},
+        {"patch moving to another past date", func(f *taskChangeFixture) error {
+            _, err := f.patch.Execute(ctx, "a", TaskPatch{DueDate: &earlier})
+            return err
+        }, true},
+        {"update moving to another past date", func(f *taskChangeFixture) error {
+            _, err := f.update.Execute(ctx, "a", UpdateTaskInput{Title: "Late report", DueDate: &earlier})
+            return err
+        }, true},
+        {"past date explicitly allowed", func(f *taskChangeFixture) error {
+            _, err := f.patch.Execute(ctx, "a", TaskPatch{DueDate: &earlier, AllowPastDueDate: true})
+            return err
+        }, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            c := *task
+            err := tt.run(newTaskChangeFixture(&c))
+            if tt.wantErr {
+                if de, ok := domain.AsError(err); !ok || de.Kind != domain.KindValidation {
+                    t.Errorf("err = %v, want a validation error", err)
+                }
+            } else if err != nil {
+                t.Errorf("err = %v", err)
+            }
+        })
+    }
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// checkOpenDependencies aplica as regras de dependência a uma Task que acabou
+// de mudar de status: com pré-requisitos abertos ela não pode ser iniciada nem
+// concluída, e voltar para todo a deixa blocked.
+func checkOpenDependencies(ctx context.Context, deps domain.TaskDependencyRepository, task *domain.Task) error {
+    switch task.Status {
+    case domain.StatusTodo, domain.StatusInProgress, domain.StatusDone:
+    default:
+        return nil
+    }
+    prereqs, err := deps.BlockedBy(ctx, task.ID)
+    if err != nil {
+        return err
+    }
+    for _, p := range prereqs {
+        if p.Status.Closed() {
+            continue
+        }
+        if task.Status != domain.StatusTodo {
+            return domain.ErrTaskHasOpenDependencies
+        }
+        task.Status = domain.StatusBlocked
+        return nil
+    }
+    return nil
+}
+
+// syncBlocked reavalia o bloqueio automático das Tasks informadas, tipicamente
+// as que dependem de uma Task que mudou de status ou foi removida.
+func syncBlocked(ctx context.Context, deps domain.TaskDependencyRepository, tasks []*domain.Task) error {
+    ids := make([]string, len(tasks))
+    for i, t := range tasks {
+        ids[i] = t.ID
+    }
+    return deps.SyncBlocked(ctx, ids)
+}
+
+// loadTaskDependencies monta a vizinhança da Task no grafo.
+func loadTaskDependencies(ctx context.Context, deps domain.TaskDependencyRepository, id string) (*domain.TaskDependencies, error) {
+    blockedBy, err := deps.BlockedBy(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    blocks, err := deps.Blocks(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    return &domain.TaskDependencies{BlockedBy: blockedBy, Blocks: blocks}, nil
+}
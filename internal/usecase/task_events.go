@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// newTaskEvent cria um evento de Task com ID novo.
+func newTaskEvent(typ domain.EventType, task *domain.Task) *domain.Event {
+    return &domain.Event{
+        ID:         uuid.NewString(),
+        Type:       typ,
+        OccurredAt: time.Now(),
+        UserID:     task.UserID,
+        Task:       task,
+    }
+}
+
+// taskUpdatedEvents devolve os eventos de uma Task alterada a partir do
+// estado anterior: sempre task.updated e, se ela acabou de ser concluída,
+// também task.completed.
+func taskUpdatedEvents(task, before *domain.Task) []*domain.Event {
+    events := []*domain.Event{newTaskEvent(domain.EventTaskUpdated, task)}
+    if task.Status == domain.StatusDone && before.Status != domain.StatusDone {
+        events = append(events, newTaskEvent(domain.EventTaskCompleted, task))
+    }
+    for _, e := range events {
+        e.Previous = before
+    }
+    return events
+}
+
+// publish entrega os eventos ao publisher, se houver um configurado.
+func publish(ctx context.Context, events domain.EventPublisher, list ...*domain.Event) error {
+    if events == nil || len(list) == 0 {
+        return nil
+    }
+    return events.Publish(ctx, list...)
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// checkTaskParent valida o pai de task (se houver) e o retorna: ele precisa
+// existir e ser do usuário, não pode ser a própria Task nem um descendente
+// dela, e a hierarquia resultante não pode passar de domain.TaskMaxDepth.
+func checkTaskParent(ctx context.Context, repo domain.TaskRepository, task *domain.Task) (*domain.Task, error) {
+    if task.ParentID == "" {
+        return nil, nil
+    }
+    invalid := func(msg string) error {
+        return domain.NewValidationError(domain.FieldError{Field: "parent_id", Message: msg})
+    }
+    if task.ParentID == task.ID {
+        return nil, invalid("must not be the task itself")
+    }
+
+    parent, err := repo.FindByID(ctx, task.ParentID)
+    if errors.Is(err, domain.ErrTaskNotFound) {
+        return nil, invalid("does not exist")
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    ancestors, err := repo.Ancestors(ctx, parent.ID)
+    if err != nil {
+        return nil, err
+    }
+    for _, id := range ancestors {
+        if id == task.ID {
+            return nil, invalid("would create a cycle")
+        }
+    }
+
+    // Uma Task existente leva junto a subárvore dela
+    height := 0
+    if task.ID != "" {
+        descendants, err := repo.Descendants(ctx, task.ID)
+        if err != nil {
+            return nil, err
+        }
+        _, height = domain.BuildTaskTree(task, descendants)
+    }
+    // Níveis: ancestrais do pai + pai + a Task + subárvore da Task
+    if len(ancestors)+2+height > domain.TaskMaxDepth {
+        return nil, invalid(fmt.Sprintf("would exceed the maximum depth of %d levels", domain.TaskMaxDepth))
+    }
+    return parent, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// checkTaskProject garante que o projeto de destino de uma Task existe, é do
+// usuário do contexto e não está arquivado. ID vazio significa "sem projeto".
+func checkTaskProject(ctx context.Context, projects domain.ProjectRepository, id string) error {
+    if id == "" {
+        return nil
+    }
+    project, err := projects.FindByID(ctx, id)
+    if errors.Is(err, domain.ErrProjectNotFound) {
+        return domain.NewValidationError(domain.FieldError{Field: "project_id", Message: "does not exist"})
+    }
+    if err != nil {
+        return err
+    }
+    if project.Archived {
+        return domain.ErrProjectArchived
+    }
+    return nil
+}
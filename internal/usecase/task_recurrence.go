@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// applyTaskRecurrence aplica a regra pedida à Task (nil mantém a atual, ""
+// remove a recorrência) e mantém o início da série igual ao vencimento da
+// primeira ocorrência. Uma regra nova começa uma série nesta Task, no fuso do
+// usuário do contexto. Deve rodar antes de Task.Validate, que exige o
+// vencimento em Tasks recorrentes.
+func applyTaskRecurrence(ctx context.Context, users domain.UserRepository, task *domain.Task, rule *string) error {
+    if rule != nil {
+        if *rule == "" {
+            task.Recurrence = nil
+            return nil
+        }
+        tz, err := userTimezone(ctx, users)
+        if err != nil {
+            return err
+        }
+        var start time.Time
+        if task.DueDate != nil {
+            start = *task.DueDate
+        }
+        rec, err := domain.NewTaskRecurrence(*rule, tz, start)
+        if err != nil {
+            return err
+        }
+        if task.Recurrence == nil || task.Recurrence.Rule != rec.Rule {
+            // Em uma Task nova o repositório preenche SeriesID com o ID gerado
+            rec.SeriesID = task.ID
+            task.Recurrence = rec
+            return nil
+        }
+    }
+    if r := task.Recurrence; r != nil && r.Occurrence == 1 && task.DueDate != nil {
+        r.Start = *task.DueDate
+    }
+    return nil
+}
+
+// createNextOccurrence cria a ocorrência seguinte de uma Task recorrente que
+// acabou de ser concluída e a devolve (nil se a série acabou). Concluir de
+// novo a mesma ocorrência (depois de reabri-la) não gera outra: o banco
+// recusa a repetição e ela é ignorada.
+func createNextOccurrence(ctx context.Context, repo domain.TaskRepository, task *domain.Task) (*domain.Task, error) {
+    next, ok, err := task.NextOccurrence()
+    if err != nil || !ok {
+        return nil, err
+    }
+    if err := repo.Create(ctx, next); err != nil {
+        if errors.Is(err, domain.ErrOccurrenceExists) {
+            return nil, nil
+        }
+        return nil, err
+    }
+    return next, nil
+}
+
+// userTimezone retorna o fuso do usuário do contexto, ou o padrão quando não
+// há usuário (ex.: workers internos).
+func userTimezone(ctx context.Context, users domain.UserRepository) (string, error) {
+    userID, ok := domain.UserIDFromContext(ctx)
+    if !ok {
+        return domain.DefaultTimezone, nil
+    }
+    user, err := users.FindByID(ctx, userID)
+    if errors.Is(err, domain.ErrUserNotFound) {
+        return domain.DefaultTimezone, nil
+    }
+    if err != nil {
+        return "", err
+    }
+    return user.Timezone, nil
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "github.com/rubenfabio/gopher-tasks/internal/domain"
+
+// checkTaskVersion confere a versão que o cliente espera (If-Match) com a
+// lida do banco; expected zero dispensa a conferência.
+func checkTaskVersion(task *domain.Task, expected int) error {
+    if expected != 0 && task.Version != expected {
+        return domain.NewPreconditionError("task is at version %d, not %d", task.Version, expected)
+    }
+    return nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// withinTx roda fn na transação de tx ou, sem gerenciador configurado,
+// direto no contexto recebido.
+func withinTx(ctx context.Context, tx domain.TxManager, fn func(ctx context.Context) error) error {
+    if tx == nil {
+        return fn(ctx)
+    }
+    return tx.WithinTx(ctx, fn)
+}
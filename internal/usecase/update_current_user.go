@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// UserPatch descreve a alteração do perfil: campos nil são mantidos.
+type UserPatch struct {
+    Name     *string
+    Timezone *string // fuso IANA, ex.: America/Sao_Paulo
+}
+
+// UpdateCurrentUserUseCase encapsula a lógica de alterar o perfil do usuário autenticado.
+type UpdateCurrentUserUseCase struct {
+    Repo domain.UserRepository
+}
+
+// NewUpdateCurrentUserUseCase injeta o repositório de usuários.
+func NewUpdateCurrentUserUseCase(repo domain.UserRepository) *UpdateCurrentUserUseCase {
+    return &UpdateCurrentUserUseCase{Repo: repo}
+}
+
+// Execute aplica o patch ao User do contexto. Mudar o fuso vale para as
+// séries recorrentes criadas depois; as existentes mantêm o fuso em que começaram.
+func (uc *UpdateCurrentUserUseCase) Execute(ctx context.Context, patch UserPatch) (*domain.User, error) {
+    userID, ok := domain.UserIDFromContext(ctx)
+    if !ok {
+        return nil, domain.ErrInvalidCredentials
+    }
+    user, err := uc.Repo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    if patch.Name != nil {
+        user.Name = strings.TrimSpace(*patch.Name)
+    }
+    if patch.Timezone != nil {
+        if _, err := domain.LoadTimezone(*patch.Timezone); err != nil {
+            return nil, errInvalidTimezone
+        }
+        user.Timezone = *patch.Timezone
+    }
+
+    if err := uc.Repo.Update(ctx, user); err != nil {
+        return nil, err
+    }
+    return user, nil
+}
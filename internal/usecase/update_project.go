@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// ProjectPatch descreve a alteração de um Project: campos nil são mantidos.
+type ProjectPatch struct {
+    Name        *string
+    Description *string
+    Color       *string
+    Archived    *bool
+}
+
+// UpdateProjectUseCase encapsula a lógica de alterar, arquivar e reativar um Project.
+type UpdateProjectUseCase struct {
+    Repo domain.ProjectRepository
+}
+
+// NewUpdateProjectUseCase injeta o repositório de projetos.
+func NewUpdateProjectUseCase(repo domain.ProjectRepository) *UpdateProjectUseCase {
+    return &UpdateProjectUseCase{Repo: repo}
+}
+
+// Execute aplica o patch e retorna o Project atualizado.
+func (uc *UpdateProjectUseCase) Execute(ctx context.Context, id string, patch ProjectPatch) (*domain.Project, error) {
+    project, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if patch.Name != nil {
+        project.Name = *patch.Name
+    }
+    if patch.Description != nil {
+        project.Description = *patch.Description
+    }
+    if patch.Color != nil {
+        project.Color = *patch.Color
+    }
+    if patch.Archived != nil {
+        project.SetArchived(*patch.Archived, time.Now())
+    }
+    if err := project.Validate(); err != nil {
+        return nil, err
+    }
+    if err := uc.Repo.Update(ctx, project); err != nil {
+        return nil, err
+    }
+    return project, nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// TagPatch descreve a alteração de uma Tag: campos nil são mantidos.
+type TagPatch struct {
+    Name  *string
+    Color *string
+}
+
+// UpdateTagUseCase encapsula a lógica de renomear ou recolorir uma Tag.
+type UpdateTagUseCase struct {
+    Repo domain.TagRepository
+}
+
+// NewUpdateTagUseCase injeta o repositório de tags.
+func NewUpdateTagUseCase(repo domain.TagRepository) *UpdateTagUseCase {
+    return &UpdateTagUseCase{Repo: repo}
+}
+
+// Execute aplica o patch e retorna a Tag atualizada. Renomear reflete em
+// todas as Tasks marcadas, pois elas referenciam a Tag pelo ID.
+func (uc *UpdateTagUseCase) Execute(ctx context.Context, id string, patch TagPatch) (*domain.Tag, error) {
+    tag, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if patch.Name != nil {
+        tag.Name = *patch.Name
+    }
+    if patch.Color != nil {
+        tag.Color = *patch.Color
+    }
+    if err := tag.Validate(); err != nil {
+        return nil, err
+    }
+    if err := uc.Repo.Update(ctx, tag); err != nil {
+        return nil, err
+    }
+    return tag, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// UpdateTaskInput reúne os dados para substituição completa de uma Task.
+// Status vazio é derivado de Completed, para clientes que só conhecem o booleano.
+type UpdateTaskInput struct {
+    ProjectID        *string // nil mantém o projeto atual; "" remove do projeto
+    ParentID         *string // nil mantém o pai atual; "" promove a Task para a raiz
+    RRule            *string // nil mantém a recorrência atual; "" remove
+    Title            string
+    Description      string
+    Status           domain.TaskStatus
+    Priority         domain.Priority // zero mantém a prioridade atual
+    DueDate          *time.Time      // nil remove o vencimento
+    Completed        bool
+    Tags             []string // nil mantém as tags atuais; vazio remove todas
+    AllowPastDueDate bool
+    ExpectedVersion  int // versão do If-Match; zero não confere
+}
+
+// UpdateTaskUseCase encapsula a lógica de substituir todos os campos de uma Task.
+type UpdateTaskUseCase struct {
+    Repo     domain.TaskRepository
+    Projects domain.ProjectRepository
+    Deps     domain.TaskDependencyRepository
+    Users    domain.UserRepository
+    Events   domain.EventPublisher
+    Tx       domain.TxManager
+}
+
+// NewUpdateTaskUseCase injeta os repositórios de tarefas, de projetos, de dependências e
+// de usuários, o publisher dos eventos de Task e o gerenciador de transações.
+func NewUpdateTaskUseCase(
+    repo domain.TaskRepository,
+    projects domain.ProjectRepository,
+    deps domain.TaskDependencyRepository,
+    users domain.UserRepository,
+    events domain.EventPublisher,
+    tx domain.TxManager,
+) *UpdateTaskUseCase {
+    return &UpdateTaskUseCase{Repo: repo, Projects: projects, Deps: deps, Users: users, Events: events, Tx: tx}
+}
+
+// Execute substitui os campos editáveis da Task e retorna a entidade
+// atualizada. A gravação, o desbloqueio dos dependentes, a próxima
+// ocorrência de uma série e os eventos rodam na mesma transação.
+func (uc *UpdateTaskUseCase) Execute(ctx context.Context, id string, in UpdateTaskInput) (*domain.Task, error) {
+    var task *domain.Task
+    var events []*domain.Event
+    err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+        var err error
+        task, events, err = uc.update(ctx, id, in)
+        if err != nil {
+            return err
+        }
+        return publish(ctx, uc.Events, events...)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return task, nil
+}
+
+// update aplica a entrada à Task lida na transação e devolve os eventos da mudança.
+func (uc *UpdateTaskUseCase) update(ctx context.Context, id string, in UpdateTaskInput) (*domain.Task, []*domain.Event, error) {
+    repos := taskChangeRepos{Tasks: uc.Repo, Projects: uc.Projects, Deps: uc.Deps}
+    opts := taskChangeOptions{ExpectedVersion: in.ExpectedVersion, AllowPastDueDate: in.AllowPastDueDate}
+    return applyTaskChange(ctx, repos, id, opts, func(task *domain.Task) error {
+        status := in.Status
+        if status == "" {
+            status = statusFromCompleted(task.Status, in.Completed)
+        }
+        if err := task.TransitionTo(status); err != nil {
+            return err
+        }
+
+        task.Title = in.Title
+        task.Description = in.Description
+        task.DueDate = in.DueDate
+        if in.Priority != 0 {
+            task.Priority = in.Priority
+        }
+        if in.Tags != nil {
+            task.Tags = in.Tags
+        }
+        if in.ProjectID != nil {
+            task.ProjectID = *in.ProjectID
+        }
+        if in.ParentID != nil {
+            task.ParentID = *in.ParentID
+        }
+        return applyTaskRecurrence(ctx, uc.Users, task, in.RRule)
+    })
+}
+
+// statusFromCompleted traduz o antigo booleano "completed" em status: true
+// conclui a Task; false reabre uma Task concluída e mantém os demais status.
+func statusFromCompleted(current domain.TaskStatus, completed bool) domain.TaskStatus {
+    switch {
+    case completed:
+        return domain.StatusDone
+    case current == domain.StatusDone:
+        return domain.StatusTodo
+    default:
+        return current
+    }
+}
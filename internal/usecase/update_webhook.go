@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// WebhookPatch descreve a alteração de uma assinatura: campos nil são mantidos.
+type WebhookPatch struct {
+    URL          *string
+    Events       []domain.EventType // nil mantém os eventos atuais
+    Active       *bool
+    RotateSecret bool // gera um segredo novo, devolvido só nesta resposta
+}
+
+// UpdateWebhookUseCase encapsula a lógica de alterar, pausar e reativar uma assinatura.
+type UpdateWebhookUseCase struct {
+    Repo domain.WebhookRepository
+}
+
+// NewUpdateWebhookUseCase injeta o repositório de webhooks.
+func NewUpdateWebhookUseCase(repo domain.WebhookRepository) *UpdateWebhookUseCase {
+    return &UpdateWebhookUseCase{Repo: repo}
+}
+
+// Execute aplica o patch e retorna a assinatura atualizada. Entregas
+// pendentes de uma assinatura pausada esperam até ela ser reativada.
+func (uc *UpdateWebhookUseCase) Execute(ctx context.Context, id string, patch WebhookPatch) (*domain.Webhook, error) {
+    webhook, err := uc.Repo.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if patch.URL != nil {
+        webhook.URL = *patch.URL
+    }
+    if patch.Events != nil {
+        webhook.Events = patch.Events
+    }
+    if patch.Active != nil {
+        webhook.Active = *patch.Active
+    }
+    if patch.RotateSecret {
+        secret, err := newWebhookSecret()
+        if err != nil {
+            return nil, err
+        }
+        webhook.Secret = secret
+    }
+    if err := webhook.Validate(); err != nil {
+        return nil, err
+    }
+    if err := uc.Repo.Update(ctx, webhook); err != nil {
+        return nil, err
+    }
+    return webhook, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rubenfabio/gopher-tasks/internal/domain"
+)
+
+// WebhookEventPublisher enfileira cada evento para as assinaturas de webhook
+// do dono da Task; o envio fica com DeliverWebhooksUseCase.
+type WebhookEventPublisher struct {
+    Webhooks domain.WebhookRepository
+}
+
+// NewWebhookEventPublisher injeta o repositório de webhooks.
+func NewWebhookEventPublisher(webhooks domain.WebhookRepository) *WebhookEventPublisher {
+    return &WebhookEventPublisher{Webhooks: webhooks}
+}
+
+// Publish serializa o evento uma vez; todas as assinaturas recebem o mesmo corpo.
+func (p *WebhookEventPublisher) Publish(ctx context.Context, events ...*domain.Event) error {
+    for _, e := range events {
+        if e.UserID == "" {
+            continue
+        }
+        payload, err := json.Marshal(e)
+        if err != nil {
+            return err
+        }
+        if err := p.Webhooks.Enqueue(ctx, e, payload); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,415 @@
+// Package jsonpatch aplica patches a documentos JSON: JSON Patch (RFC 6902),
+// com caminhos JSON Pointer (RFC 6901), e JSON Merge Patch (RFC 7396).
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+    // ErrInvalidPatch indica um patch malformado (op desconhecida, campo
+    // obrigatório ausente, ponteiro inválido).
+    ErrInvalidPatch = errors.New("jsonpatch: invalid patch")
+    // ErrPathNotFound indica que o caminho não existe no documento.
+    ErrPathNotFound = errors.New("jsonpatch: path not found")
+    // ErrTestFailed indica que uma operação test não casou com o documento.
+    ErrTestFailed = errors.New("jsonpatch: test failed")
+)
+
+// Operation é uma operação de um JSON Patch. Value guarda o JSON cru para
+// distinguir "value": null de um value ausente.
+type Operation struct {
+    Op    string          `json:"op"`
+    Path  string          `json:"path"`
+    From  string          `json:"from,omitempty"`
+    Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch é a lista de operações, aplicadas em ordem.
+type Patch []Operation
+
+// OperationError diz qual operação do patch falhou.
+type OperationError struct {
+    Index int
+    Op    Operation
+    Err   error
+}
+
+func (e *OperationError) Error() string {
+    return fmt.Sprintf("operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+    return e.Err
+}
+
+// DecodePatch lê e valida a estrutura de um JSON Patch.
+func DecodePatch(data []byte) (Patch, error) {
+    var p Patch
+    if err := json.Unmarshal(data, &p); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+    }
+    for i, op := range p {
+        if err := op.validate(); err != nil {
+            return nil, &OperationError{Index: i, Op: op, Err: err}
+        }
+    }
+    return p, nil
+}
+
+func (op Operation) validate() error {
+    switch op.Op {
+    case "add", "replace", "test":
+        if op.Value == nil {
+            return fmt.Errorf("%w: missing value", ErrInvalidPatch)
+        }
+    case "move", "copy":
+        if _, err := parsePointer(op.From); err != nil {
+            return err
+        }
+    case "remove":
+    default:
+        return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+    }
+    _, err := parsePointer(op.Path)
+    return err
+}
+
+// Apply aplica as operações ao documento e devolve o resultado. O patch é
+// atômico: se uma operação falha, o erro é um *OperationError e nada é
+// devolvido.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+    root, err := decode(doc)
+    if err != nil {
+        return nil, err
+    }
+    for i, op := range p {
+        if root, err = op.apply(root); err != nil {
+            return nil, &OperationError{Index: i, Op: op, Err: err}
+        }
+    }
+    return json.Marshal(root)
+}
+
+func (op Operation) apply(root interface{}) (interface{}, error) {
+    if err := op.validate(); err != nil {
+        return nil, err
+    }
+    path, _ := parsePointer(op.Path)
+    switch op.Op {
+    case "add", "replace", "test":
+        value, err := decode(op.Value)
+        if err != nil {
+            return nil, err
+        }
+        switch op.Op {
+        case "add":
+            return add(root, path, value)
+        case "replace":
+            return replace(root, path, value)
+        }
+        current, err := get(root, path)
+        if err != nil {
+            return nil, err
+        }
+        if !equal(current, value) {
+            return nil, ErrTestFailed
+        }
+        return root, nil
+    case "remove":
+        return remove(root, path)
+    }
+
+    from, _ := parsePointer(op.From)
+    value, err := get(root, from)
+    if err != nil {
+        return nil, err
+    }
+    if op.Op == "move" {
+        if isPrefix(from, path) && len(from) < len(path) {
+            return nil, fmt.Errorf("%w: cannot move a value into one of its children", ErrInvalidPatch)
+        }
+        if root, err = remove(root, from); err != nil {
+            return nil, err
+        }
+    } else {
+        value = deepCopy(value)
+    }
+    return add(root, path, value)
+}
+
+// MergePatch aplica um JSON Merge Patch ao documento: membros null são
+// removidos, objetos são mesclados recursivamente e qualquer outro valor
+// substitui o atual.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+    root, err := decode(doc)
+    if err != nil {
+        return nil, err
+    }
+    p, err := decode(patch)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+    }
+    return json.Marshal(merge(root, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+    p, ok := patch.(map[string]interface{})
+    if !ok {
+        return patch
+    }
+    t, ok := target.(map[string]interface{})
+    if !ok {
+        t = map[string]interface{}{}
+    }
+    for k, v := range p {
+        if v == nil {
+            delete(t, k)
+            continue
+        }
+        t[k] = merge(t[k], v)
+    }
+    return t
+}
+
+// decode lê o JSON mantendo os números como json.Number, sem perder precisão.
+func decode(data []byte) (interface{}, error) {
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.UseNumber()
+    var v interface{}
+    if err := dec.Decode(&v); err != nil {
+        return nil, err
+    }
+    if dec.More() {
+        return nil, errors.New("unexpected data after the JSON value")
+    }
+    return v, nil
+}
+
+// parsePointer divide um JSON Pointer em tokens já sem os escapes ~0 e ~1.
+func parsePointer(p string) ([]string, error) {
+    if p == "" {
+        return nil, nil
+    }
+    if !strings.HasPrefix(p, "/") {
+        return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, p)
+    }
+    tokens := strings.Split(p[1:], "/")
+    for i, t := range tokens {
+        if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(t), "~") {
+            return nil, fmt.Errorf("%w: bad escape in pointer %q", ErrInvalidPatch, p)
+        }
+        tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+    }
+    return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+    if len(prefix) > len(path) {
+        return false
+    }
+    for i := range prefix {
+        if prefix[i] != path[i] {
+            return false
+        }
+    }
+    return true
+}
+
+// arrayIndex converte o token em índice de arr; "-" (só em add) e len(arr)
+// apontam para o fim.
+func arrayIndex(arr []interface{}, token string, appending bool) (int, error) {
+    if token == "-" && appending {
+        return len(arr), nil
+    }
+    if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+        return 0, fmt.Errorf("%w: %q is not an array index", ErrPathNotFound, token)
+    }
+    i, err := strconv.Atoi(token)
+    limit := len(arr)
+    if appending {
+        limit++
+    }
+    if err != nil || i >= limit {
+        return 0, fmt.Errorf("%w: index %s out of range", ErrPathNotFound, token)
+    }
+    return i, nil
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+    for _, token := range path {
+        switch n := node.(type) {
+        case map[string]interface{}:
+            v, ok := n[token]
+            if !ok {
+                return nil, fmt.Errorf("%w: member %q", ErrPathNotFound, token)
+            }
+            node = v
+        case []interface{}:
+            i, err := arrayIndex(n, token, false)
+            if err != nil {
+                return nil, err
+            }
+            node = n[i]
+        default:
+            return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrPathNotFound, token)
+        }
+    }
+    return node, nil
+}
+
+// update troca, no pai do último token, o container devolvido por fn; os
+// arrays são reconstruídos no caminho porque inserir ou remover muda a fatia.
+func update(node interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+    if len(path) == 1 {
+        return fn(node, path[0])
+    }
+    child, err := get(node, path[:1])
+    if err != nil {
+        return nil, err
+    }
+    child, err = update(child, path[1:], fn)
+    if err != nil {
+        return nil, err
+    }
+    switch n := node.(type) {
+    case map[string]interface{}:
+        n[path[0]] = child
+    case []interface{}:
+        i, _ := arrayIndex(n, path[0], false)
+        n[i] = child
+    }
+    return node, nil
+}
+
+func add(root interface{}, path []string, value interface{}) (interface{}, error) {
+    if len(path) == 0 {
+        return value, nil
+    }
+    return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+        switch p := parent.(type) {
+        case map[string]interface{}:
+            p[token] = value
+            return p, nil
+        case []interface{}:
+            i, err := arrayIndex(p, token, true)
+            if err != nil {
+                return nil, err
+            }
+            p = append(p, nil)
+            copy(p[i+1:], p[i:])
+            p[i] = value
+            return p, nil
+        }
+        return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrPathNotFound, token)
+    })
+}
+
+func remove(root interface{}, path []string) (interface{}, error) {
+    if len(path) == 0 {
+        return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+    }
+    return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+        switch p := parent.(type) {
+        case map[string]interface{}:
+            if _, ok := p[token]; !ok {
+                return nil, fmt.Errorf("%w: member %q", ErrPathNotFound, token)
+            }
+            delete(p, token)
+            return p, nil
+        case []interface{}:
+            i, err := arrayIndex(p, token, false)
+            if err != nil {
+                return nil, err
+            }
+            return append(p[:i], p[i+1:]...), nil
+        }
+        return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrPathNotFound, token)
+    })
+}
+
+func replace(root interface{}, path []string, value interface{}) (interface{}, error) {
+    if _, err := get(root, path); err != nil {
+        return nil, err
+    }
+    if len(path) == 0 {
+        return value, nil
+    }
+    return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+        switch p := parent.(type) {
+        case map[string]interface{}:
+            p[token] = value
+        case []interface{}:
+            i, _ := arrayIndex(p, token, false)
+            p[i] = value
+        }
+        return parent, nil
+    })
+}
+
+// equal compara dois valores JSON; números são comparados pelo valor
+// (1 e 1.0 são iguais) e objetos independem da ordem dos membros.
+func equal(a, b interface{}) bool {
+    switch x := a.(type) {
+    case map[string]interface{}:
+        y, ok := b.(map[string]interface{})
+        if !ok || len(x) != len(y) {
+            return false
+        }
+        for k, v := range x {
+            w, ok := y[k]
+            if !ok || !equal(v, w) {
+                return false
+            }
+        }
+        return true
+    case []interface{}:
+        y, ok := b.([]interface{})
+        if !ok || len(x) != len(y) {
+            return false
+        }
+        for i := range x {
+            if !equal(x[i], y[i]) {
+                return false
+            }
+        }
+        return true
+    case json.Number:
+        y, ok := b.(json.Number)
+        if !ok {
+            return false
+        }
+        if x == y {
+            return true
+        }
+        fx, errx := x.Float64()
+        fy, erry := y.Float64()
+        return errx == nil && erry == nil && fx == fy
+    default:
+        return a == b
+    }
+}
+
+func deepCopy(v interface{}) interface{} {
+    switch x := v.(type) {
+    case map[string]interface{}:
+        c := make(map[string]interface{}, len(x))
+        for k, w := range x {
+            c[k] = deepCopy(w)
+        }
+        return c
+    case []interface{}:
+        c := make([]interface{}, len(x))
+        for i, w := range x {
+            c[i] = deepCopy(w)
+        }
+        return c
+    default:
+        return v
+    }
+}
@@ -0,0 +1,212 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const doc = `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`
+
+// sameJSON compara dois documentos JSON pelo valor.
+func sameJSON(t *testing.T, got []byte, want string) bool {
+    t.Helper()
+    var g, w interface{}
+    if err := json.Unmarshal(got, &g); err != nil {
+        t.Fatalf("result is not JSON: %s", got)
+    }
+    if err := json.Unmarshal([]byte(want), &w); err != nil {
+        t.Fatalf("bad expectation %s", want)
+    }
+    return reflect.DeepEqual(g, w)
+}
+
+func TestPatchApply(t *testing.T) {
+    tests := []struct {
+        name    string
+        patch   string
+        want    string
+        wantErr error
+    }{
+        // add
+        {name: "add member", patch: `[{"op":"add","path":"/x","value":true}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null,"x":true}`},
+        {name: "add replaces an existing member", patch: `[{"op":"add","path":"/a","value":[1]}]`,
+            want: `{"a":[1],"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "add inserts into an array", patch: `[{"op":"add","path":"/b/c/1","value":9}]`,
+            want: `{"a":1,"b":{"c":[1,9,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "add appends with -", patch: `[{"op":"add","path":"/b/c/-","value":9}]`,
+            want: `{"a":1,"b":{"c":[1,2,3,9]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "add at the array length appends", patch: `[{"op":"add","path":"/b/c/3","value":9}]`,
+            want: `{"a":1,"b":{"c":[1,2,3,9]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "add past the array length", patch: `[{"op":"add","path":"/b/c/4","value":9}]`, wantErr: ErrPathNotFound},
+        {name: "add with a leading-zero index", patch: `[{"op":"add","path":"/b/c/01","value":9}]`, wantErr: ErrPathNotFound},
+        {name: "add with a negative index", patch: `[{"op":"add","path":"/b/c/-1","value":9}]`, wantErr: ErrPathNotFound},
+        {name: "add under a missing parent", patch: `[{"op":"add","path":"/x/y","value":1}]`, wantErr: ErrPathNotFound},
+        {name: "add under a scalar", patch: `[{"op":"add","path":"/a/y","value":1}]`, wantErr: ErrPathNotFound},
+        {name: "add to the root replaces the document", patch: `[{"op":"add","path":"","value":{"z":1}}]`, want: `{"z":1}`},
+        {name: "add null value", patch: `[{"op":"add","path":"/x","value":null}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null,"x":null}`},
+
+        // remove
+        {name: "remove member", patch: `[{"op":"remove","path":"/a"}]`,
+            want: `{"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "remove array element", patch: `[{"op":"remove","path":"/b/c/0"}]`,
+            want: `{"a":1,"b":{"c":[2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "remove null member", patch: `[{"op":"remove","path":"/n"}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde"}`},
+        {name: "remove missing member", patch: `[{"op":"remove","path":"/x"}]`, wantErr: ErrPathNotFound},
+        {name: "remove with - ", patch: `[{"op":"remove","path":"/b/c/-"}]`, wantErr: ErrPathNotFound},
+        {name: "remove out of range", patch: `[{"op":"remove","path":"/b/c/3"}]`, wantErr: ErrPathNotFound},
+        {name: "remove the root", patch: `[{"op":"remove","path":""}]`, wantErr: ErrInvalidPatch},
+
+        // replace
+        {name: "replace member", patch: `[{"op":"replace","path":"/a","value":"one"}]`,
+            want: `{"a":"one","b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "replace array element", patch: `[{"op":"replace","path":"/b/c/2","value":0}]`,
+            want: `{"a":1,"b":{"c":[1,2,0]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "replace with null", patch: `[{"op":"replace","path":"/a","value":null}]`,
+            want: `{"a":null,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "replace missing member", patch: `[{"op":"replace","path":"/x","value":1}]`, wantErr: ErrPathNotFound},
+        {name: "replace with -", patch: `[{"op":"replace","path":"/b/c/-","value":1}]`, wantErr: ErrPathNotFound},
+        {name: "replace the root", patch: `[{"op":"replace","path":"","value":[]}]`, want: `[]`},
+
+        // move
+        {name: "move member", patch: `[{"op":"move","from":"/a","path":"/z"}]`,
+            want: `{"z":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "move array element to the end", patch: `[{"op":"move","from":"/b/c/0","path":"/b/c/-"}]`,
+            want: `{"a":1,"b":{"c":[2,3,1]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "move onto itself", patch: `[{"op":"move","from":"/b","path":"/b"}]`, want: doc},
+        {name: "move into its own child", patch: `[{"op":"move","from":"/b","path":"/b/d"}]`, wantErr: ErrInvalidPatch},
+        {name: "move from a missing member", patch: `[{"op":"move","from":"/x","path":"/y"}]`, wantErr: ErrPathNotFound},
+
+        // copy
+        {name: "copy is independent of the source", patch: `[{"op":"copy","from":"/b","path":"/d"},{"op":"replace","path":"/d/c/0","value":7}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"d":{"c":[7,2,3]},"a/b":"slash","m~n":"tilde","n":null}`},
+        {name: "copy into its own child", patch: `[{"op":"copy","from":"/b","path":"/b/d"}]`,
+            want: `{"a":1,"b":{"c":[1,2,3],"d":{"c":[1,2,3]}},"a/b":"slash","m~n":"tilde","n":null}`},
+
+        // test
+        {name: "test equal value", patch: `[{"op":"test","path":"/b","value":{"c":[1,2,3]}}]`, want: doc},
+        {name: "test compares numbers by value", patch: `[{"op":"test","path":"/a","value":1.0}]`, want: doc},
+        {name: "test null", patch: `[{"op":"test","path":"/n","value":null}]`, want: doc},
+        {name: "test different value", patch: `[{"op":"test","path":"/a","value":"1"}]`, wantErr: ErrTestFailed},
+        {name: "test array order matters", patch: `[{"op":"test","path":"/b/c","value":[3,2,1]}]`, wantErr: ErrTestFailed},
+        {name: "test missing member", patch: `[{"op":"test","path":"/x","value":null}]`, wantErr: ErrPathNotFound},
+
+        // JSON Pointer
+        {name: "~1 escapes a slash", patch: `[{"op":"replace","path":"/a~1b","value":"s"}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"s","m~n":"tilde","n":null}`},
+        {name: "~0 escapes a tilde", patch: `[{"op":"remove","path":"/m~0n"}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","n":null}`},
+        {name: "~01 is ~1, not /", patch: `[{"op":"add","path":"/~01","value":0}]`,
+            want: `{"a":1,"b":{"c":[1,2,3]},"a/b":"slash","m~n":"tilde","n":null,"~1":0}`},
+
+        // atomicidade
+        {name: "a failing operation discards the earlier ones", patch: `[{"op":"remove","path":"/a"},{"op":"test","path":"/a","value":1}]`, wantErr: ErrPathNotFound},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            p, err := DecodePatch([]byte(tt.patch))
+            if err != nil {
+                t.Fatalf("DecodePatch: %v", err)
+            }
+            got, err := p.Apply([]byte(doc))
+            if tt.wantErr != nil {
+                var opErr *OperationError
+                if !errors.Is(err, tt.wantErr) || !errors.As(err, &opErr) {
+                    t.Fatalf("Apply() = %s, %v; want an *OperationError wrapping %v", got, err, tt.wantErr)
+                }
+                if got != nil {
+                    t.Errorf("Apply() returned %s along with the error", got)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Apply: %v", err)
+            }
+            if !sameJSON(t, got, tt.want) {
+                t.Errorf("Apply() = %s, want %s", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestPatchApplyReportsTheFailingOperation(t *testing.T) {
+    p, _ := DecodePatch([]byte(`[{"op":"test","path":"/a","value":1},{"op":"test","path":"/a","value":2}]`))
+    _, err := p.Apply([]byte(doc))
+    var opErr *OperationError
+    if !errors.As(err, &opErr) || opErr.Index != 1 || opErr.Op.Path != "/a" {
+        t.Fatalf("Apply() = %v, want the second operation", err)
+    }
+}
+
+func TestDecodePatch(t *testing.T) {
+    tests := []struct {
+        name  string
+        patch string
+        ok    bool
+    }{
+        {"null value is a value", `[{"op":"add","path":"/x","value":null}]`, true},
+        {"absent value", `[{"op":"add","path":"/x"}]`, false},
+        {"absent value in replace", `[{"op":"replace","path":"/x"}]`, false},
+        {"absent value in test", `[{"op":"test","path":"/x"}]`, false},
+        {"remove needs no value", `[{"op":"remove","path":"/x"}]`, true},
+        {"unknown op", `[{"op":"merge","path":"/x","value":1}]`, false},
+        {"pointer without leading slash", `[{"op":"remove","path":"x"}]`, false},
+        {"bad escape", `[{"op":"remove","path":"/a~2"}]`, false},
+        {"bad from", `[{"op":"copy","from":"a","path":"/x"}]`, false},
+        {"not an array", `{"op":"remove","path":"/x"}`, false},
+        {"empty patch", `[]`, true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := DecodePatch([]byte(tt.patch))
+            if tt.ok && err != nil {
+                t.Errorf("DecodePatch() = %v", err)
+            }
+            if !tt.ok && !errors.Is(err, ErrInvalidPatch) {
+                t.Errorf("DecodePatch() = %v, want ErrInvalidPatch", err)
+            }
+        })
+    }
+}
+
+func TestMergePatch(t *testing.T) {
+    tests := []struct {
+        name  string
+        doc   string
+        patch string
+        want  string
+    }{
+        {"null deletes a member", `{"a":1,"b":2}`, `{"a":null}`, `{"b":2}`},
+        {"null on a missing member", `{"a":1}`, `{"x":null}`, `{"a":1}`},
+        {"objects merge recursively", `{"a":{"b":1,"c":2}}`, `{"a":{"b":3,"d":null,"c":null}}`, `{"a":{"b":3}}`},
+        {"arrays are replaced", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+        {"object replaces a scalar", `{"a":1}`, `{"a":{"b":null,"c":1}}`, `{"a":{"c":1}}`},
+        {"non-object patch replaces the document", `{"a":1}`, `["x"]`, `["x"]`},
+        {"scalar patch replaces the document", `{"a":1}`, `"x"`, `"x"`},
+        {"null patch replaces the document", `{"a":1}`, `null`, `null`},
+        {"object patch on a non-object document", `[1]`, `{"a":1}`, `{"a":1}`},
+        {"empty patch keeps the document", `{"a":1}`, `{}`, `{"a":1}`},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+            if err != nil {
+                t.Fatalf("MergePatch: %v", err)
+            }
+            if !sameJSON(t, got, tt.want) {
+                t.Errorf("MergePatch() = %s, want %s", got, tt.want)
+            }
+        })
+    }
+
+    if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+        t.Errorf("MergePatch(malformed) = %v, want ErrInvalidPatch", err)
+    }
+}
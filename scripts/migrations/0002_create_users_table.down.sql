@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_user_created_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS user_id;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_search;
+DROP INDEX IF EXISTS idx_tasks_user_updated_at;
+DROP INDEX IF EXISTS idx_tasks_user_due_date;
+DROP INDEX IF EXISTS idx_tasks_user_priority;
+DROP INDEX IF EXISTS idx_tasks_user_status;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'todo'
+        CHECK (status IN ('todo', 'in_progress', 'blocked', 'done', 'cancelled')),
+    ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 2
+        CHECK (priority BETWEEN 1 AND 4);
+
+UPDATE tasks SET status = 'done' WHERE completed;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_status ON tasks (user_id, status);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_priority ON tasks (user_id, priority);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date ON tasks (user_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_updated_at ON tasks (user_id, updated_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks
+    USING GIN (to_tsvector('simple', title || ' ' || coalesce(description, '')));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_project_created_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
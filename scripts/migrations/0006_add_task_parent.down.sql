@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_parent;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_parent_not_self;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,6 @@
+-- Subtarefas são removidas junto com a tarefa pai
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+    ADD CONSTRAINT tasks_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent ON tasks (parent_id);
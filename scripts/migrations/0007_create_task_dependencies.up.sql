@@ -0,0 +1,11 @@
+-- task_id só pode começar depois que depends_on_id terminar
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, depends_on_id),
+    CONSTRAINT task_dependencies_not_self CHECK (task_id <> depends_on_id)
+);
+
+-- Busca no sentido inverso (quem depende de uma task)
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies (depends_on_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_tasks_recurrence_occurrence;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS recurrence_index,
+    DROP COLUMN IF EXISTS recurrence_start,
+    DROP COLUMN IF EXISTS recurrence_series_id,
+    DROP COLUMN IF EXISTS recurrence_timezone,
+    DROP COLUMN IF EXISTS recurrence_rule;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
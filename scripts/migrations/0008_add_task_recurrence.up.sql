@@ -0,0 +1,15 @@
+-- Fuso do usuário, usado no cálculo das ocorrências de tasks recorrentes
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Cada ocorrência de uma série é uma task; series_id é o ID da primeira
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS recurrence_rule TEXT,
+    ADD COLUMN IF NOT EXISTS recurrence_timezone TEXT,
+    ADD COLUMN IF NOT EXISTS recurrence_series_id UUID,
+    ADD COLUMN IF NOT EXISTS recurrence_start TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS recurrence_index INTEGER;
+
+-- Garante que concluir a mesma ocorrência duas vezes não gere duas seguintes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_recurrence_occurrence
+    ON tasks (recurrence_series_id, recurrence_index)
+    WHERE recurrence_series_id IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Lembrete de uma task: em horário fixo (remind_at) ou minutos antes do vencimento (before_minutes)
+CREATE TABLE IF NOT EXISTS reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    before_minutes INTEGER,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMP WITH TIME ZONE,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT reminders_one_trigger CHECK ((remind_at IS NULL) <> (before_minutes IS NULL)),
+    CONSTRAINT reminders_before_positive CHECK (before_minutes IS NULL OR before_minutes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_task ON reminders (task_id);
+
+-- Varredura do scheduler: apenas lembretes ainda não entregues nem abandonados
+CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders (task_id)
+    WHERE sent_at IS NULL AND failed_at IS NULL;
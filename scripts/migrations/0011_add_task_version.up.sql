@@ -0,0 +1,2 @@
+-- Controle de concorrência otimista: incrementado a cada alteração da tarefa
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,21 @@
+-- Respostas guardadas por Idempotency-Key, por usuário; status NULL indica
+-- que a requisição original ainda está em andamento. token identifica a
+-- reserva: uma réplica cuja reserva foi retomada por outra (lease vencido)
+-- não grava nem apaga a resposta da nova dona
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    token TEXT NOT NULL,
+    method TEXT NOT NULL,
+    path TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status INTEGER,
+    headers JSONB,
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+-- Coleta das chaves expiradas
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,30 @@
+-- Eventos de Task gravados na mesma transação da mudança; o relay os publica
+-- nos sinks em ordem de id por task e marca published_at. delivered_sinks
+-- guarda os sinks que já receberam o evento: depois de uma falha parcial, a
+-- nova tentativa entrega só aos que faltam
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL,
+    event_id UUID NOT NULL UNIQUE,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    delivered_sinks TEXT[] NOT NULL DEFAULT '{}',
+    last_error TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Varredura do relay: eventos pendentes, do mais antigo ao mais novo de cada task
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (task_id, id)
+    WHERE published_at IS NULL;
+
+-- Coleta dos eventos publicados
+CREATE INDEX IF NOT EXISTS idx_outbox_published ON outbox (published_at)
+    WHERE published_at IS NOT NULL;
+
+-- O relay entrega pelo menos uma vez: republicar um evento não duplica as
+-- entregas de webhook
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (webhook_id, event_id);
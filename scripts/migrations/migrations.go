@@ -0,0 +1,11 @@
+// Package migrations embute os arquivos SQL de schema no binário.
+//
+// Convenção de nomes: NNNN_descricao.up.sql e NNNN_descricao.down.sql.
+package migrations
+
+import "embed"
+
+// FS contém todos os arquivos .sql deste diretório.
+//
+//go:embed *.sql
+var FS embed.FS